@@ -15,14 +15,22 @@ var (
 	vulnerabilityReportsCRD []byte
 	//go:embed deploy/crd/clustervulnerabilityreports.crd.yaml
 	clusterVulnerabilityReportsCRD []byte
+	//go:embed deploy/crd/sbomreports.crd.yaml
+	sbomReportsCRD []byte
+	//go:embed deploy/crd/vulnerabilityexceptions.crd.yaml
+	vulnerabilityExceptionsCRD []byte
 	//go:embed deploy/crd/configauditreports.crd.yaml
 	configAuditReportsCRD []byte
 	//go:embed deploy/crd/clusterconfigauditreports.crd.yaml
 	clusterConfigAuditReportsCRD []byte
+	//go:embed deploy/crd/misconfigurationreports.crd.yaml
+	misconfigurationReportsCRD []byte
 	//go:embed deploy/crd/clustercompliancereports.crd.yaml
 	clusterComplianceReportsCRD []byte
 	//go:embed deploy/crd/clustercompliancedetailreports.crd.yaml
 	clusterComplianceDetailReportsCRD []byte
+	//go:embed deploy/crd/complianceattestations.crd.yaml
+	complianceAttestationsCRD []byte
 	//go:embed deploy/crd/ciskubebenchreports.crd.yaml
 	kubeBenchReportsCRD []byte
 	//go:embed deploy/crd/kubehunterreports.crd.yaml
@@ -32,6 +40,12 @@ var (
 
 	//go:embed deploy/specs/nsa-1.0.yaml
 	nsaSpecV10 []byte
+	//go:embed deploy/specs/cis-1.23.yaml
+	cisSpecV123 []byte
+	//go:embed deploy/specs/pss-baseline-0.1.yaml
+	pssBaselineSpecV01 []byte
+	//go:embed deploy/specs/pss-restricted-0.1.yaml
+	pssRestrictedSpecV01 []byte
 )
 
 func PoliciesConfigMap() (corev1.ConfigMap, error) {
@@ -51,6 +65,14 @@ func GetClusterVulnerabilityReportsCRD() (apiextensionsv1.CustomResourceDefiniti
 	return getCRDFromBytes(clusterVulnerabilityReportsCRD)
 }
 
+func GetSBOMReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(sbomReportsCRD)
+}
+
+func GetVulnerabilityExceptionsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(vulnerabilityExceptionsCRD)
+}
+
 func GetConfigAuditReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
 	return getCRDFromBytes(configAuditReportsCRD)
 }
@@ -59,6 +81,10 @@ func GetClusterConfigAuditReportsCRD() (apiextensionsv1.CustomResourceDefinition
 	return getCRDFromBytes(clusterConfigAuditReportsCRD)
 }
 
+func GetMisconfigurationReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(misconfigurationReportsCRD)
+}
+
 func GetClusterComplianceReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
 	return getCRDFromBytes(clusterComplianceReportsCRD)
 }
@@ -67,6 +93,10 @@ func GetClusterComplianceDetailReportsCRD() (apiextensionsv1.CustomResourceDefin
 	return getCRDFromBytes(clusterComplianceDetailReportsCRD)
 }
 
+func GetComplianceAttestationsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
+	return getCRDFromBytes(complianceAttestationsCRD)
+}
+
 func GetCISKubeBenchReportsCRD() (apiextensionsv1.CustomResourceDefinition, error) {
 	return getCRDFromBytes(kubeBenchReportsCRD)
 }
@@ -79,6 +109,36 @@ func GetNSASpecV10() (v1alpha1.ClusterComplianceReport, error) {
 	return getComplianceSpec(nsaSpecV10)
 }
 
+func GetCISSpecV123() (v1alpha1.ClusterComplianceReport, error) {
+	return getComplianceSpec(cisSpecV123)
+}
+
+func GetPSSBaselineSpecV01() (v1alpha1.ClusterComplianceReport, error) {
+	return getComplianceSpec(pssBaselineSpecV01)
+}
+
+func GetPSSRestrictedSpecV01() (v1alpha1.ClusterComplianceReport, error) {
+	return getComplianceSpec(pssRestrictedSpecV01)
+}
+
+// GetComplianceSpecs returns all bundled compliance specs.
+func GetComplianceSpecs() ([]v1alpha1.ClusterComplianceReport, error) {
+	var specs []v1alpha1.ClusterComplianceReport
+	for _, get := range []func() (v1alpha1.ClusterComplianceReport, error){
+		GetNSASpecV10,
+		GetCISSpecV123,
+		GetPSSBaselineSpecV01,
+		GetPSSRestrictedSpecV01,
+	} {
+		spec, err := get()
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
 func getCRDFromBytes(bytes []byte) (apiextensionsv1.CustomResourceDefinition, error) {
 	var crd apiextensionsv1.CustomResourceDefinition
 	_, _, err := scheme.Codecs.UniversalDecoder().Decode(bytes, nil, &crd)
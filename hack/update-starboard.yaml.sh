@@ -6,11 +6,15 @@ CRD_DIR=$SCRIPT_ROOT/deploy/crd
 STATIC_DIR=$SCRIPT_ROOT/deploy/static
 
 cat $CRD_DIR/vulnerabilityreports.crd.yaml \
+  $CRD_DIR/sbomreports.crd.yaml \
+  $CRD_DIR/vulnerabilityexceptions.crd.yaml \
   $CRD_DIR/configauditreports.crd.yaml \
   $CRD_DIR/clusterconfigauditreports.crd.yaml \
+  $CRD_DIR/misconfigurationreports.crd.yaml \
   $CRD_DIR/ciskubebenchreports.crd.yaml \
   $CRD_DIR/clustercompliancereports.crd.yaml \
   $CRD_DIR/clustercompliancedetailreports.crd.yaml \
+  $CRD_DIR/complianceattestations.crd.yaml \
   $STATIC_DIR/01-starboard-operator.ns.yaml \
   $STATIC_DIR/02-starboard-operator.rbac.yaml \
   $STATIC_DIR/03-starboard-operator.config.yaml \
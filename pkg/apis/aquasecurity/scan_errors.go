@@ -0,0 +1,244 @@
+package aquasecurity
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ScanErrorCategory groups scanner errors by their cause.
+type ScanErrorCategory string
+
+const (
+	ScanErrorRegistryAuth    ScanErrorCategory = "RegistryAuth"
+	ScanErrorImageNotFound   ScanErrorCategory = "ImageNotFound"
+	ScanErrorDBDownload      ScanErrorCategory = "DBDownload"
+	ScanErrorTimeout         ScanErrorCategory = "Timeout"
+	ScanErrorResourceSkipped ScanErrorCategory = "ResourceSkipped"
+	ScanErrorOther           ScanErrorCategory = "Other"
+)
+
+// ScanError is a categorized error reported by the scanner. Errors reported
+// for a resource refer to it by Namespace, Kind and Name, whereas errors
+// printed before the report, such as DB download failures, leave them empty.
+type ScanError struct {
+	Category  ScanErrorCategory
+	Namespace string
+	Kind      string
+	Name      string
+	// Image is the image reference the error concerns, if it can be told
+	// from the message.
+	Image   string
+	Message string
+}
+
+// ScanErrorSummary counts scanner errors by category.
+type ScanErrorSummary struct {
+	RegistryAuthCount    int
+	ImageNotFoundCount   int
+	DBDownloadCount      int
+	TimeoutCount         int
+	ResourceSkippedCount int
+	OtherCount           int
+}
+
+// Total returns the number of scanner errors.
+func (s ScanErrorSummary) Total() int {
+	return s.RegistryAuthCount + s.ImageNotFoundCount + s.DBDownloadCount +
+		s.TimeoutCount + s.ResourceSkippedCount + s.OtherCount
+}
+
+// Patterns are matched in order against the lower cased message, so the more
+// specific categories come first. For example, a timeout while pulling the
+// DB is reported as a DB download failure. HTTP status codes are only matched
+// next to the word status or their reason phrase, because image tags and
+// digests contain arbitrary numbers.
+var scanErrorPatterns = []struct {
+	category ScanErrorCategory
+	patterns []*regexp.Regexp
+}{
+	{
+		category: ScanErrorDBDownload,
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`\bdb (?:download|error)\b`),
+			regexp.MustCompile(`\bdownload(?:ing)? (?:the )?(?:vulnerability |java |policy )?(?:db|database)\b`),
+			regexp.MustCompile(`\b(?:vulnerability|java) (?:db|database)\b`),
+			regexp.MustCompile(`\bdatabase download\b`),
+			regexp.MustCompile(`\btrivy-(?:java-)?db\b`),
+		},
+	},
+	{
+		category: ScanErrorRegistryAuth,
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`\bunauthorized\b`),
+			regexp.MustCompile(`\bauthentication required\b`),
+			regexp.MustCompile(`\bno basic auth credentials\b`),
+			regexp.MustCompile(`\bdenied: `),
+			regexp.MustCompile(`\baccess to the resource is denied\b`),
+			regexp.MustCompile(`\bstatus(?: code)?:? 40[13]\b`),
+			regexp.MustCompile(`\b403 forbidden\b`),
+		},
+	},
+	{
+		category: ScanErrorImageNotFound,
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`\bmanifest[_ ]unknown\b`),
+			regexp.MustCompile(`\bname[_ ]unknown\b`),
+			regexp.MustCompile(`\bno such image\b`),
+			regexp.MustCompile(`\b(?:image|manifest|repository)(?: \S+)? not found\b`),
+			regexp.MustCompile(`\brepository(?: \S+)? does not exist\b`),
+			regexp.MustCompile(`\bstatus(?: code)?:? 404\b`),
+			regexp.MustCompile(`\b404 not found\b`),
+		},
+	},
+	{
+		category: ScanErrorTimeout,
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`\bdeadline exceeded\b`),
+			regexp.MustCompile(`\btimeout\b`),
+			regexp.MustCompile(`\btimed out\b`),
+		},
+	},
+	{
+		category: ScanErrorResourceSkipped,
+		patterns: []*regexp.Regexp{
+			regexp.MustCompile(`\bskip(?:ped|ping)?\b`),
+			regexp.MustCompile(`\bnot supported\b`),
+			regexp.MustCompile(`\bunsupported\b`),
+		},
+	},
+}
+
+var (
+	imageInParenthesesRegexp = regexp.MustCompile(`image \(([^)\s]+)\)`)
+	imageInQuotesRegexp      = regexp.MustCompile(`image "([^"\s]+)"`)
+	logLineRegexp            = regexp.MustCompile(`^\S+\s+(DEBUG|INFO|WARN|ERROR|FATAL)\s+(.*)$`)
+)
+
+// ClassifyScanError returns the ScanErrorCategory of the given error message.
+func ClassifyScanError(message string) ScanErrorCategory {
+	lower := strings.ToLower(message)
+	for _, candidate := range scanErrorPatterns {
+		for _, pattern := range candidate.patterns {
+			if pattern.MatchString(lower) {
+				return candidate.category
+			}
+		}
+	}
+	return ScanErrorOther
+}
+
+// NewScanError returns the categorized ScanError for the given message.
+func NewScanError(message string) ScanError {
+	scanError := ScanError{
+		Category: ClassifyScanError(message),
+		Message:  message,
+	}
+	if match := imageInParenthesesRegexp.FindStringSubmatch(message); match != nil {
+		scanError.Image = match[1]
+	} else if match := imageInQuotesRegexp.FindStringSubmatch(message); match != nil {
+		scanError.Image = match[1]
+	}
+	return scanError
+}
+
+// ParseLogErrors returns the errors found in the text printed by the scanner
+// before the report. Log lines below the ERROR level are ignored, whereas
+// lines without a level are taken as errors.
+func ParseLogErrors(text string) []ScanError {
+	var scanErrors []ScanError
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if match := logLineRegexp.FindStringSubmatch(line); match != nil {
+			if match[1] != "ERROR" && match[1] != "FATAL" {
+				continue
+			}
+			line = strings.TrimSpace(match[2])
+		}
+		scanErrors = append(scanErrors, NewScanError(line))
+	}
+	return scanErrors
+}
+
+// ScanError returns the categorized error of the resource, or nil if the
+// resource was scanned successfully.
+func (r K8SResourceVulnerability) ScanError() *ScanError {
+	return newResourceScanError(r.Namespace, r.Kind, r.Name, r.Error)
+}
+
+// ScanError returns the categorized error of the resource, or nil if the
+// resource was scanned successfully.
+func (r K8SResourceMisconfiguration) ScanError() *ScanError {
+	return newResourceScanError(r.Namespace, r.Kind, r.Name, r.Error)
+}
+
+func newResourceScanError(namespace, kind, name, message string) *ScanError {
+	if strings.TrimSpace(message) == "" {
+		return nil
+	}
+	scanError := NewScanError(message)
+	scanError.Namespace = namespace
+	scanError.Kind = kind
+	scanError.Name = name
+	return &scanError
+}
+
+// ParseScanErrors sets ScanErrors and ErrorSummary from the raw Errors text
+// and the errors reported for each resource of the report.
+func (r *TrivyReport) ParseScanErrors() {
+	r.ScanErrors = ParseLogErrors(r.Errors)
+	if r.Report != nil {
+		for _, resource := range r.Report.Vulnerabilities {
+			if scanError := resource.ScanError(); scanError != nil {
+				r.ScanErrors = append(r.ScanErrors, *scanError)
+			}
+		}
+		for _, resource := range r.Report.Misconfigurations {
+			if scanError := resource.ScanError(); scanError != nil {
+				r.ScanErrors = append(r.ScanErrors, *scanError)
+			}
+		}
+	}
+	r.ErrorSummary = SummarizeScanErrors(r.ScanErrors)
+}
+
+// FailedImages returns the sorted, unique references of images that could
+// not be scanned, so that callers can retry them.
+func (r *TrivyReport) FailedImages() []string {
+	seen := make(map[string]bool)
+	var images []string
+	for _, scanError := range r.ScanErrors {
+		if scanError.Image == "" || seen[scanError.Image] {
+			continue
+		}
+		seen[scanError.Image] = true
+		images = append(images, scanError.Image)
+	}
+	sort.Strings(images)
+	return images
+}
+
+// SummarizeScanErrors counts the given errors by category.
+func SummarizeScanErrors(scanErrors []ScanError) ScanErrorSummary {
+	var summary ScanErrorSummary
+	for _, scanError := range scanErrors {
+		switch scanError.Category {
+		case ScanErrorRegistryAuth:
+			summary.RegistryAuthCount++
+		case ScanErrorImageNotFound:
+			summary.ImageNotFoundCount++
+		case ScanErrorDBDownload:
+			summary.DBDownloadCount++
+		case ScanErrorTimeout:
+			summary.TimeoutCount++
+		case ScanErrorResourceSkipped:
+			summary.ResourceSkippedCount++
+		default:
+			summary.OtherCount++
+		}
+	}
+	return summary
+}
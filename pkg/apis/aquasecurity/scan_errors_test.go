@@ -0,0 +1,193 @@
+package aquasecurity_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyScanError(t *testing.T) {
+	testCases := []struct {
+		message  string
+		expected aquasecurity.ScanErrorCategory
+	}{
+		{
+			message:  "unable to inspect the image (registry.example.com/app:1.0): GET https://registry.example.com/v2/app/manifests/1.0: UNAUTHORIZED: authentication required",
+			expected: aquasecurity.ScanErrorRegistryAuth,
+		},
+		{
+			message:  "unable to inspect the image (nginx:0.0.0): MANIFEST_UNKNOWN: manifest unknown",
+			expected: aquasecurity.ScanErrorImageNotFound,
+		},
+		{
+			message:  "init error: DB error: failed to download vulnerability DB: database download error",
+			expected: aquasecurity.ScanErrorDBDownload,
+		},
+		{
+			message:  "scan error: context deadline exceeded",
+			expected: aquasecurity.ScanErrorTimeout,
+		},
+		{
+			message:  "resource kind CronJob is not supported",
+			expected: aquasecurity.ScanErrorResourceSkipped,
+		},
+		{
+			message:  "something went wrong",
+			expected: aquasecurity.ScanErrorOther,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.expected), func(t *testing.T) {
+			assert.Equal(t, tc.expected, aquasecurity.ClassifyScanError(tc.message))
+		})
+	}
+}
+
+func TestClassifyScanError_Counterexamples(t *testing.T) {
+	testCases := []struct {
+		name     string
+		message  string
+		expected aquasecurity.ScanErrorCategory
+	}{
+		{
+			name:     "Should not take status code in image tag for registry auth",
+			message:  "unable to inspect the image (quay.io/app:1.4013): MANIFEST_UNKNOWN: manifest unknown",
+			expected: aquasecurity.ScanErrorImageNotFound,
+		},
+		{
+			name:     "Should not take image tag equal to status code for registry auth",
+			message:  "unable to inspect the image (gcr.io/x/y:403): connection refused",
+			expected: aquasecurity.ScanErrorOther,
+		},
+		{
+			name:     "Should not take status code in image digest for registry auth",
+			message:  "unable to inspect the image (nginx@sha256:4013f1c8e6d8d7ba46c0bd5b3e2e8a7c4d9b4c8f3f2d4b4036b8e1d0f7a0c403): connection reset by peer",
+			expected: aquasecurity.ScanErrorOther,
+		},
+		{
+			name:     "Should classify unauthorized status code",
+			message:  "unable to inspect the image (registry.example.com/app:1.0): GET https://registry.example.com/v2/app/manifests/1.0: unexpected status code 401 Unauthorized (HEAD responses have no body, use GET for details)",
+			expected: aquasecurity.ScanErrorRegistryAuth,
+		},
+		{
+			name:     "Should classify forbidden status code",
+			message:  "unable to inspect the image (gcr.io/x/y:1.0): GET https://gcr.io/v2/x/y/manifests/1.0: unexpected status code 403 Forbidden",
+			expected: aquasecurity.ScanErrorRegistryAuth,
+		},
+		{
+			name:     "Should classify registry denied error",
+			message:  "unable to inspect the image (docker.io/private/app:1.0): DENIED: requested access to the resource is denied",
+			expected: aquasecurity.ScanErrorRegistryAuth,
+		},
+		{
+			name:     "Should not take layer download failure for DB download",
+			message:  "failed to download layer sha256:0b4ad5a3b6f6: GET https://registry.example.com/v2/app/blobs/sha256:0b4ad5a3b6f6: unexpected status code 401 Unauthorized",
+			expected: aquasecurity.ScanErrorRegistryAuth,
+		},
+		{
+			name:     "Should not take layer download failure without cause for DB download",
+			message:  "failed to download layer sha256:0b4ad5a3b6f6: connection reset by peer",
+			expected: aquasecurity.ScanErrorOther,
+		},
+		{
+			name:     "Should not take file permission error for registry auth",
+			message:  "open /home/scanner/.cache/fanal/fanal.db: permission denied",
+			expected: aquasecurity.ScanErrorOther,
+		},
+		{
+			name:     "Should not take missing secret for missing image",
+			message:  "secret \"registry-credentials\" not found",
+			expected: aquasecurity.ScanErrorOther,
+		},
+		{
+			name:     "Should classify missing repository",
+			message:  "unable to inspect the image (registry.example.com/missing:1.0): GET https://registry.example.com/v2/missing/manifests/1.0: unexpected status code 404 Not Found",
+			expected: aquasecurity.ScanErrorImageNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, aquasecurity.ClassifyScanError(tc.message))
+		})
+	}
+}
+
+func TestParseLogErrors(t *testing.T) {
+	scanErrors := aquasecurity.ParseLogErrors("2022-08-01T10:00:00.000Z\tINFO\tNeed to update DB\n" +
+		"2022-08-01T10:00:01.000Z\tWARN\tunable to get cluster version\n" +
+		"2022-08-01T10:00:02.000Z\tFATAL\tinit error: DB error: failed to download vulnerability DB\n" +
+		"\n" +
+		"panic: runtime error\n")
+	assert.Equal(t, []aquasecurity.ScanError{
+		{
+			Category: aquasecurity.ScanErrorDBDownload,
+			Message:  "init error: DB error: failed to download vulnerability DB",
+		},
+		{
+			Category: aquasecurity.ScanErrorOther,
+			Message:  "panic: runtime error",
+		},
+	}, scanErrors)
+}
+
+func TestTrivyReport_ParseScanErrors(t *testing.T) {
+	report := &aquasecurity.TrivyReport{
+		Errors: "2022-08-01T10:00:00.000Z\tERROR\tscan error: context deadline exceeded\n",
+		Report: &aquasecurity.ScanReport{
+			Vulnerabilities: []aquasecurity.K8SResourceVulnerability{
+				{
+					Namespace: "default",
+					Kind:      "Deployment",
+					Name:      "app",
+					Error:     "unable to inspect the image (registry.example.com/app:1.0): UNAUTHORIZED: authentication required",
+				},
+				{
+					Namespace: "default",
+					Kind:      "Deployment",
+					Name:      "nginx",
+				},
+			},
+			Misconfigurations: []aquasecurity.K8SResourceMisconfiguration{
+				{
+					Namespace: "default",
+					Kind:      "Deployment",
+					Name:      "web",
+					Error:     `unable to inspect the image "nginx:0.0.0": MANIFEST_UNKNOWN`,
+				},
+			},
+		},
+	}
+	report.ParseScanErrors()
+
+	assert.Nil(t, report.Report.Vulnerabilities[1].ScanError())
+	assert.Equal(t, []aquasecurity.ScanError{
+		{
+			Category: aquasecurity.ScanErrorTimeout,
+			Message:  "scan error: context deadline exceeded",
+		},
+		{
+			Category:  aquasecurity.ScanErrorRegistryAuth,
+			Namespace: "default",
+			Kind:      "Deployment",
+			Name:      "app",
+			Image:     "registry.example.com/app:1.0",
+			Message:   "unable to inspect the image (registry.example.com/app:1.0): UNAUTHORIZED: authentication required",
+		},
+		{
+			Category:  aquasecurity.ScanErrorImageNotFound,
+			Namespace: "default",
+			Kind:      "Deployment",
+			Name:      "web",
+			Image:     "nginx:0.0.0",
+			Message:   `unable to inspect the image "nginx:0.0.0": MANIFEST_UNKNOWN`,
+		},
+	}, report.ScanErrors)
+	assert.Equal(t, aquasecurity.ScanErrorSummary{
+		RegistryAuthCount:  1,
+		ImageNotFoundCount: 1,
+		TimeoutCount:       1,
+	}, report.ErrorSummary)
+	assert.Equal(t, 3, report.ErrorSummary.Total())
+	assert.Equal(t, []string{"nginx:0.0.0", "registry.example.com/app:1.0"}, report.FailedImages())
+}
@@ -1,8 +1,14 @@
 package aquasecurity
 
+import "time"
+
 type TrivyReport struct {
 	Report *ScanReport
+	// Errors is the raw text printed by the scanner before the report.
 	Errors string
+	// ScanErrors and ErrorSummary are set by ParseScanErrors.
+	ScanErrors   []ScanError
+	ErrorSummary ScanErrorSummary
 }
 
 type ScanReport struct {
@@ -26,15 +32,61 @@ type VulnerabilityScanResult struct {
 }
 
 type Vulnerability struct {
-	VulnerabilityID  string `json:"VulnerabilityID"`
-	PkgName          string `json:"PkgName"`
-	InstalledVersion string `json:"InstalledVersion"`
-	FixedVersion     string `json:"FixedVersion"`
-	Severity         string `json:"Severity"`
+	VulnerabilityID  string           `json:"VulnerabilityID"`
+	PkgName          string           `json:"PkgName"`
+	InstalledVersion string           `json:"InstalledVersion"`
+	FixedVersion     string           `json:"FixedVersion"`
+	Severity         string           `json:"Severity"`
+	Title            string           `json:"Title,omitempty"`
+	Description      string           `json:"Description,omitempty"`
+	PrimaryURL       string           `json:"PrimaryURL,omitempty"`
+	References       []string         `json:"References,omitempty"`
+	CVSS             map[string]*CVSS `json:"CVSS,omitempty"`
+	PublishedDate    *time.Time       `json:"PublishedDate,omitempty"`
+	LastModifiedDate *time.Time       `json:"LastModifiedDate,omitempty"`
+
+	// KnownExploited, KnownExploitedDateAdded and ExploitProbability are not
+	// reported by Trivy, but set by Starboard from the exploit catalog.
+	KnownExploited          bool     `json:"KnownExploited,omitempty"`
+	KnownExploitedDateAdded string   `json:"KnownExploitedDateAdded,omitempty"`
+	ExploitProbability      *float64 `json:"ExploitProbability,omitempty"`
+}
+
+// Score returns the CVSS v3 score of the vulnerability, see GetScoreFromCVSS.
+func (v Vulnerability) Score() *float64 {
+	return GetScoreFromCVSS(v.CVSS)
 }
 
+// CVSS holds the scores and vectors reported by a single CVSS source, such as
+// nvd or a vendor.
 type CVSS struct {
-	V3Score *float64 `json:"V3Score,omitempty"`
+	V2Vector string   `json:"V2Vector,omitempty"`
+	V3Vector string   `json:"V3Vector,omitempty"`
+	V2Score  *float64 `json:"V2Score,omitempty"`
+	V3Score  *float64 `json:"V3Score,omitempty"`
+}
+
+// GetScoreFromCVSS returns the CVSS v3 score of a vendor if there is one, and
+// the nvd score otherwise.
+func GetScoreFromCVSS(CVSSs map[string]*CVSS) *float64 {
+	var nvdScore, vendorScore *float64
+
+	for name, cvss := range CVSSs {
+		if cvss == nil {
+			continue
+		}
+		if name == "nvd" {
+			nvdScore = cvss.V3Score
+		} else {
+			vendorScore = cvss.V3Score
+		}
+	}
+
+	if vendorScore != nil {
+		return vendorScore
+	}
+
+	return nvdScore
 }
 
 type K8SResourceMisconfiguration struct {
@@ -53,8 +105,28 @@ type MisconfigurationScanResult struct {
 }
 
 type Misconfiguration struct {
-	ID        string
-	Status    string
-	Severity  string
-	Namespace string
+	Type        string   `json:"Type,omitempty"`
+	ID          string   `json:"ID"`
+	AVDID       string   `json:"AVDID,omitempty"`
+	Title       string   `json:"Title,omitempty"`
+	Description string   `json:"Description,omitempty"`
+	Message     string   `json:"Message,omitempty"`
+	Namespace   string   `json:"Namespace,omitempty"`
+	Resolution  string   `json:"Resolution,omitempty"`
+	Severity    string   `json:"Severity"`
+	PrimaryURL  string   `json:"PrimaryURL,omitempty"`
+	References  []string `json:"References,omitempty"`
+	Status      string   `json:"Status"`
+
+	CauseMetadata *CauseMetadata `json:"CauseMetadata,omitempty"`
+}
+
+// CauseMetadata points to the part of the scanned resource that caused a
+// misconfiguration.
+type CauseMetadata struct {
+	Resource  string `json:"Resource,omitempty"`
+	Provider  string `json:"Provider,omitempty"`
+	Service   string `json:"Service,omitempty"`
+	StartLine int    `json:"StartLine,omitempty"`
+	EndLine   int    `json:"EndLine,omitempty"`
 }
@@ -0,0 +1,110 @@
+package aquasecurity_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/pointer"
+)
+
+func TestVulnerability_UnmarshalJSON(t *testing.T) {
+	var vulnerability aquasecurity.Vulnerability
+	err := json.Unmarshal([]byte(`{
+  "VulnerabilityID": "CVE-2020-1967",
+  "PkgName": "libssl1.1",
+  "InstalledVersion": "1.1.1c-r0",
+  "FixedVersion": "1.1.1g-r0",
+  "Severity": "HIGH",
+  "Title": "openssl: Segmentation fault in SSL_check_chain",
+  "Description": "Server or client applications that call the SSL_check_chain() function may crash.",
+  "PrimaryURL": "https://avd.aquasec.com/nvd/cve-2020-1967",
+  "References": ["https://www.openssl.org/news/secadv/20200421.txt"],
+  "CVSS": {
+    "nvd": {
+      "V2Vector": "AV:N/AC:M/Au:N/C:N/I:N/A:P",
+      "V3Vector": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H",
+      "V2Score": 4.3,
+      "V3Score": 7.5
+    }
+  },
+  "PublishedDate": "2020-04-21T14:15:00Z",
+  "LastModifiedDate": "2022-04-08T10:15:00Z"
+}`), &vulnerability)
+	require.NoError(t, err)
+
+	published := time.Date(2020, time.April, 21, 14, 15, 0, 0, time.UTC)
+	lastModified := time.Date(2022, time.April, 8, 10, 15, 0, 0, time.UTC)
+	assert.Equal(t, aquasecurity.Vulnerability{
+		VulnerabilityID:  "CVE-2020-1967",
+		PkgName:          "libssl1.1",
+		InstalledVersion: "1.1.1c-r0",
+		FixedVersion:     "1.1.1g-r0",
+		Severity:         "HIGH",
+		Title:            "openssl: Segmentation fault in SSL_check_chain",
+		Description:      "Server or client applications that call the SSL_check_chain() function may crash.",
+		PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2020-1967",
+		References:       []string{"https://www.openssl.org/news/secadv/20200421.txt"},
+		CVSS: map[string]*aquasecurity.CVSS{
+			"nvd": {
+				V2Vector: "AV:N/AC:M/Au:N/C:N/I:N/A:P",
+				V3Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:H",
+				V2Score:  pointer.Float64(4.3),
+				V3Score:  pointer.Float64(7.5),
+			},
+		},
+		PublishedDate:    &published,
+		LastModifiedDate: &lastModified,
+	}, vulnerability)
+	assert.Equal(t, pointer.Float64(7.5), vulnerability.Score())
+}
+
+func TestMisconfiguration_UnmarshalJSON(t *testing.T) {
+	var misconfiguration aquasecurity.Misconfiguration
+	err := json.Unmarshal([]byte(`{
+  "Type": "Kubernetes Security Check",
+  "ID": "KSV001",
+  "AVDID": "AVD-KSV-0001",
+  "Title": "Process can elevate its own privileges",
+  "Description": "A program inside the container can elevate its own privileges and run as root.",
+  "Message": "Container 'nginx' of Deployment 'nginx' should set 'securityContext.allowPrivilegeEscalation' to false",
+  "Namespace": "builtin.kubernetes.KSV001",
+  "Query": "data.builtin.kubernetes.KSV001.deny",
+  "Resolution": "Set 'set containers[].securityContext.allowPrivilegeEscalation' to 'false'.",
+  "Severity": "MEDIUM",
+  "PrimaryURL": "https://avd.aquasec.com/misconfig/ksv001",
+  "References": ["https://avd.aquasec.com/misconfig/ksv001"],
+  "Status": "FAIL",
+  "CauseMetadata": {
+    "Provider": "Kubernetes",
+    "Service": "general",
+    "StartLine": 132,
+    "EndLine": 143
+  }
+}`), &misconfiguration)
+	require.NoError(t, err)
+
+	assert.Equal(t, aquasecurity.Misconfiguration{
+		Type:        "Kubernetes Security Check",
+		ID:          "KSV001",
+		AVDID:       "AVD-KSV-0001",
+		Title:       "Process can elevate its own privileges",
+		Description: "A program inside the container can elevate its own privileges and run as root.",
+		Message:     "Container 'nginx' of Deployment 'nginx' should set 'securityContext.allowPrivilegeEscalation' to false",
+		Namespace:   "builtin.kubernetes.KSV001",
+		Resolution:  "Set 'set containers[].securityContext.allowPrivilegeEscalation' to 'false'.",
+		Severity:    "MEDIUM",
+		PrimaryURL:  "https://avd.aquasec.com/misconfig/ksv001",
+		References:  []string{"https://avd.aquasec.com/misconfig/ksv001"},
+		Status:      "FAIL",
+		CauseMetadata: &aquasecurity.CauseMetadata{
+			Provider:  "Kubernetes",
+			Service:   "general",
+			StartLine: 132,
+			EndLine:   143,
+		},
+	}, misconfiguration)
+}
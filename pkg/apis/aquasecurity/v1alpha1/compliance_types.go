@@ -11,6 +11,11 @@ const (
 type ClusterComplianceSummary struct {
 	PassCount int `json:"passCount"`
 	FailCount int `json:"failCount"`
+	// WarnCount is the number of controls with the WARN status, i.e. controls
+	// without results and manual controls without a ComplianceAttestation.
+	WarnCount int `json:"warnCount,omitempty"`
+	// ManualCount is the number of manual controls.
+	ManualCount int `json:"manualCount,omitempty"`
 }
 
 // +genclient
@@ -31,6 +36,27 @@ type ReportSpec struct {
 	Cron        string    `json:"cron"`
 	Version     string    `json:"version"`
 	Controls    []Control `json:"controls"`
+	// Scope limits the resources evaluated by the controls. If not set,
+	// controls are evaluated against all resources in the cluster.
+	Scope *ComplianceScope `json:"scope,omitempty"`
+}
+
+// ComplianceScope selects resources evaluated by the controls of a compliance
+// spec, e.g. resources of a single tenant. A namespaced resource is in scope
+// if its namespace is listed in Namespaces and matches NamespaceSelector, and
+// if the resource matches ResourceSelector. Cluster-scoped resources, such as
+// nodes, are out of scope if namespaces are selected.
+type ComplianceScope struct {
+	// Namespaces selects namespaces by name.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector selects namespaces by labels.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// ResourceSelector selects resources by labels.
+	ResourceSelector *metav1.LabelSelector `json:"resourceSelector,omitempty"`
+	// TenantLabel is the key of the namespace label which identifies the
+	// tenant of resources in the namespace. If not set, the tenant of a
+	// resource is its namespace.
+	TenantLabel string `json:"tenantLabel,omitempty"`
 }
 
 //Control represent the cps controls data and mapping checks
@@ -47,12 +73,36 @@ type Control struct {
 //SpecCheck represent the scanner who perform the control check
 type SpecCheck struct {
 	ID string `json:"id"`
+	// Threshold selects vulnerabilities that fail the check. It's only used
+	// by the vulnerability scanner.
+	// +optional
+	Threshold *VulnerabilityThreshold `json:"threshold,omitempty"`
+}
+
+// VulnerabilityThreshold selects vulnerabilities of a workload that fail a
+// control check. A workload fails the check if any of its vulnerabilities
+// matches all conditions of the threshold.
+type VulnerabilityThreshold struct {
+	// Severity is the minimum severity of a matching vulnerability.
+	Severity Severity `json:"severity"`
+
+	// FixAvailable matches only vulnerabilities with a fixed version.
+	// +optional
+	FixAvailable bool `json:"fixAvailable,omitempty"`
+
+	// OlderThanDays matches only vulnerabilities published more than the
+	// given number of days ago. Vulnerabilities without a published date do
+	// not match.
+	// +optional
+	OlderThanDays int `json:"olderThanDays,omitempty"`
 }
 
 //Mapping represent the scanner who perform the control check
 type Mapping struct {
-	Scanner string      `json:"scanner"`
-	Checks  []SpecCheck `json:"checks"`
+	Scanner string `json:"scanner"`
+	// Checks are the scanner checks of the control. Manual controls have
+	// no checks, their status is recorded as a ComplianceAttestation.
+	Checks []SpecCheck `json:"checks,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -68,6 +118,28 @@ type ReportStatus struct {
 	UpdateTimestamp metav1.Time              `json:"updateTimestamp"`
 	Summary         ClusterComplianceSummary `json:"summary"`
 	ControlChecks   []ControlCheck           `json:"controlCheck"`
+	// History holds the most recent snapshots of the report status, the
+	// latest first.
+	History []ComplianceSnapshot `json:"history,omitempty"`
+	// Transitions holds the changes of control statuses observed within the
+	// History, the latest first.
+	Transitions []ControlTransition `json:"transitions,omitempty"`
+}
+
+// ComplianceSnapshot is the summary of a single compliance report generation.
+type ComplianceSnapshot struct {
+	UpdateTimestamp metav1.Time              `json:"updateTimestamp"`
+	Summary         ClusterComplianceSummary `json:"summary"`
+	FailedControls  []string                 `json:"failedControls,omitempty"`
+}
+
+// ControlTransition records the change of a control status between two
+// subsequent compliance report generations.
+type ControlTransition struct {
+	ID        string        `json:"id"`
+	From      ControlStatus `json:"from"`
+	To        ControlStatus `json:"to"`
+	Timestamp metav1.Time   `json:"timestamp"`
 }
 
 // ControlCheck provides the result of conducting a single audit step.
@@ -78,6 +150,9 @@ type ControlCheck struct {
 	PassTotal   int      `json:"passTotal"`
 	FailTotal   int      `json:"failTotal"`
 	Severity    Severity `json:"severity"`
+	// Status is the status of the control, WARN if the control has no
+	// results.
+	Status ControlStatus `json:"status,omitempty"`
 }
 
 type ControlStatus string
@@ -0,0 +1,65 @@
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ComplianceAttestationsCRName    = "complianceattestations.aquasecurity.github.io"
+	ComplianceAttestationsCRVersion = "v1alpha1"
+	ComplianceAttestationKind       = "ComplianceAttestation"
+	ComplianceAttestationListKind   = "ComplianceAttestationList"
+)
+
+// ComplianceAttestationSpec is the spec of a ComplianceAttestation.
+type ComplianceAttestationSpec struct {
+	// Compliance is the name of the attested ClusterComplianceReport, e.g. nsa.
+	Compliance string `json:"compliance"`
+
+	// ControlID is the identifier of the attested control, e.g. 1.0.
+	ControlID string `json:"controlID"`
+
+	// Status is the attested status of the control, either PASS or FAIL.
+	Status ControlStatus `json:"status"`
+
+	// Evidence describes how the control was checked.
+	Evidence string `json:"evidence"`
+
+	// Author is the person or team who checked the control.
+	Author string `json:"author"`
+
+	// ExpiresAt is the time after which the control must be checked again.
+	// An expired attestation fails the control.
+	ExpiresAt metav1.Time `json:"expiresAt"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ComplianceAttestation is a specification for the ComplianceAttestation
+// resource, which records the result of a manual check of a control of a
+// ClusterComplianceReport.
+type ComplianceAttestation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ComplianceAttestationSpec `json:"spec"`
+}
+
+// IsExpired returns true if the attestation has expired at the given time.
+func (a ComplianceAttestation) IsExpired(now time.Time) bool {
+	return !now.Before(a.Spec.ExpiresAt.Time)
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ComplianceAttestationList is a list of ComplianceAttestation resources.
+type ComplianceAttestationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ComplianceAttestation `json:"items"`
+}
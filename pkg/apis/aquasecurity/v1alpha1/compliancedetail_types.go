@@ -20,11 +20,11 @@ type ClusterComplianceDetailReport struct {
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
-// ClusterComplianceDetailReportList is a list of compliance kinds.
+// ClusterComplianceDetailReportList is a list of compliance detail kinds.
 type ClusterComplianceDetailReportList struct {
 	metav1.TypeMeta `json:",inline"`
 	metav1.ListMeta `json:"metadata"`
-	Items           []ClusterComplianceReport `json:"items"`
+	Items           []ClusterComplianceDetailReport `json:"items"`
 }
 
 type ClusterComplianceDetailReportData struct {
@@ -46,6 +46,7 @@ type ControlCheckDetails struct {
 type ResultDetails struct {
 	Name      string        `json:"name,omitempty"`
 	Namespace string        `json:"namespace,omitempty"`
+	Tenant    string        `json:"tenant,omitempty"`
 	Msg       string        `json:"msg"`
 	Status    ControlStatus `json:"status"`
 }
@@ -4,15 +4,98 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	MisconfigurationReportsCRName    = "misconfigurationreports.aquasecurity.github.io"
+	MisconfigurationReportsCRVersion = "v1alpha1"
+	MisconfigurationReportKind       = "MisconfigurationReport"
+	MisconfigurationReportListKind   = "MisconfigurationReportList"
+)
+
+// MisconfigurationStatus is the result of a misconfiguration check.
+type MisconfigurationStatus string
+
+const (
+	MisconfigurationStatusPass      MisconfigurationStatus = "PASS"
+	MisconfigurationStatusFail      MisconfigurationStatus = "FAIL"
+	MisconfigurationStatusException MisconfigurationStatus = "EXCEPTION"
+)
+
+// Misconfiguration is the result of a single misconfiguration check.
 type Misconfiguration struct {
-	ID     string `json:"ID"`
-	Status string `json:"Status"`
+	// ID is the identifier of the check, e.g. KSV001.
+	ID string `json:"id"`
+
+	// AVDID is the identifier of the check in the Aqua Vulnerability
+	// Database, e.g. AVD-KSV-0001.
+	// +optional
+	AVDID string `json:"avdID,omitempty"`
+
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Severity    Severity               `json:"severity"`
+	Status      MisconfigurationStatus `json:"status"`
+
+	// Message describes why the check failed for the scanned resource.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Resolution describes how to fix the misconfiguration.
+	// +optional
+	Resolution string `json:"resolution,omitempty"`
+
+	PrimaryLink string   `json:"primaryLink,omitempty"`
+	References  []string `json:"references,omitempty"`
+
+	// CauseMetadata points to the part of the resource that caused the
+	// misconfiguration.
+	// +optional
+	CauseMetadata *MisconfigurationCause `json:"causeMetadata,omitempty"`
+}
+
+// MisconfigurationCause points to the part of the resource that caused a
+// misconfiguration.
+type MisconfigurationCause struct {
+	// Resource is the path of the misconfigured resource, e.g.
+	// Deployment/nginx.
+	Resource string `json:"resource,omitempty"`
+
+	// StartLine is the first line of the misconfigured code in the resource
+	// manifest.
+	StartLine int `json:"startLine,omitempty"`
+
+	// EndLine is the last line of the misconfigured code in the resource
+	// manifest.
+	EndLine int `json:"endLine,omitempty"`
 }
 
-// MisconfigurationReportData is the spec for the vulnerability scan result.
-//
-// The spec follows the Pluggable Scanners API defined for Harbor.
-// @see https://github.com/goharbor/pluggable-scanner-spec/blob/master/api/spec/scanner-adapter-openapi-v1.0.yaml
+// MisconfigurationSummary is a summary of misconfiguration checks.
+type MisconfigurationSummary struct {
+	// PassCount is the number of passed checks.
+	PassCount int `json:"passCount"`
+
+	// FailCount is the number of failed checks.
+	FailCount int `json:"failCount"`
+
+	// ExceptionCount is the number of checks excluded by exceptions.
+	ExceptionCount int `json:"exceptionCount"`
+
+	// CriticalCount is the number of failed checks with critical severity.
+	CriticalCount int `json:"criticalCount"`
+
+	// HighCount is the number of failed checks with high severity.
+	HighCount int `json:"highCount"`
+
+	// MediumCount is the number of failed checks with medium severity.
+	MediumCount int `json:"mediumCount"`
+
+	// LowCount is the number of failed checks with low severity.
+	LowCount int `json:"lowCount"`
+
+	// UnknownCount is the number of failed checks with unknown severity.
+	UnknownCount int `json:"unknownCount"`
+}
+
+// MisconfigurationReportData is the spec for the misconfiguration scan result.
 type MisconfigurationReportData struct {
 	// UpdateTimestamp is a timestamp representing the server time in UTC when this report was updated.
 	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
@@ -26,21 +109,60 @@ type MisconfigurationReportData struct {
 	// Artifact is a container image scanned for Vulnerabilities.
 	Artifact Artifact `json:"artifact"`
 
-	// Summary is a summary of Vulnerability counts grouped by Severity.
-	Summary VulnerabilitySummary `json:"summary"`
+	// Summary is a summary of passed and failed checks.
+	Summary MisconfigurationSummary `json:"summary"`
+
+	// Misconfigurations is a list of misconfiguration checks run against the workload.
+	Misconfigurations []Misconfiguration `json:"misconfigurations"`
+}
+
+func MisconfigurationSummaryFrom(misconfigurations []Misconfiguration) MisconfigurationSummary {
+	summary := MisconfigurationSummary{}
 
-	// Vulnerabilities is a list of operating system (OS) or application software Vulnerability items found in the Artifact.
-	Misconfigurations []Misconfiguration `json:"vulnerabilities"`
+	for _, misconfiguration := range misconfigurations {
+		switch misconfiguration.Status {
+		case MisconfigurationStatusPass:
+			summary.PassCount++
+			continue
+		case MisconfigurationStatusException:
+			summary.ExceptionCount++
+			continue
+		}
+		summary.FailCount++
+		switch misconfiguration.Severity {
+		case SeverityCritical:
+			summary.CriticalCount++
+		case SeverityHigh:
+			summary.HighCount++
+		case SeverityMedium:
+			summary.MediumCount++
+		case SeverityLow:
+			summary.LowCount++
+		default:
+			summary.UnknownCount++
+		}
+	}
+
+	return summary
 }
 
 // +genclient
-// +genclient:nonNamespaced
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
-// ClusterVulnerabilityReport is a specification for the ClusterVulnerabilityReport resource.
+// MisconfigurationReport is a specification for the MisconfigurationReport resource.
 type MisconfigurationReport struct {
-	metav1.TypeMeta   `json:",incline"`
+	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Report MisconfigurationReportData `json:"report"`
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MisconfigurationReportList is a list of MisconfigurationReport resources.
+type MisconfigurationReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []MisconfigurationReport `json:"items"`
+}
@@ -0,0 +1,47 @@
+package v1alpha1_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMisconfigurationSummaryFrom(t *testing.T) {
+	misconfigurations := []v1alpha1.Misconfiguration{
+		{
+			Severity: v1alpha1.SeverityCritical,
+			Status:   v1alpha1.MisconfigurationStatusFail,
+		},
+		{
+			Severity: v1alpha1.SeverityCritical,
+			Status:   v1alpha1.MisconfigurationStatusPass,
+		},
+		{
+			Severity: v1alpha1.SeverityHigh,
+			Status:   v1alpha1.MisconfigurationStatusFail,
+		},
+		{
+			Severity: v1alpha1.SeverityMedium,
+			Status:   v1alpha1.MisconfigurationStatusException,
+		},
+		{
+			Severity: v1alpha1.SeverityLow,
+			Status:   v1alpha1.MisconfigurationStatusFail,
+		},
+		{
+			Severity: v1alpha1.SeverityUnknown,
+			Status:   v1alpha1.MisconfigurationStatusFail,
+		},
+	}
+	summary := v1alpha1.MisconfigurationSummaryFrom(misconfigurations)
+	assert.Equal(t, v1alpha1.MisconfigurationSummary{
+		PassCount:      1,
+		FailCount:      4,
+		ExceptionCount: 1,
+		CriticalCount:  1,
+		HighCount:      1,
+		LowCount:       1,
+		UnknownCount:   1,
+	}, summary)
+}
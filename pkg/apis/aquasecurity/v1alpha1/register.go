@@ -46,6 +46,14 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ClusterComplianceReportList{},
 		&ClusterComplianceDetailReport{},
 		&ClusterComplianceDetailReportList{},
+		&SBOMReport{},
+		&SBOMReportList{},
+		&VulnerabilityException{},
+		&VulnerabilityExceptionList{},
+		&MisconfigurationReport{},
+		&MisconfigurationReportList{},
+		&ComplianceAttestation{},
+		&ComplianceAttestationList{},
 	)
 	meta.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
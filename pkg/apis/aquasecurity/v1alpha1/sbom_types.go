@@ -0,0 +1,108 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	SBOMReportsCRName    = "sbomreports.aquasecurity.github.io"
+	SBOMReportsCRVersion = "v1alpha1"
+	SBOMReportKind       = "SBOMReport"
+	SBOMReportListKind   = "SBOMReportList"
+)
+
+// SBOMFormat is the format of a software bill of materials document.
+type SBOMFormat string
+
+const (
+	SBOMFormatCycloneDX SBOMFormat = "cyclonedx"
+	SBOMFormatSPDX      SBOMFormat = "spdx"
+)
+
+// SBOMEncoding is the encoding of the SBOM document stored with a report.
+type SBOMEncoding string
+
+const (
+	// SBOMEncodingNone means that the document is stored as is.
+	SBOMEncodingNone SBOMEncoding = ""
+	// SBOMEncodingGzipBase64 means that the document is gzip compressed and
+	// then base64 encoded.
+	SBOMEncodingGzipBase64 SBOMEncoding = "gzip+base64"
+)
+
+// SBOMSummary is a summary of the SBOM document.
+type SBOMSummary struct {
+	// ComponentsCount is the number of components, or packages in case of
+	// SPDX, listed in the document.
+	ComponentsCount int `json:"componentsCount"`
+}
+
+// SBOMDocumentRef refers to the object that stores the SBOM document when
+// it is too large to be stored in the report itself.
+type SBOMDocumentRef struct {
+	// Kind is the kind of the object that stores the document. Only
+	// ConfigMap is supported.
+	Kind string `json:"kind"`
+
+	// Name is the name of the object that stores the document. The object
+	// lives in the namespace of the report.
+	Name string `json:"name"`
+
+	// Key is the key of the document within the object's data.
+	Key string `json:"key"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SBOMReport is a specification for the SBOMReport resource.
+type SBOMReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Report is the actual software bill of materials report data.
+	Report SBOMReportData `json:"report"`
+}
+
+// SBOMReportData is the software bill of materials of a container image.
+type SBOMReportData struct {
+	// UpdateTimestamp is a timestamp representing the server time in UTC when this report was updated.
+	UpdateTimestamp metav1.Time `json:"updateTimestamp"`
+
+	// Scanner is the scanner that generated this report.
+	Scanner Scanner `json:"scanner"`
+
+	// Registry is the registry the Artifact was pulled from.
+	Registry Registry `json:"registry"`
+
+	// Artifact is a container image described by the document.
+	Artifact Artifact `json:"artifact"`
+
+	// Format is the format of the document.
+	Format SBOMFormat `json:"format"`
+
+	// Summary is a summary of the document.
+	Summary SBOMSummary `json:"summary"`
+
+	// Encoding is the encoding of the Document, or of the data referred by
+	// the DocumentRef.
+	Encoding SBOMEncoding `json:"encoding,omitempty"`
+
+	// Document is the SBOM document in the JSON format, encoded as specified
+	// by the Encoding. It is empty if the document is stored externally.
+	Document string `json:"document,omitempty"`
+
+	// DocumentRef refers to the object that stores the document if it's too
+	// large to be stored in the report.
+	DocumentRef *SBOMDocumentRef `json:"documentRef,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SBOMReportList is a list of SBOMReport resources.
+type SBOMReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []SBOMReport `json:"items"`
+}
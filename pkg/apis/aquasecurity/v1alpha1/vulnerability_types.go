@@ -13,7 +13,19 @@ const (
 	ClusterVulnerabilityReportsCRName = "clustervulnerabilityreports.aquasecurity.github.io"
 )
 
+// VEXStatus is the status of a vulnerability in a product as stated by a
+// Vulnerability Exploitability eXchange (VEX) document.
+type VEXStatus string
+
+const (
+	VEXStatusNotAffected        VEXStatus = "not_affected"
+	VEXStatusAffected           VEXStatus = "affected"
+	VEXStatusFixed              VEXStatus = "fixed"
+	VEXStatusUnderInvestigation VEXStatus = "under_investigation"
+)
+
 // VulnerabilitySummary is a summary of Vulnerability counts grouped by Severity.
+// Vulnerabilities with the VEXStatusNotAffected status are not counted.
 type VulnerabilitySummary struct {
 	// CriticalCount is the number of vulnerabilities with Critical Severity.
 	CriticalCount int `json:"criticalCount"`
@@ -32,6 +44,13 @@ type VulnerabilitySummary struct {
 
 	// NoneCount is the number of packages without any vulnerability.
 	NoneCount int `json:"noneCount"`
+
+	// ExploitedCount is the number of vulnerabilities known to be exploited in the wild.
+	ExploitedCount int `json:"exploitedCount"`
+
+	// SuppressedCount is the number of vulnerabilities suppressed by
+	// VulnerabilityExceptions, which are not included in other counts.
+	SuppressedCount int `json:"suppressedCount"`
 }
 
 // Registry is a collection of repositories used to store Artifacts.
@@ -76,6 +95,35 @@ type Vulnerability struct {
 	PrimaryLink string   `json:"primaryLink,omitempty"`
 	Links       []string `json:"links"`
 	Score       *float64 `json:"score,omitempty"`
+
+	// PublishedDate is the time when the vulnerability was published.
+	// +optional
+	PublishedDate *metav1.Time `json:"publishedDate,omitempty"`
+
+	// KnownExploited indicates that the vulnerability is listed in the catalog
+	// of known exploited vulnerabilities.
+	KnownExploited bool `json:"knownExploited,omitempty"`
+
+	// KnownExploitedDateAdded is the date when the vulnerability was added to
+	// the catalog of known exploited vulnerabilities, e.g. 2021-12-10.
+	KnownExploitedDateAdded string `json:"knownExploitedDateAdded,omitempty"`
+
+	// ExploitProbability is the probability of exploitation in the next 30 days
+	// as estimated by the Exploit Prediction Scoring System (EPSS).
+	ExploitProbability *float64 `json:"exploitProbability,omitempty"`
+
+	// Suppressed indicates that the vulnerability matches an active
+	// VulnerabilityException.
+	Suppressed bool `json:"suppressed,omitempty"`
+
+	// SuppressedBy is the name of the VulnerabilityException that suppresses
+	// the vulnerability.
+	SuppressedBy string `json:"suppressedBy,omitempty"`
+
+	// VEXStatus is the status of the vulnerability stated by a matching VEX
+	// document. Vulnerabilities with the not_affected status are not included
+	// in the summary.
+	VEXStatus VEXStatus `json:"vexStatus,omitempty"`
 }
 
 // +genclient
@@ -130,7 +178,7 @@ type VulnerabilityReportList struct {
 
 // ClusterVulnerabilityReport is a specification for the ClusterVulnerabilityReport resource.
 type ClusterVulnerabilityReport struct {
-	metav1.TypeMeta   `json:",incline"`
+	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Report VulnerabilityReportData `json:"report"`
@@ -0,0 +1,87 @@
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	VulnerabilityExceptionsCRName    = "vulnerabilityexceptions.aquasecurity.github.io"
+	VulnerabilityExceptionsCRVersion = "v1alpha1"
+	VulnerabilityExceptionKind       = "VulnerabilityException"
+	VulnerabilityExceptionListKind   = "VulnerabilityExceptionList"
+)
+
+// VulnerabilityExceptionScope narrows down the workloads and images an
+// exception applies to. An empty scope matches all workloads in the namespace
+// of the exception.
+type VulnerabilityExceptionScope struct {
+	// WorkloadSelector selects workloads by their labels.
+	WorkloadSelector *metav1.LabelSelector `json:"workloadSelector,omitempty"`
+
+	// Repositories is a list of glob patterns of image repositories, with or
+	// without the registry server, e.g. library/nginx or index.docker.io/library/*.
+	Repositories []string `json:"repositories,omitempty"`
+}
+
+// VulnerabilityExceptionSpec is the spec of a VulnerabilityException. At least
+// one of VulnerabilityID and Resource must be set.
+type VulnerabilityExceptionSpec struct {
+	// VulnerabilityID is the identifier of the suppressed vulnerability.
+	VulnerabilityID string `json:"vulnerabilityID,omitempty"`
+
+	// Resource is a glob pattern of vulnerable packages, applications, or
+	// libraries, e.g. openssl or libssl*.
+	Resource string `json:"resource,omitempty"`
+
+	// Scope is the scope of the exception within its namespace.
+	Scope VulnerabilityExceptionScope `json:"scope,omitempty"`
+
+	// Justification explains why matching vulnerabilities are accepted.
+	Justification string `json:"justification"`
+
+	// Approver is the person or team who approved the exception.
+	Approver string `json:"approver"`
+
+	// ExpiresAt is the time after which matching vulnerabilities are no longer
+	// suppressed.
+	ExpiresAt metav1.Time `json:"expiresAt"`
+}
+
+// VulnerabilityExceptionStatus is the observed state of a
+// VulnerabilityException.
+type VulnerabilityExceptionStatus struct {
+	// Expired indicates that the exception has expired and no longer
+	// suppresses vulnerabilities.
+	Expired bool `json:"expired,omitempty"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VulnerabilityException is a specification for the VulnerabilityException
+// resource, which suppresses matching vulnerabilities in VulnerabilityReports
+// of workloads in its namespace until it expires.
+type VulnerabilityException struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VulnerabilityExceptionSpec   `json:"spec"`
+	Status VulnerabilityExceptionStatus `json:"status,omitempty"`
+}
+
+// IsExpired returns true if the exception has expired at the given time.
+func (e VulnerabilityException) IsExpired(now time.Time) bool {
+	return !now.Before(e.Spec.ExpiresAt.Time)
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VulnerabilityExceptionList is a list of VulnerabilityException resources.
+type VulnerabilityExceptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []VulnerabilityException `json:"items"`
+}
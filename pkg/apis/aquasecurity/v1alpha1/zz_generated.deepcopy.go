@@ -6,6 +6,7 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -289,7 +290,7 @@ func (in *ClusterComplianceDetailReportList) DeepCopyInto(out *ClusterCompliance
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterComplianceReport, len(*in))
+		*out = make([]ClusterComplianceDetailReport, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -528,6 +529,137 @@ func (in *Compliance) DeepCopy() *Compliance {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceAttestation) DeepCopyInto(out *ComplianceAttestation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceAttestation.
+func (in *ComplianceAttestation) DeepCopy() *ComplianceAttestation {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceAttestation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComplianceAttestation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceAttestationList) DeepCopyInto(out *ComplianceAttestationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ComplianceAttestation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceAttestationList.
+func (in *ComplianceAttestationList) DeepCopy() *ComplianceAttestationList {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceAttestationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComplianceAttestationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceAttestationSpec) DeepCopyInto(out *ComplianceAttestationSpec) {
+	*out = *in
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceAttestationSpec.
+func (in *ComplianceAttestationSpec) DeepCopy() *ComplianceAttestationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceAttestationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceScope) DeepCopyInto(out *ComplianceScope) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceSelector != nil {
+		in, out := &in.ResourceSelector, &out.ResourceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceScope.
+func (in *ComplianceScope) DeepCopy() *ComplianceScope {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceSnapshot) DeepCopyInto(out *ComplianceSnapshot) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	out.Summary = in.Summary
+	if in.FailedControls != nil {
+		in, out := &in.FailedControls, &out.FailedControls
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceSnapshot.
+func (in *ComplianceSnapshot) DeepCopy() *ComplianceSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigAuditReport) DeepCopyInto(out *ConfigAuditReport) {
 	*out = *in
@@ -715,6 +847,23 @@ func (in *ControlCheckDetails) DeepCopy() *ControlCheckDetails {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlTransition) DeepCopyInto(out *ControlTransition) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlTransition.
+func (in *ControlTransition) DeepCopy() *ControlTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeHunterReport) DeepCopyInto(out *KubeHunterReport) {
 	*out = *in
@@ -837,7 +986,9 @@ func (in *Mapping) DeepCopyInto(out *Mapping) {
 	if in.Checks != nil {
 		in, out := &in.Checks, &out.Checks
 		*out = make([]SpecCheck, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -852,6 +1003,152 @@ func (in *Mapping) DeepCopy() *Mapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Misconfiguration) DeepCopyInto(out *Misconfiguration) {
+	*out = *in
+	if in.References != nil {
+		in, out := &in.References, &out.References
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CauseMetadata != nil {
+		in, out := &in.CauseMetadata, &out.CauseMetadata
+		*out = new(MisconfigurationCause)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Misconfiguration.
+func (in *Misconfiguration) DeepCopy() *Misconfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(Misconfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MisconfigurationCause) DeepCopyInto(out *MisconfigurationCause) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MisconfigurationCause.
+func (in *MisconfigurationCause) DeepCopy() *MisconfigurationCause {
+	if in == nil {
+		return nil
+	}
+	out := new(MisconfigurationCause)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MisconfigurationReport) DeepCopyInto(out *MisconfigurationReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MisconfigurationReport.
+func (in *MisconfigurationReport) DeepCopy() *MisconfigurationReport {
+	if in == nil {
+		return nil
+	}
+	out := new(MisconfigurationReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MisconfigurationReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MisconfigurationReportData) DeepCopyInto(out *MisconfigurationReportData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	out.Scanner = in.Scanner
+	out.Registry = in.Registry
+	out.Artifact = in.Artifact
+	out.Summary = in.Summary
+	if in.Misconfigurations != nil {
+		in, out := &in.Misconfigurations, &out.Misconfigurations
+		*out = make([]Misconfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MisconfigurationReportData.
+func (in *MisconfigurationReportData) DeepCopy() *MisconfigurationReportData {
+	if in == nil {
+		return nil
+	}
+	out := new(MisconfigurationReportData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MisconfigurationReportList) DeepCopyInto(out *MisconfigurationReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MisconfigurationReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MisconfigurationReportList.
+func (in *MisconfigurationReportList) DeepCopy() *MisconfigurationReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(MisconfigurationReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MisconfigurationReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MisconfigurationSummary) DeepCopyInto(out *MisconfigurationSummary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MisconfigurationSummary.
+func (in *MisconfigurationSummary) DeepCopy() *MisconfigurationSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(MisconfigurationSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Registry) DeepCopyInto(out *Registry) {
 	*out = *in
@@ -878,6 +1175,11 @@ func (in *ReportSpec) DeepCopyInto(out *ReportSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = new(ComplianceScope)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -901,6 +1203,20 @@ func (in *ReportStatus) DeepCopyInto(out *ReportStatus) {
 		*out = make([]ControlCheck, len(*in))
 		copy(*out, *in)
 	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ComplianceSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Transitions != nil {
+		in, out := &in.Transitions, &out.Transitions
+		*out = make([]ControlTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -930,6 +1246,124 @@ func (in *ResultDetails) DeepCopy() *ResultDetails {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SBOMDocumentRef) DeepCopyInto(out *SBOMDocumentRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SBOMDocumentRef.
+func (in *SBOMDocumentRef) DeepCopy() *SBOMDocumentRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SBOMDocumentRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SBOMReport) DeepCopyInto(out *SBOMReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SBOMReport.
+func (in *SBOMReport) DeepCopy() *SBOMReport {
+	if in == nil {
+		return nil
+	}
+	out := new(SBOMReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SBOMReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SBOMReportData) DeepCopyInto(out *SBOMReportData) {
+	*out = *in
+	in.UpdateTimestamp.DeepCopyInto(&out.UpdateTimestamp)
+	out.Scanner = in.Scanner
+	out.Registry = in.Registry
+	out.Artifact = in.Artifact
+	out.Summary = in.Summary
+	if in.DocumentRef != nil {
+		in, out := &in.DocumentRef, &out.DocumentRef
+		*out = new(SBOMDocumentRef)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SBOMReportData.
+func (in *SBOMReportData) DeepCopy() *SBOMReportData {
+	if in == nil {
+		return nil
+	}
+	out := new(SBOMReportData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SBOMReportList) DeepCopyInto(out *SBOMReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SBOMReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SBOMReportList.
+func (in *SBOMReportList) DeepCopy() *SBOMReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(SBOMReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SBOMReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SBOMSummary) DeepCopyInto(out *SBOMSummary) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SBOMSummary.
+func (in *SBOMSummary) DeepCopy() *SBOMSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(SBOMSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Scanner) DeepCopyInto(out *Scanner) {
 	*out = *in
@@ -970,6 +1404,11 @@ func (in *ScannerCheckResult) DeepCopy() *ScannerCheckResult {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpecCheck) DeepCopyInto(out *SpecCheck) {
 	*out = *in
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(VulnerabilityThreshold)
+		**out = **in
+	}
 	return
 }
 
@@ -996,6 +1435,15 @@ func (in *Vulnerability) DeepCopyInto(out *Vulnerability) {
 		*out = new(float64)
 		**out = **in
 	}
+	if in.PublishedDate != nil {
+		in, out := &in.PublishedDate, &out.PublishedDate
+		*out = (*in).DeepCopy()
+	}
+	if in.ExploitProbability != nil {
+		in, out := &in.ExploitProbability, &out.ExploitProbability
+		*out = new(float64)
+		**out = **in
+	}
 	return
 }
 
@@ -1009,6 +1457,127 @@ func (in *Vulnerability) DeepCopy() *Vulnerability {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityException) DeepCopyInto(out *VulnerabilityException) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityException.
+func (in *VulnerabilityException) DeepCopy() *VulnerabilityException {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityException)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VulnerabilityException) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityExceptionList) DeepCopyInto(out *VulnerabilityExceptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VulnerabilityException, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityExceptionList.
+func (in *VulnerabilityExceptionList) DeepCopy() *VulnerabilityExceptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityExceptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VulnerabilityExceptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityExceptionScope) DeepCopyInto(out *VulnerabilityExceptionScope) {
+	*out = *in
+	if in.WorkloadSelector != nil {
+		in, out := &in.WorkloadSelector, &out.WorkloadSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityExceptionScope.
+func (in *VulnerabilityExceptionScope) DeepCopy() *VulnerabilityExceptionScope {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityExceptionScope)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityExceptionSpec) DeepCopyInto(out *VulnerabilityExceptionSpec) {
+	*out = *in
+	in.Scope.DeepCopyInto(&out.Scope)
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityExceptionSpec.
+func (in *VulnerabilityExceptionSpec) DeepCopy() *VulnerabilityExceptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityExceptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityExceptionStatus) DeepCopyInto(out *VulnerabilityExceptionStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityExceptionStatus.
+func (in *VulnerabilityExceptionStatus) DeepCopy() *VulnerabilityExceptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityExceptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VulnerabilityReport) DeepCopyInto(out *VulnerabilityReport) {
 	*out = *in
@@ -1112,3 +1681,19 @@ func (in *VulnerabilitySummary) DeepCopy() *VulnerabilitySummary {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VulnerabilityThreshold) DeepCopyInto(out *VulnerabilityThreshold) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VulnerabilityThreshold.
+func (in *VulnerabilityThreshold) DeepCopy() *VulnerabilityThreshold {
+	if in == nil {
+		return nil
+	}
+	out := new(VulnerabilityThreshold)
+	in.DeepCopyInto(out)
+	return out
+}
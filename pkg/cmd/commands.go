@@ -3,15 +3,19 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 	"time"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
@@ -89,3 +93,52 @@ func getScannerOpts(cmd *cobra.Command) (opts kube.ScannerOpts, err error) {
 	}
 	return
 }
+
+const (
+	outputFlagName     = "output"
+	outputFileFlagName = "output-file"
+)
+
+func registerScanOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP(outputFlagName, "o", "table", "Output format. One of table|json|yaml")
+	cmd.Flags().String(outputFileFlagName, "", "If set, save the raw report produced by the scanner to this file")
+}
+
+func getScanOutputFormat(cmd *cobra.Command) (string, error) {
+	format, err := cmd.Flags().GetString(outputFlagName)
+	if err != nil {
+		return "", err
+	}
+	switch format {
+	case "table", "json", "yaml":
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid output format %q, allowed formats are: table,json,yaml", format)
+	}
+}
+
+// openRawReportFile creates the file set by the output-file flag. It returns
+// nil if the flag is not set.
+func openRawReportFile(cmd *cobra.Command) (*os.File, error) {
+	path, err := cmd.Flags().GetString(outputFileFlagName)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return nil, nil
+	}
+	return os.Create(path)
+}
+
+// printScanResult prints the given object of a type registered in the
+// starboard scheme in the json or yaml format.
+func printScanResult(out io.Writer, format string, obj runtime.Object) error {
+	printer, err := genericclioptions.NewPrintFlags("").
+		WithTypeSetter(starboard.NewScheme()).
+		WithDefaultOutput(format).
+		ToPrinter()
+	if err != nil {
+		return err
+	}
+	return printer.PrintObj(obj, out)
+}
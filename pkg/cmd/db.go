@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func NewDBCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, outWriter io.Writer) *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "Manage the shared Trivy DB cache",
+	}
+	dbCmd.AddCommand(NewDBImportCmd(buildInfo, cf, outWriter))
+
+	return dbCmd
+}
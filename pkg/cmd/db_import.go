@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/plugin"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	dbImportTimeoutFlagName        = "timeout"
+	dbImportServiceAccountFlagName = "service-account"
+)
+
+const (
+	// defaultOperatorNamespace is the namespace of the operator installed with
+	// the Helm chart or static manifests, where it maintains the DB cache.
+	defaultOperatorNamespace      = "starboard-system"
+	defaultOperatorServiceAccount = "starboard-operator"
+)
+
+func NewDBImportCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import BUNDLE",
+		Short: "Import an offline Trivy DB bundle into the shared Trivy DB cache",
+		Long: `Import an offline Trivy DB bundle into the shared Trivy DB cache
+
+BUNDLE is a gzipped tar archive with the trivy.db and metadata.json files, such as the db.tar.gz
+layer of the Trivy DB OCI artifact. The cache is the persistent volume claim set by the
+trivy.dbCache.claimName property of the Trivy plugin, which is created if it does not exist.
+
+The cache is looked up in the operator namespace (starboard-system) unless the --namespace flag is
+set, e.g. to the starboard namespace created by the init command. The import pod runs with the
+operator service account, or with the starboard service account in the starboard namespace, unless
+the --service-account flag is set.
+
+Scan results of container images cached as clustervulnerabilityreports before the import are
+deleted, so that images are rescanned with the imported DB.
+`,
+		Example: fmt.Sprintf(`  # Import the Trivy DB downloaded with oras on a machine with internet access
+  oras pull ghcr.io/aquasecurity/trivy-db:2
+  %[1]s db import db.tar.gz
+
+  # Import the Trivy DB into the cache used by scan commands of the CLI
+  %[1]s db import db.tar.gz --namespace starboard`, buildInfo.Executable),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			timeout, err := cmd.Flags().GetDuration(dbImportTimeoutFlagName)
+			if err != nil {
+				return err
+			}
+			namespace, serviceAccountName := defaultOperatorNamespace, defaultOperatorServiceAccount
+			if cmd.Flags().Changed("namespace") {
+				namespace, _, err = cf.ToRawKubeConfigLoader().Namespace()
+				if err != nil {
+					return err
+				}
+				if namespace == starboard.NamespaceName {
+					serviceAccountName = starboard.ServiceAccountName
+				}
+			}
+			if cmd.Flags().Changed(dbImportServiceAccountFlagName) {
+				serviceAccountName, err = cmd.Flags().GetString(dbImportServiceAccountFlagName)
+				if err != nil {
+					return err
+				}
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			bundle, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = bundle.Close()
+			}()
+
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			kubeClientset, err := kubernetes.NewForConfig(kubeConfig)
+			if err != nil {
+				return err
+			}
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return err
+			}
+			config, err := starboard.NewConfigManager(kubeClientset, namespace).Read(ctx)
+			if err != nil {
+				return err
+			}
+			_, pluginContext, err := plugin.NewResolver().
+				WithBuildInfo(buildInfo).
+				WithNamespace(namespace).
+				WithServiceAccountName(serviceAccountName).
+				WithConfig(config).
+				WithClient(kubeClient).
+				GetVulnerabilityPlugin()
+			if err != nil {
+				return err
+			}
+			if pluginContext.GetName() != trivy.Plugin {
+				return fmt.Errorf("importing DB requires the %s vulnerability scanner, got %s", trivy.Plugin, pluginContext.GetName())
+			}
+			pluginConfig, err := pluginContext.GetConfig()
+			if err != nil {
+				return err
+			}
+			trivyConfig := trivy.Config{PluginConfig: pluginConfig}
+			cache, enabled, err := trivyConfig.GetDBCache()
+			if err != nil {
+				return err
+			}
+			if !enabled {
+				return fmt.Errorf("trivy.dbCache.claimName is not set in ConfigMap %s/%s", namespace, starboard.GetPluginConfigMapName(trivy.Plugin))
+			}
+
+			err = trivy.ImportDB(ctx, kubeClientset, trivyConfig, buildInfo, cache,
+				namespace, serviceAccountName, bundle)
+			if err != nil {
+				return err
+			}
+			clock := ext.NewSystemClock()
+			err = vulnerabilityreport.NewImageCache(kubeClient, clock, 0).Invalidate(ctx, clock.Now())
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(out, "Imported %s into persistent volume claim %s/%s\n",
+				args[0], namespace, cache.ClaimName)
+			return err
+		},
+	}
+	cmd.Flags().Duration(dbImportTimeoutFlagName, 5*time.Minute, "The length of time to wait before giving up on the import")
+	cmd.Flags().String(dbImportServiceAccountFlagName, defaultOperatorServiceAccount, "The service account of the import pod")
+	return cmd
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+const cacheDirFlagName = "cache-dir"
+
+// NewDBImportServerCmd returns the command run by the pod created by the db
+// import command. It is not meant to be run by users.
+func NewDBImportServerCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "db-import-server",
+		Short:  "Extract an uploaded Trivy DB bundle to the Trivy DB cache",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cacheDir, err := cmd.Flags().GetString(cacheDirFlagName)
+			if err != nil {
+				return err
+			}
+			port, err := cmd.Flags().GetInt(portFlagName)
+			if err != nil {
+				return err
+			}
+
+			handler := trivy.NewDBImportServer(cacheDir)
+			server := &http.Server{
+				Addr:    fmt.Sprintf(":%d", port),
+				Handler: handler,
+			}
+			go func() {
+				<-handler.Done()
+				klog.V(3).Infof("DB bundle imported, shutting down")
+				_ = server.Shutdown(context.Background())
+			}()
+			err = server.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String(cacheDirFlagName, trivy.DBCacheMountPath, "Path of the Trivy DB cache")
+	cmd.Flags().Int(portFlagName, trivy.DBImportPort, "Port to receive the DB bundle on")
+	return cmd
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func NewDiffCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, outWriter io.Writer) *cobra.Command {
+	diffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Compare security reports",
+	}
+	diffCmd.AddCommand(NewDiffVulnerabilityReportsCmd(buildInfo.Executable, cf, outWriter))
+	diffCmd.PersistentFlags().StringP("output", "o", "table", "Output format. One of table|json")
+
+	return diffCmd
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	showUnchangedFlagName = "show-unchanged"
+	allWorkloadsFlagName  = "all-workloads"
+)
+
+func NewDiffVulnerabilityReportsCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "vulnerabilityreports BEFORE [AFTER]",
+		Aliases: []string{"vulns", "vuln", "vulnerabilities"},
+		Short:   "Compare vulnerability reports",
+		Long: `Compare vulnerability reports of two scans and show new, fixed and unchanged vulnerabilities
+
+BEFORE and AFTER are files with vulnerability reports saved with the get vulnerabilityreports
+command in JSON or YAML format, or JSON reports of the trivy k8s command. If AFTER is omitted,
+BEFORE is compared with the vulnerability reports in the cluster of the workloads and containers
+found in BEFORE, or of all workloads in the namespaces found in BEFORE with --all-workloads.
+`,
+		Example: fmt.Sprintf(`  # Compare vulnerability reports saved by two scans
+  %[1]s diff vulnerabilityreports before.json after.json
+
+  # Compare saved vulnerability reports with the ones in the cluster
+  %[1]s get vulns deploy/nginx -o json > before.json
+  %[1]s diff vulns before.json
+
+  # Compare saved vulnerability reports with the ones of all workloads in the same namespaces
+  %[1]s diff vulns before.json --all-workloads
+
+  # Compare saved vulnerability reports in JSON output format
+  %[1]s diff vulns before.json after.json -o json`, executable),
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			format := cmd.Flag("output").Value.String()
+			if format != "table" && format != "json" {
+				return fmt.Errorf("invalid output format %q, allowed formats are: table,json", format)
+			}
+			showUnchanged, err := cmd.Flags().GetBool(showUnchangedFlagName)
+			if err != nil {
+				return err
+			}
+			allWorkloads, err := cmd.Flags().GetBool(allWorkloadsFlagName)
+			if err != nil {
+				return err
+			}
+
+			before, err := loadVulnerabilityReports(args[0])
+			if err != nil {
+				return err
+			}
+
+			var diff vulnerabilityreport.Diff
+			if len(args) == 2 {
+				after, err := loadVulnerabilityReports(args[1])
+				if err != nil {
+					return err
+				}
+				if (before.trivyReport == nil) != (after.trivyReport == nil) {
+					return fmt.Errorf("cannot compare trivy reports with vulnerability reports")
+				}
+				if before.trivyReport != nil {
+					diff = vulnerabilityreport.DiffTrivyReports(before.trivyReport, after.trivyReport)
+				} else {
+					diff = vulnerabilityreport.DiffReports(before.reports, after.reports)
+				}
+			} else {
+				diff, err = diffWithCluster(ctx, cf, before, allWorkloads)
+				if err != nil {
+					return err
+				}
+			}
+
+			if format == "json" {
+				encoder := json.NewEncoder(out)
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(diff)
+			}
+			return printDiff(out, diff, showUnchanged)
+		},
+	}
+
+	cmd.Flags().Bool(showUnchangedFlagName, false, "If true, list unchanged vulnerabilities in table output format")
+	cmd.Flags().Bool(allWorkloadsFlagName, false, "If true and AFTER is omitted, compare with vulnerability reports of all workloads in the namespaces found in BEFORE")
+
+	return cmd
+}
+
+// savedVulnerabilityReports holds either vulnerability reports or a trivy
+// report loaded from a file.
+type savedVulnerabilityReports struct {
+	reports     []v1alpha1.VulnerabilityReport
+	trivyReport *aquasecurity.TrivyReport
+}
+
+func loadVulnerabilityReports(path string) (savedVulnerabilityReports, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return savedVulnerabilityReports{}, err
+	}
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return savedVulnerabilityReports{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(data, &typeMeta); err != nil {
+		return savedVulnerabilityReports{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	switch typeMeta.Kind {
+	case "VulnerabilityReportList", "List":
+		var list v1alpha1.VulnerabilityReportList
+		if err := json.Unmarshal(data, &list); err != nil {
+			return savedVulnerabilityReports{}, fmt.Errorf("reading %s: %w", path, err)
+		}
+		return savedVulnerabilityReports{reports: list.Items}, nil
+	case v1alpha1.VulnerabilityReportKind:
+		var report v1alpha1.VulnerabilityReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			return savedVulnerabilityReports{}, fmt.Errorf("reading %s: %w", path, err)
+		}
+		return savedVulnerabilityReports{reports: []v1alpha1.VulnerabilityReport{report}}, nil
+	case "":
+		trivyReport, err := vulnerabilityreport.ReadTrivyReport(
+			vulnerabilityreport.NewScanReportIterator(io.NopCloser(bytes.NewReader(data))))
+		if err != nil {
+			return savedVulnerabilityReports{}, fmt.Errorf("reading %s: %w", path, err)
+		}
+		return savedVulnerabilityReports{trivyReport: trivyReport}, nil
+	default:
+		return savedVulnerabilityReports{}, fmt.Errorf("reading %s: unsupported kind %q", path, typeMeta.Kind)
+	}
+}
+
+// diffWithCluster compares the saved reports with the vulnerability reports
+// in the cluster of the same workloads and containers, or of all workloads in
+// the namespaces the reports were saved from if allWorkloads is true. A saved
+// trivy report is converted to vulnerability reports first, so that containers
+// can be matched by name.
+func diffWithCluster(ctx context.Context, cf *genericclioptions.ConfigFlags, before savedVulnerabilityReports, allWorkloads bool) (vulnerabilityreport.Diff, error) {
+	kubeConfig, err := cf.ToRESTConfig()
+	if err != nil {
+		return vulnerabilityreport.Diff{}, err
+	}
+	scheme := starboard.NewScheme()
+	kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return vulnerabilityreport.Diff{}, err
+	}
+
+	if before.trivyReport != nil {
+		cm, err := kube.InitCompatibleMgr(kubeClient.RESTMapper())
+		if err != nil {
+			return vulnerabilityreport.Diff{}, err
+		}
+		objectResolver := kube.NewObjectResolver(kubeClient, cm)
+		before.reports, err = vulnerabilityreport.NewConverter(scheme, &objectResolver, ext.NewSystemClock()).
+			Convert(ctx, before.trivyReport)
+		if err != nil {
+			return vulnerabilityreport.Diff{}, fmt.Errorf("converting trivy report: %w", err)
+		}
+	}
+
+	namespaces := make(map[string]bool)
+	containers := make(map[vulnerabilityreport.ContainerKey]bool)
+	for _, report := range before.reports {
+		namespaces[report.Namespace] = true
+		containers[vulnerabilityreport.GetContainerKey(report)] = true
+	}
+	var after []v1alpha1.VulnerabilityReport
+	for namespace := range namespaces {
+		var list v1alpha1.VulnerabilityReportList
+		err := kubeClient.List(ctx, &list, client.InNamespace(namespace))
+		if err != nil {
+			return vulnerabilityreport.Diff{}, fmt.Errorf("list vulnerability reports: %w", err)
+		}
+		for _, report := range list.Items {
+			if allWorkloads || containers[vulnerabilityreport.GetContainerKey(report)] {
+				after = append(after, report)
+			}
+		}
+	}
+
+	return vulnerabilityreport.DiffReports(before.reports, after), nil
+}
+
+func printDiff(out io.Writer, diff vulnerabilityreport.Diff, showUnchanged bool) error {
+	w := printers.GetNewTabWriter(out)
+	fmt.Fprintln(w, "NAMESPACE\tKIND\tNAME\tCONTAINER\tVULNERABILITY\tRESOURCE\tCHANGE\tSEVERITY")
+	for _, container := range diff.Containers {
+		for _, vulnerability := range container.Vulnerabilities {
+			if vulnerability.Change == vulnerabilityreport.ChangeUnchanged && !vulnerability.SeverityChanged() && !showUnchanged {
+				continue
+			}
+			severity := string(vulnerability.Severity)
+			if vulnerability.SeverityChanged() {
+				severity = fmt.Sprintf("%s -> %s", vulnerability.PreviousSeverity, vulnerability.Severity)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				container.Namespace, container.Kind, container.Name, container.Container,
+				vulnerability.VulnerabilityID, vulnerability.Resource, vulnerability.Change, severity)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "\n%d new, %d fixed, %d unchanged, %d with changed severity\n",
+		diff.Summary.NewCount, diff.Summary.FixedCount, diff.Summary.UnchangedCount, diff.Summary.SeverityChangedCount)
+	return err
+}
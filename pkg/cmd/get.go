@@ -14,7 +14,9 @@ func NewGetCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags,
 		Short: "Get security reports",
 	}
 	getCmd.AddCommand(NewGetVulnerabilityReportsCmd(buildInfo.Executable, cf, outWriter))
+	getCmd.AddCommand(NewGetSBOMReportsCmd(buildInfo.Executable, cf, outWriter))
 	getCmd.AddCommand(NewGetConfigAuditReportsCmd(buildInfo.Executable, cf, outWriter))
+	getCmd.AddCommand(NewGetMisconfigurationReportsCmd(buildInfo.Executable, cf, outWriter))
 	getCmd.AddCommand(NewGetClusterComplianceReportsCmd(buildInfo.Executable, cf, outWriter))
 	getCmd.PersistentFlags().StringP("output", "o", "", "Output format. One of yaml|json")
 
@@ -4,16 +4,21 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/compliance"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -28,7 +33,14 @@ func NewGetClusterComplianceReportsCmd(executable string, cf *genericclioptions.
   %[1]s get clustercompliancereports nsa -o json
 
   # Get compliance detail report for control checks failure in JSON output format
-  %[1]s get clustercompliancereports nsa -o json --detail`, executable),
+  %[1]s get clustercompliancereports nsa -o json --detail
+
+  # Export cluster compliance report as OSCAL assessment results or JUnit test suite
+  %[1]s get clustercompliancereports nsa -o oscal
+  %[1]s get clustercompliancereports nsa -o junit
+
+  # Get the trend of recent compliance report snapshots and control transitions
+  %[1]s get clustercompliancereports nsa --history`, executable),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logger := ctrl.Log.WithName("reconciler").WithName("clustercompliancereport")
 			ctx := context.Background()
@@ -52,6 +64,18 @@ func NewGetClusterComplianceReportsCmd(executable string, cf *genericclioptions.
 			if err != nil {
 				return err
 			}
+
+			// Print the history recorded by the operator as is. Generating the
+			// report would add a snapshot and consume control transitions
+			// without raising events for them.
+			history, err := cmd.Flags().GetBool("history")
+			if err != nil {
+				return fmt.Errorf("history flag is not set correctly, check flag usage: %w", err)
+			}
+			if history {
+				return printComplianceHistory(out, report.Status)
+			}
+
 			kubeClientset, err := kubernetes.NewForConfig(kubeConfig)
 			if err != nil {
 				return err
@@ -60,13 +84,34 @@ func NewGetClusterComplianceReportsCmd(executable string, cf *genericclioptions.
 			if err != nil {
 				return err
 			}
-			complianceMgr := compliance.NewMgr(kubeClient, logger, starboardConfig)
-			err = complianceMgr.GenerateComplianceReport(ctx, report.Spec)
+			cm, err := kube.InitCompatibleMgr(kubeClient.RESTMapper())
+			if err != nil {
+				return err
+			}
+			objectResolver := kube.NewObjectResolver(kubeClient, cm)
+			complianceMgr := compliance.NewMgr(kubeClient, objectResolver, logger, starboardConfig, ext.NewSystemClock())
+			_, err = complianceMgr.GenerateComplianceReport(ctx, report.Spec)
 			if err != nil {
 				return fmt.Errorf("failed to generate report: %w", err)
 			}
 
 			format := cmd.Flag("output").Value.String()
+			if compliance.IsExportFormat(format) {
+				var complianceReport v1alpha1.ClusterComplianceReport
+				err := GetComplianceReport(ctx, kubeClient, namespaceName, out, &complianceReport)
+				if err != nil {
+					return err
+				}
+				complianceDetailReport, err := GetComplianceDetailReport(ctx, kubeClient, namespaceName, out)
+				if err != nil {
+					return err
+				}
+				if err := compliance.Export(out, format, complianceReport, *complianceDetailReport); err != nil {
+					return fmt.Errorf("export compliance reports: %w", err)
+				}
+				return nil
+			}
+
 			printer, err := genericclioptions.NewPrintFlags("").
 				WithTypeSetter(scheme).
 				WithDefaultOutput(format).
@@ -91,25 +136,49 @@ func NewGetClusterComplianceReportsCmd(executable string, cf *genericclioptions.
 				return nil
 			}
 
-			detailNamespaceName, err := ComplianceNameFromArgs(args, "details")
-			if err != nil {
-				return err
-			}
-			var complianceDetailReport v1alpha1.ClusterComplianceDetailReport
-			err = GetComplianceReport(ctx, kubeClient, detailNamespaceName, out, &complianceDetailReport)
+			complianceDetailReport, err := GetComplianceDetailReport(ctx, kubeClient, namespaceName, out)
 			if err != nil {
 				return err
 			}
-			if err := printer.PrintObj(&complianceDetailReport, out); err != nil {
+			if err := printer.PrintObj(complianceDetailReport, out); err != nil {
 				return fmt.Errorf("print compliance reports: %w", err)
 			}
 			return nil
 		},
 	}
 	cmd.PersistentFlags().BoolP("detail", "d", false, "Get compliance detail report for control checks failure")
+	cmd.PersistentFlags().Bool("history", false, "Get the trend of recent compliance report snapshots and control transitions")
 	return cmd
 }
 
+func printComplianceHistory(out io.Writer, status v1alpha1.ReportStatus) error {
+	w := printers.GetNewTabWriter(out)
+	fmt.Fprintln(w, "UPDATED\tPASS\tFAIL\tWARN\tFAILED CONTROLS")
+	for _, snapshot := range status.History {
+		failedControls := strings.Join(snapshot.FailedControls, ",")
+		if failedControls == "" {
+			failedControls = "<none>"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\n", snapshot.UpdateTimestamp.UTC().Format(time.RFC3339),
+			snapshot.Summary.PassCount, snapshot.Summary.FailCount, snapshot.Summary.WarnCount, failedControls)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(status.Transitions) == 0 {
+		_, err := fmt.Fprintln(out, "\nNo control transitions found.")
+		return err
+	}
+	fmt.Fprintln(out)
+	w = printers.GetNewTabWriter(out)
+	fmt.Fprintln(w, "TIMESTAMP\tCONTROL\tFROM\tTO")
+	for _, transition := range status.Transitions {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", transition.Timestamp.UTC().Format(time.RFC3339),
+			transition.ID, transition.From, transition.To)
+	}
+	return w.Flush()
+}
+
 func GetComplianceReport(ctx context.Context, client client.Client, namespaceName types.NamespacedName, out io.Writer, report client.Object) error {
 	err := client.Get(ctx, namespaceName, report)
 	if err != nil {
@@ -121,3 +190,17 @@ func GetComplianceReport(ctx context.Context, client client.Client, namespaceNam
 	}
 	return nil
 }
+
+// GetComplianceDetailReport returns the detail report of the compliance report
+// with the given name, with all its shards put back together.
+func GetComplianceDetailReport(ctx context.Context, client client.Client, namespaceName types.NamespacedName, out io.Writer) (*v1alpha1.ClusterComplianceDetailReport, error) {
+	report, err := compliance.GetDetailReport(ctx, client, namespaceName.Name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			fmt.Fprintf(out, "No complaince reports found with name: %s .\n", namespaceName.Name)
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed getting report: %w", err)
+	}
+	return report, nil
+}
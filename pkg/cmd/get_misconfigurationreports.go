@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/trivymisconfig"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewGetMisconfigurationReportsCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "misconfigurationreports (NAME | TYPE/NAME)",
+		Aliases: []string{"misconfigs", "misconfig"},
+		Short:   "Get misconfiguration reports",
+		Long: `Get misconfiguration reports for the specified workload
+
+TYPE is a Kubernetes workload. Shortcuts and API groups will be resolved, e.g. 'po' or 'deployments.apps'.
+NAME is the name of a particular Kubernetes workload.
+`,
+		Example: fmt.Sprintf(`  # Get misconfiguration reports for a Deployment with the specified name
+  %[1]s get misconfigurationreports deploy/nginx
+
+  # Get misconfiguration reports for a Deployment with the specified name in the specified namespace
+  %[1]s get misconfigs deploy/nginx -n staging
+
+  # Get misconfiguration reports for a ReplicaSet with the specified name in JSON output format
+  %[1]s get misconfig replicaset/nginx -o json`, executable),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			scheme := starboard.NewScheme()
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+			if err != nil {
+				return err
+			}
+			ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+			if err != nil {
+				return err
+			}
+			mapper, err := cf.ToRESTMapper()
+			if err != nil {
+				return err
+			}
+			workload, _, err := WorkloadFromArgs(mapper, ns, args)
+			if err != nil {
+				return err
+			}
+			cm, err := kube.InitCompatibleMgr(kubeClient.RESTMapper())
+			if err != nil {
+				return err
+			}
+			objectResolver := kube.NewObjectResolver(kubeClient, cm)
+			reader := trivymisconfig.NewReadWriter(&objectResolver)
+			items, err := reader.FindByOwnerInHierarchy(ctx, workload)
+			if err != nil {
+				return fmt.Errorf("list misconfiguration reports: %w", err)
+			}
+			if len(items) == 0 {
+				fmt.Fprintf(out, "No reports found in %s namespace.\n", workload.Namespace)
+				return nil
+			}
+
+			format := cmd.Flag("output").Value.String()
+
+			var printer printers.ResourcePrinter
+
+			switch format {
+			case "yaml", "json":
+				printer, err = genericclioptions.NewPrintFlags("").
+					WithTypeSetter(scheme).
+					WithDefaultOutput(format).
+					ToPrinter()
+				if err != nil {
+					return err
+				}
+			case "":
+				printer = printers.NewTablePrinter(printers.PrintOptions{})
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json", format)
+			}
+
+			return printer.PrintObj(&v1alpha1.MisconfigurationReportList{Items: items}, out)
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewGetSBOMReportsCmd(executable string, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "sbomreports (NAME | TYPE/NAME)",
+		Aliases: []string{"sbom", "sboms"},
+		Short:   "Get SBOM reports",
+		Long: `Get SBOM reports for the specified workload
+
+TYPE is a Kubernetes workload. Shortcuts and API groups will be resolved, e.g. 'po' or 'deployments.apps'.
+NAME is the name of a particular Kubernetes workload.
+
+The cyclonedx and spdx output formats print the raw SBOM document of a single
+container, which must be selected with the --container flag if the workload
+has more than one container.
+`,
+		Example: fmt.Sprintf(`  # Get SBOM reports for a Deployment with the specified name
+  %[1]s get sbomreports deploy/nginx
+
+  # Get SBOM reports for a Deployment with the specified name in the specified namespace
+  %[1]s get sbom deploy/nginx -n staging
+
+  # Get the CycloneDX document of a Deployment with the specified name
+  %[1]s get sbom deploy/nginx -o cyclonedx
+
+  # Get the SPDX document of the specified container belonging to
+  # a ReplicaSet with the specified name
+  %[1]s get sbom replicaset/nginx --container nginx -o spdx`, executable),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			scheme := starboard.NewScheme()
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: scheme})
+			if err != nil {
+				return err
+			}
+			ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+			if err != nil {
+				return err
+			}
+			mapper, err := cf.ToRESTMapper()
+			if err != nil {
+				return err
+			}
+			workload, _, err := WorkloadFromArgs(mapper, ns, args)
+			if err != nil {
+				return err
+			}
+			cm, err := kube.InitCompatibleMgr(kubeClient.RESTMapper())
+			if err != nil {
+				return err
+			}
+			objectResolver := kube.NewObjectResolver(kubeClient, cm)
+			reader := sbomreport.NewReadWriter(&objectResolver)
+			items, err := reader.FindByOwnerInHierarchy(ctx, workload)
+			if err != nil {
+				return fmt.Errorf("list SBOM reports: %w", err)
+			}
+			if len(items) == 0 {
+				fmt.Fprintf(out, "No reports found in %s namespace.\n", workload.Namespace)
+				return nil
+			}
+
+			format := cmd.Flag("output").Value.String()
+			container := cmd.Flag("container").Value.String()
+
+			list := &v1alpha1.SBOMReportList{
+				Items: []v1alpha1.SBOMReport{},
+			}
+
+			for _, item := range items {
+				if container != "" && item.Labels[starboard.LabelContainerName] != container {
+					continue
+				}
+				list.Items = append(list.Items, item)
+			}
+			if len(items) > 0 && len(list.Items) == 0 {
+				return fmt.Errorf("container %s is not valid for %s %s", container, strings.ToLower(string(workload.Kind)), workload.Name)
+			}
+
+			var printer printers.ResourcePrinter
+
+			switch format {
+			case string(v1alpha1.SBOMFormatCycloneDX), string(v1alpha1.SBOMFormatSPDX):
+				return printSBOMDocument(ctx, reader, list.Items, v1alpha1.SBOMFormat(format), out)
+			case "yaml", "json":
+				printer, err = genericclioptions.NewPrintFlags("").
+					WithTypeSetter(starboard.NewScheme()).
+					WithDefaultOutput(format).
+					ToPrinter()
+				if err != nil {
+					return err
+				}
+			case "":
+				printer = printers.NewTablePrinter(printers.PrintOptions{})
+			default:
+				return fmt.Errorf("invalid output format %q, allowed formats are: yaml,json,cyclonedx,spdx", format)
+			}
+
+			return printer.PrintObj(list, out)
+		},
+	}
+
+	cmd.PersistentFlags().StringP("container", "c", "", "Get SBOM report of this container")
+
+	return cmd
+}
+
+// printSBOMDocument writes the raw SBOM document of the only report in the
+// given slice. The document must be stored in the requested format.
+func printSBOMDocument(ctx context.Context, reader sbomreport.Reader, reports []v1alpha1.SBOMReport, format v1alpha1.SBOMFormat, out io.Writer) error {
+	if len(reports) > 1 {
+		var containers []string
+		for _, report := range reports {
+			containers = append(containers, report.Labels[starboard.LabelContainerName])
+		}
+		return fmt.Errorf("found SBOM reports for %d containers, select one with the --container flag: %s",
+			len(reports), strings.Join(containers, ","))
+	}
+	report := reports[0]
+	if report.Report.Format != format {
+		return fmt.Errorf("SBOM report %s contains %s document, but %s was requested", report.Name, report.Report.Format, format)
+	}
+	document, err := reader.GetDocument(ctx, report)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(document))
+	return err
+}
@@ -24,10 +24,14 @@ Kubernetes cluster:
  - CustomResourceDefinition objects:
    - "vulnerabilityreports.aquasecurity.github.io"
    - "clustervulnerabilityreports.aquasecurity.github.io"
+   - "sbomreports.aquasecurity.github.io"
+   - "vulnerabilityexceptions.aquasecurity.github.io"
    - "configauditreports.aquasecurity.github.io"
    - "clusterconfigauditreports.aquasecurity.github.io"
+   - "misconfigurationreports.aquasecurity.github.io"
    - "ciskubebenchreports.aquasecurity.github.io"
    - "kubehunterreports.aquasecurity.github.io"
+   - "complianceattestations.aquasecurity.github.io"
  - RBAC objects:
    - The "starboard" ClusterRole
    - The "starboard" ClusterRoleBinding
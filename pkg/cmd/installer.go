@@ -5,10 +5,9 @@ import (
 	"fmt"
 	"time"
 
-	"k8s.io/apimachinery/pkg/types"
-
 	embedded "github.com/aquasecurity/starboard"
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/compliance"
 	"github.com/aquasecurity/starboard/pkg/plugin"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	corev1 "k8s.io/api/core/v1"
@@ -166,6 +165,22 @@ func (m *Installer) Install(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	sbomReportsCRD, err := embedded.GetSBOMReportsCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &sbomReportsCRD)
+	if err != nil {
+		return err
+	}
+	vulnerabilityExceptionsCRD, err := embedded.GetVulnerabilityExceptionsCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &vulnerabilityExceptionsCRD)
+	if err != nil {
+		return err
+	}
 	kubeBenchReportsCRD, err := embedded.GetCISKubeBenchReportsCRD()
 	if err != nil {
 		return err
@@ -200,6 +215,14 @@ func (m *Installer) Install(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	misconfigurationReportsCRD, err := embedded.GetMisconfigurationReportsCRD()
+	if err != nil {
+		return err
+	}
+	err = m.createOrUpdateCRD(ctx, &misconfigurationReportsCRD)
+	if err != nil {
+		return err
+	}
 	clusterComplianceReportsCRD, err := embedded.GetClusterComplianceReportsCRD()
 	if err != nil {
 		return err
@@ -216,15 +239,19 @@ func (m *Installer) Install(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-
-	// TODO We should wait for CRD statuses and make sure that the names were accepted
-
-	// compliance report
-	clusterComplianceReportSpec, err := embedded.GetNSASpecV10()
+	complianceAttestationsCRD, err := embedded.GetComplianceAttestationsCRD()
 	if err != nil {
 		return err
 	}
-	err = m.createOrUpdateComplianceSpec(ctx, clusterComplianceReportSpec)
+	err = m.createOrUpdateCRD(ctx, &complianceAttestationsCRD)
+	if err != nil {
+		return err
+	}
+
+	// TODO We should wait for CRD statuses and make sure that the names were accepted
+
+	// compliance reports
+	err = m.createOrUpdateComplianceSpecs(ctx)
 	if err != nil {
 		return err
 	}
@@ -414,18 +441,28 @@ func (m *Installer) createOrUpdateCRD(ctx context.Context, crd *ext.CustomResour
 	return
 }
 
-func (m *Installer) createOrUpdateComplianceSpec(ctx context.Context, spec v1alpha1.ClusterComplianceReport) error {
-	namespaceName := types.NamespacedName{Name: spec.Spec.Name}
-	err := m.client.Get(ctx, namespaceName, &spec)
-	switch {
-	case err == nil:
-		klog.V(3).Infof("Updating compliance spec %q", spec.Spec.Name)
-		deepCopy := spec.DeepCopy()
-		deepCopy.Spec = spec.Spec
-		return m.client.Update(ctx, deepCopy)
-	case errors.IsNotFound(err):
-		klog.V(3).Infof("Creating compliance spec %q", spec.Spec.Name)
-		return m.client.Create(ctx, &spec)
+// createOrUpdateComplianceSpecs validates bundled compliance specs against
+// built-in checks and creates or updates them.
+func (m *Installer) createOrUpdateComplianceSpecs(ctx context.Context) error {
+	policies, err := embedded.PoliciesConfigMap()
+	if err != nil {
+		return err
+	}
+	catalog := compliance.NewCheckCatalog(policies.Data)
+	specs, err := embedded.GetComplianceSpecs()
+	if err != nil {
+		return err
+	}
+	for _, spec := range specs {
+		err = compliance.ValidateSpec(spec.Spec, catalog)
+		if err != nil {
+			return fmt.Errorf("validating compliance spec %s: %w", spec.Name, err)
+		}
+		klog.V(3).Infof("Creating or updating compliance spec %q", spec.Name)
+		err = compliance.CreateOrUpdateSpec(ctx, m.client, spec)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -448,6 +485,14 @@ func (m *Installer) Uninstall(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	err = m.deleteCRD(ctx, v1alpha1.SBOMReportsCRName)
+	if err != nil {
+		return err
+	}
+	err = m.deleteCRD(ctx, v1alpha1.VulnerabilityExceptionsCRName)
+	if err != nil {
+		return err
+	}
 	err = m.deleteCRD(ctx, v1alpha1.CISKubeBenchReportCRName)
 	if err != nil {
 		return err
@@ -464,6 +509,10 @@ func (m *Installer) Uninstall(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	err = m.deleteCRD(ctx, v1alpha1.MisconfigurationReportsCRName)
+	if err != nil {
+		return err
+	}
 	err = m.deleteCRD(ctx, v1alpha1.ClusterComplianceReportCRName)
 	if err != nil {
 		return err
@@ -472,6 +521,10 @@ func (m *Installer) Uninstall(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	err = m.deleteCRD(ctx, v1alpha1.ComplianceAttestationsCRName)
+	if err != nil {
+		return err
+	}
 	err = m.cleanupRBAC(ctx)
 	if err != nil {
 		return err
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/spf13/cobra"
+	"k8s.io/klog/v2"
+)
+
+const (
+	resultFileFlagName = "result-file"
+	readyFileFlagName  = "ready-file"
+	portFlagName       = "port"
+)
+
+// NewResultCollectorCmd returns the command run by the result collector
+// sidecar of scan jobs. It is not meant to be run by users.
+func NewResultCollectorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:    "result-collector",
+		Short:  "Serve the result file of a scan job until it is released",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resultFile, err := cmd.Flags().GetString(resultFileFlagName)
+			if err != nil {
+				return err
+			}
+			readyFile, err := cmd.Flags().GetString(readyFileFlagName)
+			if err != nil {
+				return err
+			}
+			port, err := cmd.Flags().GetInt(portFlagName)
+			if err != nil {
+				return err
+			}
+
+			handler := kube.NewResultServer(resultFile, readyFile)
+			server := &http.Server{
+				Addr:    fmt.Sprintf(":%d", port),
+				Handler: handler,
+			}
+			go func() {
+				<-handler.Released()
+				klog.V(3).Infof("Result released, shutting down")
+				_ = server.Shutdown(context.Background())
+			}()
+			err = server.ListenAndServe()
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String(resultFileFlagName, "/var/report/trivy.json", "Path of the result file written by the scanner")
+	cmd.Flags().String(readyFileFlagName, "/var/report/done.txt", "Path of the file created by the scanner once the result file is complete")
+	cmd.Flags().Int(portFlagName, kube.ResultCollectorPort, "Port to serve the result on")
+	return cmd
+}
@@ -53,11 +53,16 @@ func NewRootCmd(buildInfo starboard.BuildInfo, args []string, outWriter io.Write
 
 	rootCmd.AddCommand(NewVersionCmd(buildInfo, outWriter))
 	rootCmd.AddCommand(NewInitCmd(buildInfo, cf))
-	rootCmd.AddCommand(NewScanCmd(buildInfo, cf))
+	rootCmd.AddCommand(NewScanCmd(buildInfo, cf, outWriter))
 	rootCmd.AddCommand(NewGetCmd(buildInfo, cf, outWriter))
+	rootCmd.AddCommand(NewDiffCmd(buildInfo, cf, outWriter))
+	rootCmd.AddCommand(NewDBCmd(buildInfo, cf, outWriter))
+	rootCmd.AddCommand(NewVEXCmd(buildInfo, cf, outWriter))
 	rootCmd.AddCommand(NewReportCmd(buildInfo, cf, outWriter))
 	rootCmd.AddCommand(NewCleanupCmd(buildInfo, cf))
 	rootCmd.AddCommand(NewConfigCmd(cf, outWriter))
+	rootCmd.AddCommand(NewResultCollectorCmd())
+	rootCmd.AddCommand(NewDBImportServerCmd())
 
 	SetGlobalFlags(cf, rootCmd)
 
@@ -1,12 +1,14 @@
 package cmd
 
 import (
+	"io"
+
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 )
 
-func NewScanCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) *cobra.Command {
+func NewScanCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, outWriter io.Writer) *cobra.Command {
 	scanCmd := &cobra.Command{
 		Use:     "scan",
 		Aliases: []string{"generate"},
@@ -15,8 +17,8 @@ func NewScanCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags
 	scanCmd.AddCommand(NewScanConfigAuditReportsCmd(buildInfo, cf))
 	scanCmd.AddCommand(NewScanKubeBenchReportsCmd(cf))
 	scanCmd.AddCommand(NewScanKubeHunterReportsCmd(cf))
-	scanCmd.AddCommand(NewScanVulnerabilityReportsCmd(buildInfo, cf))
-	scanCmd.AddCommand(NewScanMisconfigurationReportsCmd(buildInfo, cf))
+	scanCmd.AddCommand(NewScanVulnerabilityReportsCmd(buildInfo, cf, outWriter))
+	scanCmd.AddCommand(NewScanMisconfigurationReportsCmd(buildInfo, cf, outWriter))
 
 	return scanCmd
 }
@@ -3,31 +3,47 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/plugin"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/trivymisconfig"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func NewScanMisconfigurationReportsCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) *cobra.Command {
+func NewScanMisconfigurationReportsCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Aliases: []string{"misconfigs", "misconfig"},
 		Use:     "misconfigurationreports (NAME | TYPE/NAME)",
-		RunE:    ScanMisconfigurationReports(buildInfo, cf),
+		Short:   "Run Trivy misconfiguration scanner for a given workload",
+		Example: fmt.Sprintf(`  # Scan a deployment with the specified name
+  %[1]s scan misconfigurationreports deploy/nginx
+
+  # Scan a deployment in YAML output format and save the raw Trivy report
+  %[1]s scan misconfigs deploy/nginx -o yaml --output-file trivy.json`, buildInfo.Executable),
+		Args: cobra.ExactArgs(1),
+		RunE: ScanMisconfigurationReports(buildInfo, cf, out),
 	}
 
 	registerScannerOpts(cmd)
+	registerScanOutputFlags(cmd)
 
 	return cmd
 }
 
-func ScanMisconfigurationReports(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) func(cmd *cobra.Command, args []string) error {
+func ScanMisconfigurationReports(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
+		format, err := getScanOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
 		ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
 		if err != nil {
 			return err
@@ -61,6 +77,16 @@ func ScanMisconfigurationReports(buildInfo starboard.BuildInfo, cf *genericcliop
 		if err != nil {
 			return err
 		}
+		rawReport, err := openRawReportFile(cmd)
+		if err != nil {
+			return err
+		}
+		if rawReport != nil {
+			defer func() {
+				_ = rawReport.Close()
+			}()
+			opts.RawReportWriter = rawReport
+		}
 		plugin, pluginContext, err := plugin.NewResolver().
 			WithBuildInfo(buildInfo).
 			WithNamespace(starboard.NamespaceName).
@@ -71,13 +97,43 @@ func ScanMisconfigurationReports(buildInfo starboard.BuildInfo, cf *genericcliop
 		if err != nil {
 			return err
 		}
-		scanner := trivymisconfig.NewScanner(kubeClientset, kubeClient, plugin, pluginContext, config, opts)
+		cm, err := kube.InitCompatibleMgr(kubeClient.RESTMapper())
+		if err != nil {
+			return err
+		}
+		scanner := trivymisconfig.NewScanner(kubeClientset, kubeClient, cm, plugin, pluginContext, config, opts)
 		reports, err := scanner.Scan(ctx, workload)
 		if err != nil {
 			return err
 		}
-		fmt.Println(reports)
-		// writer := trivymisconfig.NewReadWriter(kubeClient)
-		return nil
+		objectResolver := kube.NewObjectResolver(kubeClient, cm)
+		err = trivymisconfig.NewReadWriter(&objectResolver).Write(ctx, reports)
+		if err != nil {
+			return fmt.Errorf("writing misconfiguration reports: %w", err)
+		}
+		return printMisconfigurationReports(out, format, reports)
+	}
+}
+
+func printMisconfigurationReports(out io.Writer, format string, reports []v1alpha1.MisconfigurationReport) error {
+	list := &v1alpha1.MisconfigurationReportList{
+		Items: reports,
+	}
+	if list.Items == nil {
+		list.Items = []v1alpha1.MisconfigurationReport{}
+	}
+	if format != "table" {
+		return printScanResult(out, format, list)
+	}
+
+	w := printers.GetNewTabWriter(out)
+	fmt.Fprintln(w, "NAMESPACE\tKIND\tNAME\tID\tSEVERITY\tSTATUS\tTITLE")
+	for _, report := range list.Items {
+		for _, misconfiguration := range report.Report.Misconfigurations {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				report.Namespace, report.Labels[starboard.LabelResourceKind], report.Labels[starboard.LabelResourceName],
+				misconfiguration.ID, misconfiguration.Severity, misconfiguration.Status, misconfiguration.Title)
+		}
 	}
+	return w.Flush()
 }
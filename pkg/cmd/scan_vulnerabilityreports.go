@@ -2,14 +2,20 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/plugin"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -18,18 +24,24 @@ const (
 	vulnerabilitiesCmdShort = "Run static vulnerability scanner for each container image of a given workload"
 	vulnerabilitiesCmdLong  = `Scan a given workload for vulnerabilities using Trivy scanner
 
+The scan covers the cluster as configured for the Trivy plugin. If a workload is given, only its
+vulnerability reports are saved and printed.
+
 TYPE is a Kubernetes workload. Shortcuts and API groups will be resolved, e.g. 'po' or 'deployments.apps'.
 NAME is the name of a particular Kubernetes workload.
 `
 )
 
-func NewScanVulnerabilityReportsCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) *cobra.Command {
+func NewScanVulnerabilityReportsCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
 	cmd := &cobra.Command{
 		Aliases: []string{"vulns", "vuln"},
-		Use:     "vulnerabilityreports (NAME | TYPE/NAME)",
+		Use:     "vulnerabilityreports [NAME | TYPE/NAME]",
 		Short:   vulnerabilitiesCmdShort,
 		Long:    vulnerabilitiesCmdLong,
-		Example: fmt.Sprintf(`  # Scan a pod with the specified name
+		Example: fmt.Sprintf(`  # Scan the cluster
+  %[1]s scan vulnerabilityreports
+
+  # Scan a pod with the specified name
   %[1]s scan vulnerabilities nginx
 
   # Scan a pod with the specified name in the specified namespace
@@ -54,18 +66,43 @@ func NewScanVulnerabilityReportsCmd(buildInfo starboard.BuildInfo, cf *genericcl
   %[1]s scan vulnerabilityreports job/my-job
 
   # Scan a cronjob with the specified name and the specified scan job timeout
-  %[1]s scan vulnerabilityreports cj/my-cronjob --scan-job-timeout 2m`, buildInfo.Executable),
-		RunE: ScanVulnerabilityReports(buildInfo, cf),
+  %[1]s scan vulnerabilityreports cj/my-cronjob --scan-job-timeout 2m
+
+  # Scan a deployment in JSON output format and save the raw Trivy report
+  %[1]s scan vulnerabilityreports deploy/nginx -o json --output-file trivy.json`, buildInfo.Executable),
+		Args: cobra.MaximumNArgs(1),
+		RunE: ScanVulnerabilityReports(buildInfo, cf, out),
 	}
 
 	registerScannerOpts(cmd)
+	registerScanOutputFlags(cmd)
 
 	return cmd
 }
 
-func ScanVulnerabilityReports(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags) func(cmd *cobra.Command, args []string) error {
+func ScanVulnerabilityReports(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
+		format, err := getScanOutputFormat(cmd)
+		if err != nil {
+			return err
+		}
+		var workload *kube.ObjectRef
+		if len(args) > 0 {
+			ns, _, err := cf.ToRawKubeConfigLoader().Namespace()
+			if err != nil {
+				return err
+			}
+			mapper, err := cf.ToRESTMapper()
+			if err != nil {
+				return err
+			}
+			ref, _, err := WorkloadFromArgs(mapper, ns, args)
+			if err != nil {
+				return err
+			}
+			workload = &ref
+		}
 		kubeConfig, err := cf.ToRESTConfig()
 		if err != nil {
 			return err
@@ -87,6 +124,16 @@ func ScanVulnerabilityReports(buildInfo starboard.BuildInfo, cf *genericclioptio
 		if err != nil {
 			return err
 		}
+		rawReport, err := openRawReportFile(cmd)
+		if err != nil {
+			return err
+		}
+		if rawReport != nil {
+			defer func() {
+				_ = rawReport.Close()
+			}()
+			opts.RawReportWriter = rawReport
+		}
 		plugin, pluginContext, err := plugin.NewResolver().
 			WithBuildInfo(buildInfo).
 			WithNamespace(starboard.NamespaceName).
@@ -101,7 +148,99 @@ func ScanVulnerabilityReports(buildInfo starboard.BuildInfo, cf *genericclioptio
 		if err != nil {
 			return err
 		}
-		vulnerabilityreport.NewScanner(kubeClientset, kubeClient, cm, plugin, pluginContext, config, opts)
-		return nil
+		trivyReport, err := vulnerabilityreport.NewScanner(kubeClientset, kubeClient, cm, plugin, pluginContext, config, opts).
+			Scan(ctx)
+		if err != nil {
+			return err
+		}
+		if total := trivyReport.ErrorSummary.Total(); total > 0 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: scanner reported %d errors, failed images: %v\n", total, trivyReport.FailedImages())
+		}
+
+		exploits, err := vulnerabilityreport.LoadExploitCatalog(ctx, kubeClient, starboard.NamespaceName)
+		var invalidExploits *vulnerabilityreport.InvalidExploitCatalogError
+		if errors.As(err, &invalidExploits) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: ignoring invalid exploit catalog: %v\n", err)
+		} else if err != nil {
+			return err
+		}
+		vex, invalid, err := vulnerabilityreport.LoadVEXDocuments(ctx, kubeClient, starboard.NamespaceName)
+		if err != nil {
+			return err
+		}
+		for _, document := range invalid {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: skipping invalid VEX document: %v\n", document)
+		}
+		objectResolver := kube.NewObjectResolver(kubeClient, cm)
+		converter := vulnerabilityreport.NewConverter(scheme, &objectResolver, ext.NewSystemClock()).
+			WithImageCache(vulnerabilityreport.NewImageCache(kubeClient, ext.NewSystemClock(), 0)).
+			WithExploitCatalog(exploits).
+			WithVEXDocuments(vex).
+			WithExceptions()
+		if pluginContext.GetName() == trivy.Plugin {
+			pluginConfig, err := pluginContext.GetConfig()
+			if err != nil {
+				return err
+			}
+			scope, err := trivy.Config{PluginConfig: pluginConfig}.GetScanScope()
+			if err != nil {
+				return err
+			}
+			converter.WithResourceFilter(scope.Matches)
+		}
+		reports, err := converter.Convert(ctx, trivyReport)
+		if err != nil {
+			return fmt.Errorf("converting scan report: %w", err)
+		}
+		if workload != nil {
+			reports = filterVulnerabilityReports(reports, *workload)
+		}
+
+		err = vulnerabilityreport.NewReadWriter(&objectResolver).Write(ctx, reports)
+		if err != nil {
+			return fmt.Errorf("writing vulnerability reports: %w", err)
+		}
+		return printVulnerabilityReports(out, format, reports)
+	}
+}
+
+// filterVulnerabilityReports returns the reports created for the given
+// workload, which are labeled with the kind and name of the scanned resource.
+func filterVulnerabilityReports(reports []v1alpha1.VulnerabilityReport, workload kube.ObjectRef) []v1alpha1.VulnerabilityReport {
+	var filtered []v1alpha1.VulnerabilityReport
+	for _, report := range reports {
+		if report.Labels[starboard.LabelResourceKind] == string(workload.Kind) &&
+			report.Labels[starboard.LabelResourceName] == workload.Name &&
+			report.Labels[starboard.LabelResourceNamespace] == workload.Namespace {
+			filtered = append(filtered, report)
+		}
+	}
+	return filtered
+}
+
+func printVulnerabilityReports(out io.Writer, format string, reports []v1alpha1.VulnerabilityReport) error {
+	list := &v1alpha1.VulnerabilityReportList{
+		Items: reports,
+	}
+	if list.Items == nil {
+		list.Items = []v1alpha1.VulnerabilityReport{}
+	}
+	if format != "table" {
+		return printScanResult(out, format, list)
+	}
+
+	w := printers.GetNewTabWriter(out)
+	fmt.Fprintln(w, "NAMESPACE\tKIND\tNAME\tCONTAINER\tIMAGE\tCRITICAL\tHIGH\tMEDIUM\tLOW\tUNKNOWN")
+	for _, report := range list.Items {
+		image := report.Report.Artifact.Repository
+		if report.Report.Artifact.Tag != "" {
+			image += ":" + report.Report.Artifact.Tag
+		}
+		summary := report.Report.Summary
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\n",
+			report.Labels[starboard.LabelResourceNamespace], report.Labels[starboard.LabelResourceKind],
+			report.Labels[starboard.LabelResourceName], report.Labels[starboard.LabelContainerName], image,
+			summary.CriticalCount, summary.HighCount, summary.MediumCount, summary.LowCount, summary.UnknownCount)
 	}
+	return w.Flush()
 }
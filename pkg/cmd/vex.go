@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+func NewVEXCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, outWriter io.Writer) *cobra.Command {
+	vexCmd := &cobra.Command{
+		Use:   "vex",
+		Short: "Manage VEX documents of container images",
+	}
+	vexCmd.AddCommand(NewVEXImportCmd(buildInfo, cf, outWriter))
+
+	return vexCmd
+}
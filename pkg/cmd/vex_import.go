@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const vexImportNameFlagName = "name"
+
+func NewVEXImportCmd(buildInfo starboard.BuildInfo, cf *genericclioptions.ConfigFlags, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import FILE",
+		Short: "Import an OpenVEX document",
+		Long: `Import an OpenVEX document
+
+FILE is an OpenVEX document published by the vendor of container images. It's stored in
+the vex-NAME ConfigMap in the starboard namespace, which is replaced if it already exists.
+NAME defaults to the file name without extension.
+
+Statements of the document set the vexStatus property of matching vulnerabilities in
+existing vulnerabilityreports, and of vulnerabilities in reports created by subsequent scans.
+Vulnerabilities that are not_affected are not counted in the summary of a report.
+`,
+		Example: fmt.Sprintf(`  # Import the VEX document of nginx images
+  %[1]s vex import nginx.openvex.json
+
+  # Import the VEX document under the given name
+  %[1]s vex import vex.json --name nginx`, buildInfo.Executable),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := context.Background()
+			name, err := cmd.Flags().GetString(vexImportNameFlagName)
+			if err != nil {
+				return err
+			}
+			if name == "" {
+				name = strings.ToLower(strings.SplitN(filepath.Base(args[0]), ".", 2)[0])
+			}
+			if errs := validation.IsDNS1123Subdomain("vex-" + name); len(errs) > 0 {
+				return fmt.Errorf("invalid name %q: %s", name, strings.Join(errs, ", "))
+			}
+
+			content, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			document, err := vulnerabilityreport.ParseVEXDocument(bytes.NewReader(content))
+			if err != nil {
+				return err
+			}
+
+			kubeConfig, err := cf.ToRESTConfig()
+			if err != nil {
+				return err
+			}
+			kubeClient, err := client.New(kubeConfig, client.Options{Scheme: starboard.NewScheme()})
+			if err != nil {
+				return err
+			}
+
+			desired := vulnerabilityreport.GetVEXDocumentConfigMap(starboard.NamespaceName, name, content)
+			cm := &corev1.ConfigMap{}
+			cm.Namespace = desired.Namespace
+			cm.Name = desired.Name
+			_, err = controllerutil.CreateOrUpdate(ctx, kubeClient, cm, func() error {
+				cm.Labels = desired.Labels
+				cm.Data = desired.Data
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("saving VEX document: %w", err)
+			}
+
+			documents, invalid, err := vulnerabilityreport.LoadVEXDocuments(ctx, kubeClient, starboard.NamespaceName)
+			if err != nil {
+				return err
+			}
+			for _, document := range invalid {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: skipping invalid VEX document: %v\n", document)
+			}
+			updated, err := vulnerabilityreport.UpdateVEXStatus(ctx, kubeClient, documents)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(out, "Imported %d statements into ConfigMap %s/%s and updated %d vulnerability reports\n",
+				len(document.Statements), cm.Namespace, cm.Name, updated)
+			return err
+		},
+	}
+	cmd.Flags().String(vexImportNameFlagName, "", "The name of the VEX document, defaults to the file name without extension")
+	return cmd
+}
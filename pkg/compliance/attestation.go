@@ -0,0 +1,93 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+// attestationsByControl returns ComplianceAttestations of controls of the
+// given spec by control id. If a control has several attestations, the one
+// which expires last is returned.
+func (w *cm) attestationsByControl(ctx context.Context, spec v1alpha1.ReportSpec) (map[string]v1alpha1.ComplianceAttestation, error) {
+	var attestationList v1alpha1.ComplianceAttestationList
+	err := w.client.List(ctx, &attestationList)
+	if err != nil {
+		return nil, fmt.Errorf("listing compliance attestations: %w", err)
+	}
+	attestations := make(map[string]v1alpha1.ComplianceAttestation)
+	for _, attestation := range attestationList.Items {
+		if attestation.Spec.Compliance != strings.ToLower(spec.Name) {
+			continue
+		}
+		latest, ok := attestations[attestation.Spec.ControlID]
+		if ok && !attestation.Spec.ExpiresAt.After(latest.Spec.ExpiresAt.Time) {
+			continue
+		}
+		attestations[attestation.Spec.ControlID] = attestation
+	}
+	return attestations, nil
+}
+
+// manualControlChecks returns control checks of manual controls of the given
+// spec, which have the WARN status until attested.
+func manualControlChecks(smd *specDataMapping) []v1alpha1.ControlCheck {
+	controlChecks := make([]v1alpha1.ControlCheck, 0)
+	for controlID, control := range smd.controlIDControlObject {
+		if control.Mapping.Scanner != Manual {
+			continue
+		}
+		controlChecks = append(controlChecks, v1alpha1.ControlCheck{ID: controlID,
+			Name:        control.Name,
+			Description: control.Description,
+			Severity:    control.Severity,
+			Status:      v1alpha1.WarnStatus})
+	}
+	sort.Slice(controlChecks, func(i, j int) bool {
+		return controlChecks[i].ID < controlChecks[j].ID
+	})
+	return controlChecks
+}
+
+// applyAttestations sets the status of control checks with the WARN status,
+// i.e. manual controls and controls without results, to the status of their
+// attestations. Expired attestations fail the control. It returns details of
+// controls failed by attestations.
+func applyAttestations(controlChecks []v1alpha1.ControlCheck, attestations map[string]v1alpha1.ComplianceAttestation, now time.Time) []v1alpha1.ControlCheckDetails {
+	details := make([]v1alpha1.ControlCheckDetails, 0)
+	for i, controlCheck := range controlChecks {
+		if controlCheck.Status != v1alpha1.WarnStatus {
+			continue
+		}
+		attestation, ok := attestations[controlCheck.ID]
+		if !ok {
+			continue
+		}
+		status := attestation.Spec.Status
+		msg := fmt.Sprintf("%s (attested by %s)", attestation.Spec.Evidence, attestation.Spec.Author)
+		if attestation.IsExpired(now) {
+			status = v1alpha1.FailStatus
+			msg = fmt.Sprintf("Attestation by %s expired at %s", attestation.Spec.Author, attestation.Spec.ExpiresAt.UTC().Format(time.RFC3339))
+		}
+		if status == v1alpha1.PassStatus {
+			controlChecks[i].Status = v1alpha1.PassStatus
+			controlChecks[i].PassTotal = 1
+			continue
+		}
+		controlChecks[i].Status = v1alpha1.FailStatus
+		controlChecks[i].FailTotal = 1
+		details = append(details, v1alpha1.ControlCheckDetails{ID: controlCheck.ID,
+			Name:        controlCheck.Name,
+			Description: controlCheck.Description,
+			Severity:    controlCheck.Severity,
+			ScannerCheckResult: []v1alpha1.ScannerCheckResult{{
+				ObjectType: v1alpha1.ComplianceAttestationKind,
+				Details:    []v1alpha1.ResultDetails{{Name: attestation.Name, Msg: msg, Status: v1alpha1.FailStatus}},
+			}}})
+	}
+	return details
+}
@@ -0,0 +1,184 @@
+package compliance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func getAttestation(name, compliance, controlID string, status v1alpha1.ControlStatus, expiresAt time.Time) *v1alpha1.ComplianceAttestation {
+	return &v1alpha1.ComplianceAttestation{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.ComplianceAttestationSpec{
+			Compliance: compliance,
+			ControlID:  controlID,
+			Status:     status,
+			Evidence:   "Reviewed the incident response runbook",
+			Author:     "security-team",
+			ExpiresAt:  metav1.NewTime(expiresAt),
+		},
+	}
+}
+
+func TestApplyAttestations(t *testing.T) {
+	now := time.Date(2022, 9, 1, 10, 0, 0, 0, time.UTC)
+	controlChecks := []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Non-root containers", PassTotal: 2, Status: v1alpha1.PassStatus},
+		{ID: "9.0", Name: "Incident response", Status: v1alpha1.WarnStatus},
+		{ID: "9.1", Name: "Backups", Status: v1alpha1.WarnStatus},
+		{ID: "9.2", Name: "Access reviews", Status: v1alpha1.WarnStatus},
+		{ID: "9.3", Name: "Security training", Status: v1alpha1.WarnStatus},
+	}
+	attestations := map[string]v1alpha1.ComplianceAttestation{
+		"1.0": *getAttestation("nsa-1.0", "nsa", "1.0", v1alpha1.FailStatus, now.Add(time.Hour)),
+		"9.0": *getAttestation("nsa-9.0", "nsa", "9.0", v1alpha1.PassStatus, now.Add(time.Hour)),
+		"9.1": *getAttestation("nsa-9.1", "nsa", "9.1", v1alpha1.FailStatus, now.Add(time.Hour)),
+		"9.2": *getAttestation("nsa-9.2", "nsa", "9.2", v1alpha1.PassStatus, now),
+	}
+
+	details := applyAttestations(controlChecks, attestations, now)
+	assert.Equal(t, []v1alpha1.ControlCheck{
+		{ID: "1.0", Name: "Non-root containers", PassTotal: 2, Status: v1alpha1.PassStatus},
+		{ID: "9.0", Name: "Incident response", PassTotal: 1, Status: v1alpha1.PassStatus},
+		{ID: "9.1", Name: "Backups", FailTotal: 1, Status: v1alpha1.FailStatus},
+		{ID: "9.2", Name: "Access reviews", FailTotal: 1, Status: v1alpha1.FailStatus},
+		{ID: "9.3", Name: "Security training", Status: v1alpha1.WarnStatus},
+	}, controlChecks)
+	assert.Equal(t, []v1alpha1.ControlCheckDetails{
+		{ID: "9.1", Name: "Backups", ScannerCheckResult: []v1alpha1.ScannerCheckResult{{
+			ObjectType: "ComplianceAttestation",
+			Details: []v1alpha1.ResultDetails{{
+				Name:   "nsa-9.1",
+				Msg:    "Reviewed the incident response runbook (attested by security-team)",
+				Status: v1alpha1.FailStatus,
+			}},
+		}}},
+		{ID: "9.2", Name: "Access reviews", ScannerCheckResult: []v1alpha1.ScannerCheckResult{{
+			ObjectType: "ComplianceAttestation",
+			Details: []v1alpha1.ResultDetails{{
+				Name:   "nsa-9.2",
+				Msg:    "Attestation by security-team expired at 2022-09-01T10:00:00Z",
+				Status: v1alpha1.FailStatus,
+			}},
+		}}},
+	}, details)
+}
+
+func TestMgr_GenerateComplianceReport_Attestations(t *testing.T) {
+	now := time.Now()
+	spec := &v1alpha1.ClusterComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "nsa"},
+		Spec: v1alpha1.ReportSpec{
+			Name:    "nsa",
+			Version: "1.0",
+			Cron:    "0 */3 * * *",
+			Controls: []v1alpha1.Control{
+				{
+					ID:       "1.0",
+					Name:     "Non-root containers",
+					Kinds:    []string{"Workload"},
+					Mapping:  v1alpha1.Mapping{Scanner: ConfigAudit, Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}},
+					Severity: v1alpha1.SeverityMedium,
+				},
+				{
+					ID:       "1.1",
+					Name:     "Immutable container file systems",
+					Kinds:    []string{"Workload"},
+					Mapping:  v1alpha1.Mapping{Scanner: ConfigAudit, Checks: []v1alpha1.SpecCheck{{ID: "KSV014"}}},
+					Severity: v1alpha1.SeverityLow,
+				},
+				{ID: "9.0", Name: "Incident response", Mapping: v1alpha1.Mapping{Scanner: Manual}, Severity: v1alpha1.SeverityHigh},
+				{ID: "9.1", Name: "Backups", Mapping: v1alpha1.Mapping{Scanner: Manual}, Severity: v1alpha1.SeverityHigh},
+				{ID: "9.2", Name: "Access reviews", Mapping: v1alpha1.Mapping{Scanner: Manual}, Severity: v1alpha1.SeverityMedium},
+			},
+		},
+	}
+	report := &v1alpha1.ConfigAuditReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "replicaset-api",
+			Labels: map[string]string{
+				starboard.LabelResourceKind:      "ReplicaSet",
+				starboard.LabelResourceName:      "api",
+				starboard.LabelResourceNamespace: "default",
+			},
+		},
+		Report: v1alpha1.ConfigAuditReportData{
+			Checks: []v1alpha1.Check{{ID: "KSV012", Success: true}},
+		},
+	}
+	objects := []client.Object{
+		spec,
+		report,
+		// the attestation which expires last is used
+		getAttestation("nsa-9.0-old", "nsa", "9.0", v1alpha1.FailStatus, now.Add(time.Hour)),
+		getAttestation("nsa-9.0", "nsa", "9.0", v1alpha1.PassStatus, now.Add(24*time.Hour)),
+		getAttestation("nsa-9.1", "nsa", "9.1", v1alpha1.PassStatus, now.Add(-time.Hour)),
+		// attestations of other compliance reports are ignored
+		getAttestation("cis-9.2", "cis", "9.2", v1alpha1.PassStatus, now.Add(time.Hour)),
+	}
+	testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()
+	mgr := NewMgr(testClient, kube.NewObjectResolver(testClient, nil), log.Log, starboard.ConfigData{}, ext.NewSystemClock())
+
+	_, err := mgr.GenerateComplianceReport(context.TODO(), spec.Spec)
+	require.NoError(t, err)
+
+	var updated v1alpha1.ClusterComplianceReport
+	require.NoError(t, testClient.Get(context.TODO(), types.NamespacedName{Name: "nsa"}, &updated))
+	assert.Equal(t, v1alpha1.ClusterComplianceSummary{PassCount: 2, FailCount: 1, WarnCount: 2, ManualCount: 3}, updated.Status.Summary)
+	statuses := make(map[string]v1alpha1.ControlStatus)
+	for _, check := range updated.Status.ControlChecks {
+		statuses[check.ID] = check.Status
+	}
+	assert.Equal(t, map[string]v1alpha1.ControlStatus{
+		"1.0": v1alpha1.PassStatus,
+		"1.1": v1alpha1.WarnStatus,
+		"9.0": v1alpha1.PassStatus,
+		"9.1": v1alpha1.FailStatus,
+		"9.2": v1alpha1.WarnStatus,
+	}, statuses)
+
+	var detail v1alpha1.ClusterComplianceDetailReport
+	require.NoError(t, testClient.Get(context.TODO(), types.NamespacedName{Name: "nsa-details"}, &detail))
+	require.Len(t, detail.Report.ControlChecks, 1)
+	assert.Equal(t, "9.1", detail.Report.ControlChecks[0].ID)
+	assert.Equal(t, "ComplianceAttestation", detail.Report.ControlChecks[0].ScannerCheckResult[0].ObjectType)
+	assert.Equal(t, "nsa-9.1", detail.Report.ControlChecks[0].ScannerCheckResult[0].Details[0].Name)
+}
+
+func TestMgr_GenerateComplianceReport_ManualOnly(t *testing.T) {
+	spec := &v1alpha1.ClusterComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "policies"},
+		Spec: v1alpha1.ReportSpec{
+			Name:    "policies",
+			Version: "1.0",
+			Cron:    "0 */3 * * *",
+			Controls: []v1alpha1.Control{
+				{ID: "9.0", Name: "Incident response", Mapping: v1alpha1.Mapping{Scanner: Manual}, Severity: v1alpha1.SeverityHigh},
+				{ID: "9.1", Name: "Backups", Mapping: v1alpha1.Mapping{Scanner: Manual}, Severity: v1alpha1.SeverityHigh},
+			},
+		},
+	}
+	testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(spec).Build()
+	mgr := NewMgr(testClient, kube.NewObjectResolver(testClient, nil), log.Log, starboard.ConfigData{}, ext.NewSystemClock())
+
+	_, err := mgr.GenerateComplianceReport(context.TODO(), spec.Spec)
+	require.NoError(t, err)
+
+	var updated v1alpha1.ClusterComplianceReport
+	require.NoError(t, testClient.Get(context.TODO(), types.NamespacedName{Name: "policies"}, &updated))
+	assert.Equal(t, v1alpha1.ClusterComplianceSummary{WarnCount: 2, ManualCount: 2}, updated.Status.Summary)
+	assert.Len(t, updated.Status.ControlChecks, 2)
+}
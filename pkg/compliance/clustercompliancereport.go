@@ -9,19 +9,34 @@ import (
 	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/utils"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const (
+	// ReasonControlFailed is the reason of the event raised when a control of
+	// a compliance report starts failing.
+	ReasonControlFailed = "ComplianceControlFailed"
+	// ReasonControlPassed is the reason of the event raised when a failing
+	// control of a compliance report passes again.
+	ReasonControlPassed = "ComplianceControlPassed"
+	// ReasonControlWarned is the reason of the event raised when a control of
+	// a compliance report changes to the WARN status.
+	ReasonControlWarned = "ComplianceControlWarned"
+)
+
 type ClusterComplianceReportReconciler struct {
 	logr.Logger
 	client.Client
 	Mgr
 	ext.Clock
+	record.EventRecorder
 }
 
 func (r *ClusterComplianceReportReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -58,11 +73,13 @@ func (r *ClusterComplianceReportReconciler) generateComplianceReport(ctx context
 			return fmt.Errorf("failed to check report cron expression %w", err)
 		}
 		if utils.DurationExceeded(durationToNextGeneration) {
-			err = r.Mgr.GenerateComplianceReport(ctx, report.Spec)
+			transitions, err := r.Mgr.GenerateComplianceReport(ctx, report.Spec)
 			if err != nil {
 				log.Error(err, "failed to generate compliance report")
+				return err
 			}
-			return err
+			r.recordTransitions(&report, transitions)
+			return nil
 		}
 		log.V(1).Info("RequeueAfter", "durationToNextGeneration", durationToNextGeneration)
 		ctrlResult.RequeueAfter = durationToNextGeneration
@@ -71,6 +88,29 @@ func (r *ClusterComplianceReportReconciler) generateComplianceReport(ctx context
 	return ctrlResult, err
 }
 
+// recordTransitions raises an event for the given report for each control
+// that started failing, passing or warning.
+func (r *ClusterComplianceReportReconciler) recordTransitions(report *v1alpha1.ClusterComplianceReport, transitions []v1alpha1.ControlTransition) {
+	if r.EventRecorder == nil {
+		return
+	}
+	for _, transition := range transitions {
+		var eventType, reason string
+		switch transition.To {
+		case v1alpha1.FailStatus:
+			eventType, reason = corev1.EventTypeWarning, ReasonControlFailed
+		case v1alpha1.PassStatus:
+			eventType, reason = corev1.EventTypeNormal, ReasonControlPassed
+		case v1alpha1.WarnStatus:
+			eventType, reason = corev1.EventTypeWarning, ReasonControlWarned
+		default:
+			continue
+		}
+		r.EventRecorder.Eventf(report, eventType, reason,
+			"Control %s changed from %s to %s", transition.ID, transition.From, transition.To)
+	}
+}
+
 func (r *ClusterComplianceReportReconciler) reportLastUpdatedTime(report *v1alpha1.ClusterComplianceReport) time.Time {
 	updateTimeStamp := report.Status.UpdateTimestamp.Time
 	lastUpdated := updateTimeStamp
@@ -0,0 +1,91 @@
+package compliance
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+const (
+	// FormatOSCAL is the format of the OSCAL Assessment Results JSON document.
+	FormatOSCAL = "oscal"
+	// FormatJUnit is the format of the JUnit XML test suite.
+	FormatJUnit = "junit"
+)
+
+// IsExportFormat returns true if the given output format is supported by
+// Export.
+func IsExportFormat(format string) bool {
+	return format == FormatOSCAL || format == FormatJUnit
+}
+
+// Export writes the given v1alpha1.ClusterComplianceReport and its
+// v1alpha1.ClusterComplianceDetailReport to the given writer in the given
+// format. Each control of the report is exported as a single finding or test
+// case, which lists failing resources of the detail report.
+func Export(out io.Writer, format string, report v1alpha1.ClusterComplianceReport, detail v1alpha1.ClusterComplianceDetailReport) error {
+	switch format {
+	case FormatOSCAL:
+		return ExportOSCAL(out, report, detail)
+	case FormatJUnit:
+		return ExportJUnit(out, report, detail)
+	}
+	return fmt.Errorf("export format: %s is not supported", format)
+}
+
+// resourceFailure is a failing resource of a control.
+type resourceFailure struct {
+	checkID     string
+	objectType  string
+	namespace   string
+	name        string
+	msg         string
+	remediation string
+}
+
+// resource returns the kind and the name of the failing resource, or its kind
+// only if the failure is not related to a particular resource.
+func (f resourceFailure) resource() string {
+	switch {
+	case f.name == "":
+		return f.objectType
+	case f.namespace == "":
+		return fmt.Sprintf("%s/%s", f.objectType, f.name)
+	}
+	return fmt.Sprintf("%s/%s/%s", f.objectType, f.namespace, f.name)
+}
+
+// failuresByControl returns failing resources of the given detail report
+// grouped by control id.
+func failuresByControl(detail v1alpha1.ClusterComplianceDetailReport) map[string][]resourceFailure {
+	failures := make(map[string][]resourceFailure)
+	for _, control := range detail.Report.ControlChecks {
+		for _, result := range control.ScannerCheckResult {
+			for _, details := range result.Details {
+				if details.Status != v1alpha1.FailStatus {
+					continue
+				}
+				failures[control.ID] = append(failures[control.ID], resourceFailure{
+					checkID:     result.ID,
+					objectType:  result.ObjectType,
+					namespace:   details.Namespace,
+					name:        details.Name,
+					msg:         details.Msg,
+					remediation: result.Remediation,
+				})
+			}
+		}
+	}
+	return failures
+}
+
+// controlChecksByID returns control checks of the given report status indexed
+// by control id.
+func controlChecksByID(report v1alpha1.ClusterComplianceReport) map[string]v1alpha1.ControlCheck {
+	checks := make(map[string]v1alpha1.ControlCheck, len(report.Status.ControlChecks))
+	for _, check := range report.Status.ControlChecks {
+		checks[check.ID] = check
+	}
+	return checks
+}
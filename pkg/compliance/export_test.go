@@ -0,0 +1,124 @@
+package compliance
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getExportedReports() (v1alpha1.ClusterComplianceReport, v1alpha1.ClusterComplianceDetailReport) {
+	report := v1alpha1.ClusterComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "nsa"},
+		Spec: v1alpha1.ReportSpec{
+			Name:        "nsa",
+			Description: "National Security Agency - Kubernetes Hardening Guidance",
+			Version:     "1.0",
+			Controls: []v1alpha1.Control{
+				{ID: "1.0", Name: "Non-root containers", Severity: v1alpha1.SeverityMedium},
+				{ID: "1.1", Name: "Immutable container file systems", Severity: v1alpha1.SeverityLow},
+				{ID: "3.0", Name: "Pod security policies", Severity: v1alpha1.SeverityMedium},
+				{ID: "5.0", Name: "Encrypt etcd communication", Severity: v1alpha1.SeverityCritical},
+			},
+		},
+		Status: v1alpha1.ReportStatus{
+			UpdateTimestamp: metav1.NewTime(time.Date(2022, 9, 1, 10, 0, 0, 0, time.UTC)),
+			Summary:         v1alpha1.ClusterComplianceSummary{PassCount: 1, FailCount: 1, WarnCount: 1},
+			ControlChecks: []v1alpha1.ControlCheck{
+				{ID: "1.1", Name: "Immutable container file systems", PassTotal: 1, FailTotal: 2},
+				{ID: "1.0", Name: "Non-root containers", PassTotal: 2},
+				{ID: "3.0", Name: "Pod security policies", Status: v1alpha1.WarnStatus},
+			},
+		},
+	}
+	detail := v1alpha1.ClusterComplianceDetailReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "nsa-details"},
+		Report: v1alpha1.ClusterComplianceDetailReportData{
+			ControlChecks: []v1alpha1.ControlCheckDetails{
+				{ID: "1.1", ScannerCheckResult: []v1alpha1.ScannerCheckResult{{
+					ObjectType:  "Deployment",
+					ID:          "KSV014",
+					Remediation: "Set readOnlyRootFilesystem to true",
+					Details: []v1alpha1.ResultDetails{
+						{Name: "nginx", Namespace: "default", Msg: "Container nginx should set readOnlyRootFilesystem", Status: v1alpha1.FailStatus},
+						{Name: "redis", Namespace: "default", Msg: "Container redis should set readOnlyRootFilesystem", Status: v1alpha1.FailStatus},
+					},
+				}}},
+			},
+		},
+	}
+	return report, detail
+}
+
+func TestExportOSCAL(t *testing.T) {
+	report, detail := getExportedReports()
+	var out bytes.Buffer
+	require.NoError(t, Export(&out, FormatOSCAL, report, detail))
+
+	var doc oscalDocument
+	require.NoError(t, json.Unmarshal(out.Bytes(), &doc))
+	assert.Equal(t, oscalVersion, doc.AssessmentResults.Metadata.OSCALVersion)
+	assert.Equal(t, "2022-09-01T10:00:00Z", doc.AssessmentResults.Metadata.LastModified)
+	require.Len(t, doc.AssessmentResults.Results, 1)
+	result := doc.AssessmentResults.Results[0]
+	require.Len(t, doc.AssessmentResults.BackMatter.Resources, 1)
+	assert.Equal(t, "#"+doc.AssessmentResults.BackMatter.Resources[0].UUID, doc.AssessmentResults.ImportAP.Href)
+	assert.Equal(t, []oscalSelectControl{{ControlID: "nsa-1.0"}, {ControlID: "nsa-1.1"}, {ControlID: "nsa-3.0"}, {ControlID: "nsa-5.0"}},
+		result.ReviewedControls.ControlSelections[0].IncludeControls)
+
+	// Controls that were not checked or have the WARN status are not assessed.
+	require.Len(t, result.Findings, 2)
+	assert.Equal(t, oscalFindingTarget{Type: "objective-id", TargetID: "nsa-1.0", Status: oscalTargetStatus{State: "satisfied"}}, result.Findings[0].Target)
+	assert.Empty(t, result.Findings[0].RelatedObservations)
+	assert.Equal(t, oscalFindingTarget{Type: "objective-id", TargetID: "nsa-1.1", Status: oscalTargetStatus{State: "not-satisfied"}}, result.Findings[1].Target)
+	assert.Len(t, result.Findings[1].RelatedObservations, 2)
+
+	require.Len(t, result.Observations, 2)
+	assert.Equal(t, result.Findings[1].RelatedObservations[0].ObservationUUID, result.Observations[0].UUID)
+	assert.Equal(t, "Deployment/default/nginx failed KSV014", result.Observations[0].Title)
+	assert.Equal(t, "Container nginx should set readOnlyRootFilesystem", result.Observations[0].Description)
+	assert.Equal(t, "Deployment/default/nginx", result.Observations[0].Subjects[0].Title)
+	assert.Equal(t, []string{"TEST"}, result.Observations[0].Methods)
+
+	var again bytes.Buffer
+	require.NoError(t, ExportOSCAL(&again, report, detail))
+	assert.Equal(t, out.String(), again.String())
+}
+
+func TestExportJUnit(t *testing.T) {
+	report, detail := getExportedReports()
+	var out bytes.Buffer
+	require.NoError(t, Export(&out, FormatJUnit, report, detail))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="starboard" tests="4" failures="1" skipped="2">
+  <testsuite name="nsa" tests="4" failures="1" skipped="2" timestamp="2022-09-01T10:00:00Z">
+    <properties>
+      <property name="description" value="National Security Agency - Kubernetes Hardening Guidance"></property>
+      <property name="version" value="1.0"></property>
+    </properties>
+    <testcase classname="nsa" name="1.0 Non-root containers"></testcase>
+    <testcase classname="nsa" name="1.1 Immutable container file systems">
+      <failure message="2 of 3 checks failed" type="LOW"><![CDATA[Deployment/default/nginx: [KSV014] Container nginx should set readOnlyRootFilesystem
+Deployment/default/redis: [KSV014] Container redis should set readOnlyRootFilesystem]]></failure>
+    </testcase>
+    <testcase classname="nsa" name="3.0 Pod security policies">
+      <skipped message="control has no results"></skipped>
+    </testcase>
+    <testcase classname="nsa" name="5.0 Encrypt etcd communication">
+      <skipped message="control was not checked"></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, out.String())
+}
+
+func TestExport_UnsupportedFormat(t *testing.T) {
+	report, detail := getExportedReports()
+	err := Export(&bytes.Buffer{}, "sarif", report, detail)
+	assert.EqualError(t, err, "export format: sarif is not supported")
+}
@@ -0,0 +1,87 @@
+package compliance
+
+import (
+	"sort"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// controlStatus returns the status of the given control. Control checks
+// without the status fail if any check of the control failed, pass if any
+// check passed, and warn otherwise.
+func controlStatus(check v1alpha1.ControlCheck) v1alpha1.ControlStatus {
+	if check.Status != "" {
+		return check.Status
+	}
+	if check.FailTotal > 0 {
+		return v1alpha1.FailStatus
+	}
+	if check.PassTotal > 0 {
+		return v1alpha1.PassStatus
+	}
+	return v1alpha1.WarnStatus
+}
+
+// controlTransitions returns transitions of controls whose status differs
+// between the previous and current control checks, sorted by control id.
+// Controls that were not checked before are not considered transitioned.
+func controlTransitions(previous, current []v1alpha1.ControlCheck, timestamp metav1.Time) []v1alpha1.ControlTransition {
+	previousStatus := make(map[string]v1alpha1.ControlStatus, len(previous))
+	for _, check := range previous {
+		previousStatus[check.ID] = controlStatus(check)
+	}
+	transitions := make([]v1alpha1.ControlTransition, 0)
+	for _, check := range current {
+		from, ok := previousStatus[check.ID]
+		if !ok {
+			continue
+		}
+		to := controlStatus(check)
+		if from == to {
+			continue
+		}
+		transitions = append(transitions, v1alpha1.ControlTransition{
+			ID:        check.ID,
+			From:      from,
+			To:        to,
+			Timestamp: timestamp,
+		})
+	}
+	sort.Slice(transitions, func(i, j int) bool {
+		return transitions[i].ID < transitions[j].ID
+	})
+	return transitions
+}
+
+// updateHistory prepends the snapshot of the given status and the given
+// transitions to the status history. The history is bounded by the given
+// limit, and transitions older than the oldest snapshot are dropped.
+func updateHistory(status *v1alpha1.ReportStatus, transitions []v1alpha1.ControlTransition, limit int) {
+	failedControls := make([]string, 0)
+	for _, check := range status.ControlChecks {
+		if controlStatus(check) == v1alpha1.FailStatus {
+			failedControls = append(failedControls, check.ID)
+		}
+	}
+	sort.Strings(failedControls)
+	snapshot := v1alpha1.ComplianceSnapshot{
+		UpdateTimestamp: status.UpdateTimestamp,
+		Summary:         status.Summary,
+		FailedControls:  failedControls,
+	}
+	history := append([]v1alpha1.ComplianceSnapshot{snapshot}, status.History...)
+	if len(history) > limit {
+		history = history[:limit]
+	}
+	oldest := history[len(history)-1].UpdateTimestamp
+	keptTransitions := make([]v1alpha1.ControlTransition, 0, len(transitions)+len(status.Transitions))
+	for _, transition := range append(transitions, status.Transitions...) {
+		if transition.Timestamp.Before(&oldest) {
+			continue
+		}
+		keptTransitions = append(keptTransitions, transition)
+	}
+	status.History = history
+	status.Transitions = keptTransitions
+}
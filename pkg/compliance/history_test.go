@@ -0,0 +1,90 @@
+package compliance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestControlTransitions(t *testing.T) {
+	now := metav1.NewTime(time.Date(2022, 9, 1, 10, 0, 0, 0, time.UTC))
+	previous := []v1alpha1.ControlCheck{
+		{ID: "1.0", PassTotal: 2},
+		{ID: "1.1", FailTotal: 1},
+		{ID: "1.2", PassTotal: 1, FailTotal: 1},
+	}
+	current := []v1alpha1.ControlCheck{
+		{ID: "1.2", PassTotal: 2},
+		{ID: "1.0", PassTotal: 1, FailTotal: 1},
+		{ID: "1.1", FailTotal: 3},
+		{ID: "1.3", FailTotal: 1},
+	}
+	assert.Equal(t, []v1alpha1.ControlTransition{
+		{ID: "1.0", From: v1alpha1.PassStatus, To: v1alpha1.FailStatus, Timestamp: now},
+		{ID: "1.2", From: v1alpha1.FailStatus, To: v1alpha1.PassStatus, Timestamp: now},
+	}, controlTransitions(previous, current, now))
+	assert.Empty(t, controlTransitions(nil, current, now))
+}
+
+func TestUpdateHistory(t *testing.T) {
+	at := func(hour int) metav1.Time {
+		return metav1.NewTime(time.Date(2022, 9, 1, hour, 0, 0, 0, time.UTC))
+	}
+	status := v1alpha1.ReportStatus{
+		UpdateTimestamp: at(3),
+		Summary:         v1alpha1.ClusterComplianceSummary{PassCount: 1, FailCount: 2},
+		ControlChecks: []v1alpha1.ControlCheck{
+			{ID: "1.2", FailTotal: 1},
+			{ID: "1.0", PassTotal: 1},
+			{ID: "1.1", FailTotal: 1},
+		},
+		History: []v1alpha1.ComplianceSnapshot{
+			{UpdateTimestamp: at(2), Summary: v1alpha1.ClusterComplianceSummary{PassCount: 2, FailCount: 1}, FailedControls: []string{"1.1"}},
+			{UpdateTimestamp: at(1), Summary: v1alpha1.ClusterComplianceSummary{PassCount: 3}},
+		},
+		Transitions: []v1alpha1.ControlTransition{
+			{ID: "1.1", From: v1alpha1.PassStatus, To: v1alpha1.FailStatus, Timestamp: at(2)},
+		},
+	}
+
+	updateHistory(&status, []v1alpha1.ControlTransition{
+		{ID: "1.2", From: v1alpha1.PassStatus, To: v1alpha1.FailStatus, Timestamp: at(3)},
+	}, 3)
+	assert.Equal(t, []v1alpha1.ComplianceSnapshot{
+		{UpdateTimestamp: at(3), Summary: v1alpha1.ClusterComplianceSummary{PassCount: 1, FailCount: 2}, FailedControls: []string{"1.1", "1.2"}},
+		{UpdateTimestamp: at(2), Summary: v1alpha1.ClusterComplianceSummary{PassCount: 2, FailCount: 1}, FailedControls: []string{"1.1"}},
+		{UpdateTimestamp: at(1), Summary: v1alpha1.ClusterComplianceSummary{PassCount: 3}},
+	}, status.History)
+	assert.Equal(t, []v1alpha1.ControlTransition{
+		{ID: "1.2", From: v1alpha1.PassStatus, To: v1alpha1.FailStatus, Timestamp: at(3)},
+		{ID: "1.1", From: v1alpha1.PassStatus, To: v1alpha1.FailStatus, Timestamp: at(2)},
+	}, status.Transitions)
+
+	t.Run("Should drop snapshots and transitions beyond the limit", func(t *testing.T) {
+		status.UpdateTimestamp = at(4)
+		updateHistory(&status, nil, 2)
+		assert.Len(t, status.History, 2)
+		assert.Equal(t, at(4), status.History[0].UpdateTimestamp)
+		assert.Equal(t, at(3), status.History[1].UpdateTimestamp)
+		assert.Equal(t, []v1alpha1.ControlTransition{
+			{ID: "1.2", From: v1alpha1.PassStatus, To: v1alpha1.FailStatus, Timestamp: at(3)},
+		}, status.Transitions)
+	})
+}
+
+func TestClusterComplianceReportReconciler_RecordTransitions(t *testing.T) {
+	recorder := record.NewFakeRecorder(3)
+	r := ClusterComplianceReportReconciler{EventRecorder: recorder}
+	r.recordTransitions(&v1alpha1.ClusterComplianceReport{}, []v1alpha1.ControlTransition{
+		{ID: "1.0", From: v1alpha1.PassStatus, To: v1alpha1.FailStatus},
+		{ID: "1.1", From: v1alpha1.FailStatus, To: v1alpha1.PassStatus},
+		{ID: "1.2", From: v1alpha1.FailStatus, To: v1alpha1.WarnStatus},
+	})
+	assert.Equal(t, "Warning ComplianceControlFailed Control 1.0 changed from PASS to FAIL", <-recorder.Events)
+	assert.Equal(t, "Normal ComplianceControlPassed Control 1.1 changed from FAIL to PASS", <-recorder.Events)
+	assert.Equal(t, "Warning ComplianceControlWarned Control 1.2 changed from FAIL to WARN", <-recorder.Events)
+}
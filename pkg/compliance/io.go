@@ -3,18 +3,21 @@ package compliance
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/starboard"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/emirpasic/gods/sets/hashset"
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 const (
@@ -22,26 +25,43 @@ const (
 )
 
 type Mgr interface {
-	GenerateComplianceReport(ctx context.Context, spec v1alpha1.ReportSpec) error
+	// GenerateComplianceReport updates the status of the compliance report
+	// for the given spec and returns transitions of control statuses since
+	// the previous generation.
+	GenerateComplianceReport(ctx context.Context, spec v1alpha1.ReportSpec) ([]v1alpha1.ControlTransition, error)
 }
 
-func NewMgr(client client.Client, log logr.Logger, config starboard.ConfigData) Mgr {
+// NewMgr constructs a new Mgr. The kube.ObjectResolver is used to resolve
+// labels of resources if a spec selects resources by labels. The ext.Clock is
+// used to evaluate the age of vulnerabilities against thresholds.
+func NewMgr(client client.Client, objectResolver kube.ObjectResolver, log logr.Logger, config starboard.ConfigData, clock ext.Clock) Mgr {
 	return &cm{
-		client: client,
-		log:    log,
-		config: config,
+		client:         client,
+		objectResolver: objectResolver,
+		log:            log,
+		config:         config,
+		clock:          clock,
 	}
 }
 
 type cm struct {
-	client client.Client
-	log    logr.Logger
-	config starboard.ConfigData
+	client         client.Client
+	objectResolver kube.ObjectResolver
+	log            logr.Logger
+	config         starboard.ConfigData
+	clock          ext.Clock
 }
 
 type summaryTotal struct {
-	pass int
-	fail int
+	pass   int
+	fail   int
+	warn   int
+	manual int
+}
+
+// summary returns the ClusterComplianceSummary of the totals.
+func (st summaryTotal) summary() v1alpha1.ClusterComplianceSummary {
+	return v1alpha1.ClusterComplianceSummary{PassCount: st.pass, FailCount: st.fail, WarnCount: st.warn, ManualCount: st.manual}
 }
 
 type specDataMapping struct {
@@ -49,111 +69,197 @@ type specDataMapping struct {
 	controlIDControlObject   map[string]v1alpha1.Control
 	controlCheckIds          map[string][]string
 	controlIdResources       map[string][]string
+	vulnerabilityThresholds  map[string]v1alpha1.VulnerabilityThreshold
 }
 
-func (w *cm) GenerateComplianceReport(ctx context.Context, spec v1alpha1.ReportSpec) error {
+func (w *cm) GenerateComplianceReport(ctx context.Context, spec v1alpha1.ReportSpec) ([]v1alpha1.ControlTransition, error) {
 	// map specs to key/value map for easy processing
 	smd := w.populateSpecDataToMaps(spec)
+	// resolve resources in scope of the spec
+	scope, err := newScope(ctx, w.objectResolver, spec.Scope)
+	if err != nil {
+		return nil, fmt.Errorf("resolving compliance scope: %w", err)
+	}
 	// map compliance scanner to resource data
 	scannerResourceMap := mapComplianceScannerToResource(w.client, ctx, smd.scannerResourceListNames)
+	err = scope.filter(ctx, scannerResourceMap)
+	if err != nil {
+		return nil, fmt.Errorf("filtering reports by compliance scope: %w", err)
+	}
 	// organized data by check id and it aggregated results
-	checkIdsToResults, err := w.checkIdsToResults(scannerResourceMap)
+	checkIdsToResults, err := w.checkIdsToResults(smd, scannerResourceMap)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	scope.assignTenants(checkIdsToResults)
 	// map scanner checks results to control check results
 	controlChecks := w.controlChecksByScannerChecks(smd, checkIdsToResults)
+	controlChecks = append(controlChecks, manualControlChecks(smd)...)
+	// merge attestations of manual controls and controls without results
+	attestations, err := w.attestationsByControl(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	attestationDetails := applyAttestations(controlChecks, attestations, ext.NewSystemClock().Now())
 	// find summary totals
-	st := w.getTotals(controlChecks)
+	st := w.getTotals(smd, controlChecks)
 	//create cluster compliance details report
-	err = w.createComplianceDetailReport(ctx, spec, smd, checkIdsToResults, st)
+	err = w.createComplianceDetailReport(ctx, spec, smd, checkIdsToResults, attestationDetails, st)
 	if err != nil {
-		return fmt.Errorf("failed to create compliance detail report name: %s with error %w", strings.ToLower(fmt.Sprintf("%s-%s", spec.Name, "details")), err)
+		return nil, fmt.Errorf("failed to create compliance detail report name: %s with error %w", strings.ToLower(fmt.Sprintf("%s-%s", spec.Name, "details")), err)
 	}
 	//generate cluster compliance report
-	updatedReport, err := w.createComplianceReport(ctx, spec, st, controlChecks)
+	updatedReport, transitions, err := w.createComplianceReport(ctx, spec, st, controlChecks)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// update compliance report status
-	return w.client.Status().Update(ctx, updatedReport)
-
+	err = w.client.Status().Update(ctx, updatedReport)
+	if err != nil {
+		return nil, err
+	}
+	return transitions, nil
 }
 
-//createComplianceReport create compliance report
-func (w *cm) createComplianceReport(ctx context.Context, spec v1alpha1.ReportSpec, st summaryTotal, controlChecks []v1alpha1.ControlCheck) (*v1alpha1.ClusterComplianceReport, error) {
+//createComplianceReport create compliance report, keeping the history of previous statuses
+func (w *cm) createComplianceReport(ctx context.Context, spec v1alpha1.ReportSpec, st summaryTotal, controlChecks []v1alpha1.ControlCheck) (*v1alpha1.ClusterComplianceReport, []v1alpha1.ControlTransition, error) {
 	statusControlChecks := make([]v1alpha1.ControlCheck, 0)
 	//check if status data should be updated
-	if st.fail > 0 || st.pass > 0 {
+	updated := st.fail > 0 || st.pass > 0 || st.warn > 0
+	if updated {
 		statusControlChecks = append(statusControlChecks, controlChecks...)
 	}
-	summary := v1alpha1.ClusterComplianceSummary{PassCount: st.pass, FailCount: st.fail}
+	now := metav1.NewTime(ext.NewSystemClock().Now())
+	summary := st.summary()
 	report := v1alpha1.ClusterComplianceReport{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: strings.ToLower(spec.Name),
 		},
-		Status: v1alpha1.ReportStatus{UpdateTimestamp: metav1.NewTime(ext.NewSystemClock().Now()), Summary: summary, ControlChecks: statusControlChecks},
+		Status: v1alpha1.ReportStatus{UpdateTimestamp: now, Summary: summary, ControlChecks: statusControlChecks},
 	}
 	var existing v1alpha1.ClusterComplianceReport
 	err := w.client.Get(ctx, types.NamespacedName{
 		Name: strings.ToLower(spec.Name),
 	}, &existing)
 	if err != nil {
-		return nil, fmt.Errorf("compliance crd with name %s is missing", spec.Name)
+		return nil, nil, fmt.Errorf("getting compliance report %s: %w", strings.ToLower(spec.Name), err)
+	}
+	var transitions []v1alpha1.ControlTransition
+	report.Status.History = existing.Status.History
+	report.Status.Transitions = existing.Status.Transitions
+	if updated {
+		transitions = controlTransitions(existing.Status.ControlChecks, statusControlChecks, now)
+		updateHistory(&report.Status, transitions, w.config.ComplianceHistoryLimit())
 	}
 	copied := existing.DeepCopy()
 	copied.Labels = report.Labels
 	copied.Status = report.Status
 	copied.Spec = spec
-	copied.Status.UpdateTimestamp = metav1.NewTime(ext.NewSystemClock().Now())
-	return copied, nil
+	return copied, transitions, nil
 }
 
-//createComplianceDetailReport create and publish compliance details report
-func (w *cm) createComplianceDetailReport(ctx context.Context, spec v1alpha1.ReportSpec, smd *specDataMapping, checkIdsToResults map[string][]*ScannerCheckResult, st summaryTotal) error {
+//createComplianceDetailReport create and publish compliance details report, split into shards owned by the compliance report
+func (w *cm) createComplianceDetailReport(ctx context.Context, spec v1alpha1.ReportSpec, smd *specDataMapping, checkIdsToResults map[string][]*ScannerCheckResult, attestationDetails []v1alpha1.ControlCheckDetails, st summaryTotal) error {
 	controlChecksDetails := w.controlChecksDetailsByScannerChecks(smd, checkIdsToResults)
-	name := strings.ToLower(fmt.Sprintf("%s-%s", spec.Name, "details"))
+	controlChecksDetails = append(controlChecksDetails, attestationDetails...)
+	reportName := strings.ToLower(spec.Name)
+	name := detailReportName(reportName, 0)
+	var owner v1alpha1.ClusterComplianceReport
+	err := w.client.Get(ctx, types.NamespacedName{Name: reportName}, &owner)
+	if err != nil {
+		return fmt.Errorf("getting compliance report %s: %w", reportName, err)
+	}
 	// compliance details report
-	summary := v1alpha1.ClusterComplianceSummary{PassCount: st.pass, FailCount: st.fail}
-	report := v1alpha1.ClusterComplianceDetailReport{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: name,
-		},
-		Report: v1alpha1.ClusterComplianceDetailReportData{UpdateTimestamp: metav1.NewTime(ext.NewSystemClock().Now()),
-			Summary:       summary,
-			Type:          v1alpha1.Compliance{Name: name, Description: strings.ToLower(spec.Description), Version: spec.Version},
-			ControlChecks: controlChecksDetails},
+	summary := st.summary()
+	now := metav1.NewTime(ext.NewSystemClock().Now())
+	shards := shardControlChecks(controlChecksDetails, w.config.ComplianceDetailShardSize(), maxDetailShardBytes)
+	for index, shard := range shards {
+		report := v1alpha1.ClusterComplianceDetailReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: detailReportName(reportName, index),
+				Labels: map[string]string{
+					starboard.LabelComplianceReport: reportName,
+					starboard.LabelComplianceShard:  strconv.Itoa(index),
+				},
+			},
+			Report: v1alpha1.ClusterComplianceDetailReportData{UpdateTimestamp: now,
+				Summary:       summary,
+				Type:          v1alpha1.Compliance{Name: name, Description: strings.ToLower(spec.Description), Version: spec.Version},
+				ControlChecks: shard.controlChecks},
+		}
+		if shard.continues != "" {
+			report.Annotations = map[string]string{starboard.AnnotationComplianceShardContinues: shard.continues}
+		}
+		err = controllerutil.SetControllerReference(&owner, &report, w.client.Scheme())
+		if err != nil {
+			return err
+		}
+		err = w.createOrUpdateDetailReport(ctx, &report)
+		if err != nil {
+			return err
+		}
 	}
+	return w.deleteStaleDetailReports(ctx, reportName, len(shards))
+}
 
+// createOrUpdateDetailReport creates the given compliance details report shard or updates the existing one
+func (w *cm) createOrUpdateDetailReport(ctx context.Context, report *v1alpha1.ClusterComplianceDetailReport) error {
 	var existing v1alpha1.ClusterComplianceDetailReport
 	err := w.client.Get(ctx, types.NamespacedName{
-		Name: name,
+		Name: report.Name,
 	}, &existing)
 
 	if err == nil {
 		copied := existing.DeepCopy()
 		copied.Labels = report.Labels
+		copied.Annotations = report.Annotations
+		copied.OwnerReferences = report.OwnerReferences
 		copied.Report = report.Report
-		copied.Report.UpdateTimestamp = metav1.NewTime(ext.NewSystemClock().Now())
 		return w.client.Update(ctx, copied)
 	}
 
 	if errors.IsNotFound(err) {
-		return w.client.Create(ctx, &report)
+		return w.client.Create(ctx, report)
+	}
+	return err
+}
+
+// deleteStaleDetailReports deletes compliance details report shards left over from a previous, larger report
+func (w *cm) deleteStaleDetailReports(ctx context.Context, reportName string, count int) error {
+	var reportList v1alpha1.ClusterComplianceDetailReportList
+	err := w.client.List(ctx, &reportList, client.MatchingLabels{starboard.LabelComplianceReport: reportName})
+	if err != nil {
+		return fmt.Errorf("listing compliance detail reports: %w", err)
+	}
+	for i := range reportList.Items {
+		item := &reportList.Items[i]
+		if shardIndex(*item) < count {
+			continue
+		}
+		err = w.client.Delete(ctx, item)
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting compliance detail report %s: %w", item.Name, err)
+		}
 	}
 	return nil
 }
 
-// getTotals return control check totals
-func (w *cm) getTotals(controlChecks []v1alpha1.ControlCheck) summaryTotal {
-	var totalFail, totalPass int
+// getTotals return control check totals, and the numbers of WARN and manual controls
+func (w *cm) getTotals(smd *specDataMapping, controlChecks []v1alpha1.ControlCheck) summaryTotal {
+	var totalFail, totalPass, totalWarn, totalManual int
 	if len(controlChecks) > 0 {
 		for _, controlCheck := range controlChecks {
 			totalFail = totalFail + controlCheck.FailTotal
 			totalPass = totalPass + controlCheck.PassTotal
+			if controlCheck.Status == v1alpha1.WarnStatus {
+				totalWarn++
+			}
+			if smd.controlIDControlObject[controlCheck.ID].Mapping.Scanner == Manual {
+				totalManual++
+			}
 		}
 	}
-	return summaryTotal{fail: totalFail, pass: totalPass}
+	return summaryTotal{fail: totalFail, pass: totalPass, warn: totalWarn, manual: totalManual}
 }
 
 // controlChecksByScannerChecks build control checks list by parsing test results and mapping it to relevant scanner
@@ -163,17 +269,20 @@ func (w *cm) controlChecksByScannerChecks(smd *specDataMapping, checkIdsToResult
 		return controlChecks
 	}
 	for controlID, checkIds := range smd.controlCheckIds {
-		var passTotal, failTotal, total int
+		var passTotal, failTotal, warnTotal, total int
 		for _, checkId := range checkIds {
 			results, ok := checkIdsToResults[checkId]
 			if ok {
 				for _, checkResult := range results {
 					for _, crd := range checkResult.Details {
 						switch crd.Status {
-						case v1alpha1.PassStatus, v1alpha1.WarnStatus:
+						case v1alpha1.PassStatus:
 							passTotal++
 						case v1alpha1.FailStatus:
 							failTotal++
+						case v1alpha1.WarnStatus:
+							// WARN results, e.g. of manual kube-bench checks, need an attestation
+							warnTotal++
 						}
 						total++
 					}
@@ -182,7 +291,7 @@ func (w *cm) controlChecksByScannerChecks(smd *specDataMapping, checkIdsToResult
 		}
 		control, ok := smd.controlIDControlObject[controlID]
 		if ok {
-			if passTotal == 0 && failTotal == 0 {
+			if passTotal == 0 && failTotal == 0 && warnTotal == 0 {
 				if control.DefaultStatus == v1alpha1.FailStatus {
 					failTotal = 1
 				}
@@ -190,12 +299,19 @@ func (w *cm) controlChecksByScannerChecks(smd *specDataMapping, checkIdsToResult
 					passTotal = 1
 				}
 			}
+			status := v1alpha1.WarnStatus
+			if failTotal > 0 {
+				status = v1alpha1.FailStatus
+			} else if passTotal > 0 {
+				status = v1alpha1.PassStatus
+			}
 			controlChecks = append(controlChecks, v1alpha1.ControlCheck{ID: controlID,
 				Name:        control.Name,
 				Description: control.Description,
 				Severity:    control.Severity,
 				PassTotal:   passTotal,
-				FailTotal:   failTotal})
+				FailTotal:   failTotal,
+				Status:      status})
 		}
 	}
 	return controlChecks
@@ -255,7 +371,7 @@ func (w *cm) createScanCheckResult(results []*ScannerCheckResult) []v1alpha1.Sca
 			if crd.Status == v1alpha1.PassStatus || crd.Status == v1alpha1.WarnStatus {
 				continue
 			}
-			failedResultEntries = append(failedResultEntries, v1alpha1.ResultDetails{Name: crd.Name, Namespace: crd.Namespace, Msg: crd.Msg, Status: crd.Status})
+			failedResultEntries = append(failedResultEntries, v1alpha1.ResultDetails{Name: crd.Name, Namespace: crd.Namespace, Tenant: crd.Tenant, Msg: crd.Msg, Status: crd.Status})
 		}
 		if len(failedResultEntries) > 0 {
 			ctt = v1alpha1.ScannerCheckResult{ID: checkResult.ID, ObjectType: checkResult.ObjectType, Remediation: checkResult.Remediation, Details: failedResultEntries}
@@ -265,11 +381,11 @@ func (w *cm) createScanCheckResult(results []*ScannerCheckResult) []v1alpha1.Sca
 	return ctta
 }
 
-func (w *cm) checkIdsToResults(scannerResourceMap map[string]map[string]client.ObjectList) (map[string][]*ScannerCheckResult, error) {
+func (w *cm) checkIdsToResults(smd *specDataMapping, scannerResourceMap map[string]map[string]client.ObjectList) (map[string][]*ScannerCheckResult, error) {
 	checkIdsToResults := make(map[string][]*ScannerCheckResult)
 	for scanner, resourceListMap := range scannerResourceMap {
 		for resourceName, resourceList := range resourceListMap {
-			mapper, err := byScanner(scanner)
+			mapper, err := byScanner(scanner, smd.vulnerabilityThresholds, w.clock)
 			if err != nil {
 				return nil, err
 			}
@@ -298,8 +414,15 @@ func (w *cm) populateSpecDataToMaps(spec v1alpha1.ReportSpec) *specDataMapping {
 	scannerResourceListName := make(map[string]*hashset.Set)
 	//controlOID to resources
 	controlIdResources := make(map[string][]string)
+	//vulnerability check id to threshold
+	vulnerabilityThresholds := make(map[string]v1alpha1.VulnerabilityThreshold)
 	for _, control := range spec.Controls {
 		control.Kinds = mapKinds(control)
+		controlIDControlObject[control.ID] = control
+		// manual controls have no scanner data
+		if control.Mapping.Scanner == Manual {
+			continue
+		}
 		if _, ok := scannerResourceListName[control.Mapping.Scanner]; !ok {
 			scannerResourceListName[control.Mapping.Scanner] = hashset.New()
 		}
@@ -310,13 +433,15 @@ func (w *cm) populateSpecDataToMaps(spec v1alpha1.ReportSpec) *specDataMapping {
 			scannerResourceListName[control.Mapping.Scanner].Add(resource)
 			controlIdResources[control.ID] = append(controlIdResources[control.ID], resource)
 		}
-		controlIDControlObject[control.ID] = control
 		//update control resource list map
 		for _, check := range control.Mapping.Checks {
 			if _, ok := controlCheckIds[control.ID]; !ok {
 				controlCheckIds[control.ID] = make([]string, 0)
 			}
 			controlCheckIds[control.ID] = append(controlCheckIds[control.ID], check.ID)
+			if control.Mapping.Scanner == Vulnerability && check.Threshold != nil {
+				vulnerabilityThresholds[check.ID] = *check.Threshold
+			}
 		}
 
 	}
@@ -324,5 +449,6 @@ func (w *cm) populateSpecDataToMaps(spec v1alpha1.ReportSpec) *specDataMapping {
 		scannerResourceListNames: scannerResourceListName,
 		controlIDControlObject:   controlIDControlObject,
 		controlCheckIds:          controlCheckIds,
-		controlIdResources:       controlIdResources}
+		controlIdResources:       controlIdResources,
+		vulnerabilityThresholds:  vulnerabilityThresholds}
 }
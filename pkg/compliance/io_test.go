@@ -78,10 +78,16 @@ func TestControlChecksByScannerChecks(t *testing.T) {
 		want             []v1alpha1.ControlCheck
 	}{
 		{name: " control checks by scanner checks", specPath: "./testdata/fixture/nsa-1.0.yaml", want: []v1alpha1.ControlCheck{{ID: "1.0", Name: "Non-root containers",
-			PassTotal: 1, FailTotal: 0, Severity: "MEDIUM"}, {ID: "8.1", Name: "Audit log path is configure", PassTotal: 0, FailTotal: 1, Severity: "MEDIUM"}},
+			PassTotal: 1, FailTotal: 0, Severity: "MEDIUM", Status: v1alpha1.PassStatus}, {ID: "8.1", Name: "Audit log path is configure", PassTotal: 0, FailTotal: 1, Severity: "MEDIUM", Status: v1alpha1.FailStatus}},
 			mapScannerResult: map[string][]*ScannerCheckResult{
 				"KSV012": {{ID: "1.0", Remediation: "aaa", Details: []ResultDetails{{Status: "PASS"}}}},
 				"1.2.22": {{ID: "2.0", Remediation: "bbb", Details: []ResultDetails{{Status: "FAIL"}}}},
+			}},
+		{name: "control checks with only warn results", specPath: "./testdata/fixture/nsa-1.0.yaml", want: []v1alpha1.ControlCheck{{ID: "1.0", Name: "Non-root containers",
+			PassTotal: 1, FailTotal: 0, Severity: "MEDIUM", Status: v1alpha1.PassStatus}, {ID: "8.1", Name: "Audit log path is configure", PassTotal: 0, FailTotal: 0, Severity: "MEDIUM", Status: v1alpha1.WarnStatus}},
+			mapScannerResult: map[string][]*ScannerCheckResult{
+				"KSV012": {{ID: "1.0", Remediation: "aaa", Details: []ResultDetails{{Status: "PASS"}, {Status: "WARN"}}}},
+				"1.2.22": {{ID: "2.0", Remediation: "bbb", Details: []ResultDetails{{Status: "WARN"}, {Status: "WARN"}}}},
 			}}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -115,10 +121,16 @@ func TestGetTotals(t *testing.T) {
 		{name: "get totals with data", controlCheck: []v1alpha1.ControlCheck{{ID: "1.0", Name: "Non-root containers", PassTotal: 1, FailTotal: 0}, {ID: "8.1", Name: "Audit log path is configure", PassTotal: 0, FailTotal: 1}},
 			want: summaryTotal{pass: 1, fail: 1}},
 		{name: "get totals with no data", controlCheck: []v1alpha1.ControlCheck{},
-			want: summaryTotal{pass: 0, fail: 0}}}
+			want: summaryTotal{pass: 0, fail: 0}},
+		{name: "get totals with warn and manual controls", controlCheck: []v1alpha1.ControlCheck{{ID: "1.0", Name: "Non-root containers", PassTotal: 1, Status: v1alpha1.PassStatus}, {ID: "8.1", Name: "Audit log path is configure", Status: v1alpha1.WarnStatus}, {ID: "9.0", Name: "Incident response", Status: v1alpha1.WarnStatus}, {ID: "9.1", Name: "Backups", PassTotal: 1, Status: v1alpha1.PassStatus}},
+			want: summaryTotal{pass: 2, fail: 0, warn: 2, manual: 2}}}
+	smd := &specDataMapping{controlIDControlObject: map[string]v1alpha1.Control{
+		"9.0": {ID: "9.0", Mapping: v1alpha1.Mapping{Scanner: Manual}},
+		"9.1": {ID: "9.1", Mapping: v1alpha1.Mapping{Scanner: Manual}},
+	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			sm := mgr.getTotals(tt.controlCheck)
+			sm := mgr.getTotals(smd, tt.controlCheck)
 			assert.True(t, reflect.DeepEqual(sm, tt.want))
 		})
 	}
@@ -136,7 +148,7 @@ func TestCheckIdsToResults(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cct, err := mgr.checkIdsToResults(tt.reportList)
+			cct, err := mgr.checkIdsToResults(&specDataMapping{}, tt.reportList)
 			if err != nil {
 				t.Error(err)
 			}
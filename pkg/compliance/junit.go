@@ -0,0 +1,125 @@
+package compliance
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",cdata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// ExportJUnit writes the given compliance reports as the JUnit XML test suite
+// named after the spec. Each control of the spec is a test case, which fails
+// if the control failed and lists failing resources as failure details.
+// Controls that were not checked or have the WARN status are skipped.
+func ExportJUnit(out io.Writer, report v1alpha1.ClusterComplianceReport, detail v1alpha1.ClusterComplianceDetailReport) error {
+	checks := controlChecksByID(report)
+	failures := failuresByControl(detail)
+
+	suite := junitTestSuite{
+		Name:      report.Spec.Name,
+		Timestamp: report.Status.UpdateTimestamp.UTC().Format(time.RFC3339),
+		Properties: []junitProperty{
+			{Name: "description", Value: report.Spec.Description},
+			{Name: "version", Value: report.Spec.Version},
+		},
+	}
+	for _, control := range report.Spec.Controls {
+		testCase := junitTestCase{
+			ClassName: report.Spec.Name,
+			Name:      fmt.Sprintf("%s %s", control.ID, control.Name),
+		}
+		check, ok := checks[control.ID]
+		switch {
+		case !ok:
+			testCase.Skipped = &junitSkipped{Message: "control was not checked"}
+			suite.Skipped++
+		case controlStatus(check) == v1alpha1.WarnStatus:
+			testCase.Skipped = &junitSkipped{Message: "control has no results"}
+			suite.Skipped++
+		case controlStatus(check) == v1alpha1.FailStatus:
+			testCase.Failure = &junitFailure{
+				Message:  fmt.Sprintf("%d of %d checks failed", check.FailTotal, check.FailTotal+check.PassTotal),
+				Type:     string(control.Severity),
+				Contents: junitFailureContents(failures[control.ID]),
+			}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	suite.Tests = len(suite.TestCases)
+
+	suites := junitTestSuites{
+		Name:       "starboard",
+		Tests:      suite.Tests,
+		Failures:   suite.Failures,
+		Skipped:    suite.Skipped,
+		TestSuites: []junitTestSuite{suite},
+	}
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, "\n")
+	return err
+}
+
+// junitFailureContents returns one line per failing resource.
+func junitFailureContents(failures []resourceFailure) string {
+	var lines []string
+	for _, failure := range failures {
+		line := fmt.Sprintf("%s: %s", failure.resource(), failure.msg)
+		if failure.checkID != "" {
+			line = fmt.Sprintf("%s: [%s] %s", failure.resource(), failure.checkID, failure.msg)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -3,7 +3,12 @@ package compliance
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/emirpasic/gods/sets/hashset"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -14,6 +19,12 @@ const (
 	KubeBench = "kube-bench"
 	//ConfigAudit scanner name as appear in specs file
 	ConfigAudit = "config-audit"
+	//TrivyMisconfig scanner name as appear in specs file
+	TrivyMisconfig = "trivy-misconfig"
+	//Vulnerability scanner name as appear in specs file
+	Vulnerability = "vulnerability"
+	//Manual scanner name as appear in specs file, controls are checked by humans and recorded as ComplianceAttestations
+	Manual = "manual"
 )
 
 type Mapper interface {
@@ -26,12 +37,27 @@ type kubeBench struct {
 type configAudit struct {
 }
 
-func byScanner(scanner string) (Mapper, error) {
+type trivyMisconfig struct {
+}
+
+type vulnerability struct {
+	clock      ext.Clock
+	thresholds map[string]v1alpha1.VulnerabilityThreshold
+}
+
+// byScanner returns the Mapper of the given scanner. Thresholds are the
+// vulnerability thresholds by check id and, like the clock, are used by the
+// vulnerability scanner only.
+func byScanner(scanner string, thresholds map[string]v1alpha1.VulnerabilityThreshold, clock ext.Clock) (Mapper, error) {
 	switch scanner {
 	case KubeBench:
 		return &kubeBench{}, nil
 	case ConfigAudit:
 		return &configAudit{}, nil
+	case TrivyMisconfig:
+		return &trivyMisconfig{}, nil
+	case Vulnerability:
+		return &vulnerability{clock: clock, thresholds: thresholds}, nil
 	}
 	// scanner is not supported
 	return nil, fmt.Errorf("mapper scanner: %s is not supported", scanner)
@@ -94,6 +120,139 @@ func (ac configAudit) mapReportData(objType string, objList client.ObjectList) m
 	return scannerCheckResultMap
 }
 
+// mapReportData maps misconfiguration checks by their AVD ID, e.g. AVD-KSV-0001,
+// or by their ID if the AVD ID is not set. Checks excluded by exceptions are
+// mapped to the warn status.
+func (tm trivyMisconfig) mapReportData(objType string, objList client.ObjectList) map[string]*ScannerCheckResult {
+	scannerCheckResultMap := make(map[string]*ScannerCheckResult, 0)
+	ml, ok := objList.(*v1alpha1.MisconfigurationReportList)
+	if !ok || len(ml.Items) == 0 {
+		return scannerCheckResultMap
+	}
+	for _, item := range ml.Items {
+		for _, misconfiguration := range item.Report.Misconfigurations {
+			id := misconfiguration.AVDID
+			if id == "" {
+				id = misconfiguration.ID
+			}
+			if _, ok := scannerCheckResultMap[id]; !ok {
+				scannerCheckResultMap[id] = &ScannerCheckResult{ID: id, Remediation: misconfiguration.Resolution, ObjectType: objType}
+				scannerCheckResultMap[id].Details = make([]ResultDetails, 0)
+			}
+			var status = v1alpha1.FailStatus
+			switch misconfiguration.Status {
+			case v1alpha1.MisconfigurationStatusPass:
+				status = v1alpha1.PassStatus
+			case v1alpha1.MisconfigurationStatusException:
+				status = v1alpha1.WarnStatus
+			}
+			scannerCheckResultMap[id].Details = append(scannerCheckResultMap[id].Details, ResultDetails{Name: item.GetName(), Namespace: item.Namespace, Msg: misconfiguration.Message, Status: status})
+		}
+	}
+	return scannerCheckResultMap
+}
+
+// mapReportData evaluates each threshold against vulnerabilities of workloads.
+// A workload may have many vulnerability reports, one per container, so there
+// is one result detail per workload, which fails if any of its vulnerabilities
+// matches the threshold.
+func (v vulnerability) mapReportData(objType string, objList client.ObjectList) map[string]*ScannerCheckResult {
+	scannerCheckResultMap := make(map[string]*ScannerCheckResult, 0)
+	vl, ok := objList.(*v1alpha1.VulnerabilityReportList)
+	if !ok || len(vl.Items) == 0 {
+		return scannerCheckResultMap
+	}
+	checkIds := make([]string, 0, len(v.thresholds))
+	for id := range v.thresholds {
+		checkIds = append(checkIds, id)
+	}
+	sort.Strings(checkIds)
+	for _, id := range checkIds {
+		threshold := v.thresholds[id]
+		scannerCheckResult := &ScannerCheckResult{ID: id, Remediation: "Upgrade vulnerable packages to their fixed versions", ObjectType: objType}
+		scannerCheckResult.Details = make([]ResultDetails, 0)
+		// index of the workload details by namespace and name
+		workloads := make(map[string]int)
+		matches := make([][]string, 0)
+		for _, item := range vl.Items {
+			name := item.Labels[starboard.LabelResourceName]
+			if name == "" {
+				name = item.GetName()
+			}
+			key := item.Namespace + "/" + name
+			index, ok := workloads[key]
+			if !ok {
+				index = len(scannerCheckResult.Details)
+				workloads[key] = index
+				scannerCheckResult.Details = append(scannerCheckResult.Details, ResultDetails{Name: name, Namespace: item.Namespace, Status: v1alpha1.PassStatus})
+				matches = append(matches, make([]string, 0))
+			}
+			for _, vuln := range item.Report.Vulnerabilities {
+				if v.matches(threshold, vuln) {
+					matches[index] = append(matches[index], vuln.VulnerabilityID)
+				}
+			}
+		}
+		for index, ids := range matches {
+			if len(ids) == 0 {
+				continue
+			}
+			ids = uniqueSorted(ids)
+			scannerCheckResult.Details[index].Status = v1alpha1.FailStatus
+			scannerCheckResult.Details[index].Msg = fmt.Sprintf("%d vulnerabilities exceed the threshold: %s", len(ids), strings.Join(ids, ", "))
+		}
+		scannerCheckResultMap[id] = scannerCheckResult
+	}
+	return scannerCheckResultMap
+}
+
+// matches returns true if the given vulnerability matches all conditions of
+// the threshold. Suppressed vulnerabilities and vulnerabilities that do not
+// affect the workload according to VEX never match.
+func (v vulnerability) matches(threshold v1alpha1.VulnerabilityThreshold, vuln v1alpha1.Vulnerability) bool {
+	if vuln.Suppressed || vuln.VEXStatus == v1alpha1.VEXStatusNotAffected {
+		return false
+	}
+	rank, ok := severityRank[vuln.Severity]
+	if !ok || rank < severityRank[threshold.Severity] {
+		return false
+	}
+	if threshold.FixAvailable && vuln.FixedVersion == "" {
+		return false
+	}
+	if threshold.OlderThanDays > 0 {
+		if vuln.PublishedDate == nil {
+			return false
+		}
+		if v.clock.Now().Sub(vuln.PublishedDate.Time) <= time.Duration(threshold.OlderThanDays)*24*time.Hour {
+			return false
+		}
+	}
+	return true
+}
+
+var severityRank = map[v1alpha1.Severity]int{
+	v1alpha1.SeverityUnknown:  0,
+	v1alpha1.SeverityLow:      1,
+	v1alpha1.SeverityMedium:   2,
+	v1alpha1.SeverityHigh:     3,
+	v1alpha1.SeverityCritical: 4,
+}
+
+func uniqueSorted(values []string) []string {
+	set := make(map[string]struct{}, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if _, ok := set[value]; ok {
+			continue
+		}
+		set[value] = struct{}{}
+		unique = append(unique, value)
+	}
+	sort.Strings(unique)
+	return unique
+}
+
 func mapComplianceScannerToResource(cli client.Client, ctx context.Context, resourceListNames map[string]*hashset.Set) map[string]map[string]client.ObjectList {
 	scannerResource := make(map[string]map[string]client.ObjectList)
 	for scanner, objNames := range resourceListNames {
@@ -126,6 +285,10 @@ func getObjListByName(scannerName string) client.ObjectList {
 		return &v1alpha1.CISKubeBenchReportList{}
 	case ConfigAudit:
 		return &v1alpha1.ConfigAuditReportList{}
+	case TrivyMisconfig:
+		return &v1alpha1.MisconfigurationReportList{}
+	case Vulnerability:
+		return &v1alpha1.VulnerabilityReportList{}
 	default:
 		return nil
 	}
@@ -134,6 +297,7 @@ func getObjListByName(scannerName string) client.ObjectList {
 type ResultDetails struct {
 	Name      string
 	Namespace string
+	Tenant    string
 	Msg       string
 	Status    v1alpha1.ControlStatus
 }
@@ -5,11 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"io/ioutil"
 	"reflect"
+	"time"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/starboard"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -24,6 +28,8 @@ func TestGetObjListByName(t *testing.T) {
 	}{
 		{name: "kube bench scanner name", scannerName: KubeBench, want: "*v1alpha1.CISKubeBenchReportList"},
 		{name: "conf audit scanner name", scannerName: ConfigAudit, want: "*v1alpha1.ConfigAuditReportList"},
+		{name: "trivy misconfig scanner name", scannerName: TrivyMisconfig, want: "*v1alpha1.MisconfigurationReportList"},
+		{name: "vulnerability scanner name", scannerName: Vulnerability, want: "*v1alpha1.VulnerabilityReportList"},
 		{name: "no scanner name", scannerName: "", want: ""},
 	}
 	for _, tt := range tests {
@@ -35,6 +41,13 @@ func TestGetObjListByName(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("vulnerability scanner uses the given clock", func(t *testing.T) {
+		clock := ext.NewFixedClock(time.Date(2022, time.September, 1, 0, 0, 0, 0, time.UTC))
+		mapper, err := byScanner(Vulnerability, nil, clock)
+		require.NoError(t, err)
+		assert.Equal(t, clock, mapper.(*vulnerability).clock)
+	})
 }
 
 func TestByScanner(t *testing.T) {
@@ -45,10 +58,12 @@ func TestByScanner(t *testing.T) {
 	}{
 		{name: "kube bench scanner name", scannerName: KubeBench, want: "*compliance.kubeBench"},
 		{name: "conf audit scanner name", scannerName: ConfigAudit, want: "*compliance.configAudit"},
+		{name: "trivy misconfig scanner name", scannerName: TrivyMisconfig, want: "*compliance.trivyMisconfig"},
+		{name: "vulnerability scanner name", scannerName: Vulnerability, want: "*compliance.vulnerability"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cl, err := byScanner(tt.scannerName)
+			cl, err := byScanner(tt.scannerName, nil, ext.NewSystemClock())
 			if err != nil {
 				t.Error(err)
 			}
@@ -143,6 +158,10 @@ func TestMapReportDataToMap(t *testing.T) {
 		{name: "map cis benchmark report", objectType: "Node", reportList: getCisInstance([]string{"1.1", "2.2"}, []string{"PASS", "FAIL"}, []string{"aaa", "bbb"}), wantResult: getWantResults("./testdata/fixture/cis_bench_check_result.json"), mapfunc: kubeBench{}.mapReportData},
 		{name: "map empty config report", objectType: "Pod", reportList: &v1alpha1.ConfigAuditReportList{}, wantResult: map[string]*ScannerCheckResult{}, mapfunc: configAudit{}.mapReportData},
 		{name: "map empty cis report ", objectType: "Node", reportList: &v1alpha1.CISKubeBenchReportList{}, wantResult: map[string]*ScannerCheckResult{}, mapfunc: kubeBench{}.mapReportData},
+		{name: "map trivy misconfig report", objectType: "ReplicaSet", reportList: getMisconfig([]string{"AVD-KSV-0001", ""}, []string{"KSV001", "KSV003"}, []v1alpha1.MisconfigurationStatus{"FAIL", "EXCEPTION"}), wantResult: getWantResults("./testdata/fixture/trivy_misconfig_check_result.json"), mapfunc: trivyMisconfig{}.mapReportData},
+		{name: "map empty trivy misconfig report", objectType: "ReplicaSet", reportList: &v1alpha1.MisconfigurationReportList{}, wantResult: map[string]*ScannerCheckResult{}, mapfunc: trivyMisconfig{}.mapReportData},
+		{name: "map vulnerability report", objectType: "ReplicaSet", reportList: getVulnerabilityReports(), wantResult: getWantResults("./testdata/fixture/vulnerability_check_result.json"), mapfunc: getVulnerability().mapReportData},
+		{name: "map empty vulnerability report", objectType: "ReplicaSet", reportList: &v1alpha1.VulnerabilityReportList{}, wantResult: map[string]*ScannerCheckResult{}, mapfunc: getVulnerability().mapReportData},
 	}
 
 	for _, tt := range tests {
@@ -186,6 +205,82 @@ func getConfAudit(testIds []string, testStatus []bool, remediation []string) *v1
 	}}}}}}
 }
 
+func getMisconfig(avdIds []string, ids []string, status []v1alpha1.MisconfigurationStatus) *v1alpha1.MisconfigurationReportList {
+	return &v1alpha1.MisconfigurationReportList{Items: []v1alpha1.MisconfigurationReport{{
+		ObjectMeta: metav1.ObjectMeta{Name: "replicaset-nginx-6d4cf56db6", Namespace: "default"},
+		Report: v1alpha1.MisconfigurationReportData{Misconfigurations: []v1alpha1.Misconfiguration{
+			{AVDID: avdIds[0], ID: ids[0], Status: status[0], Message: "aaa", Resolution: "bbb"},
+			{AVDID: avdIds[1], ID: ids[1], Status: status[1], Message: "ccc", Resolution: "ddd"},
+		}}}}}
+}
+
+func TestVulnerabilityMatches(t *testing.T) {
+	now := time.Date(2022, time.September, 1, 0, 0, 0, 0, time.UTC)
+	published := metav1.NewTime(now.AddDate(0, 0, -60))
+	recent := metav1.NewTime(now.AddDate(0, 0, -10))
+	tests := []struct {
+		name          string
+		threshold     v1alpha1.VulnerabilityThreshold
+		vulnerability v1alpha1.Vulnerability
+		want          bool
+	}{
+		{name: "severity above threshold", threshold: v1alpha1.VulnerabilityThreshold{Severity: v1alpha1.SeverityHigh}, vulnerability: v1alpha1.Vulnerability{Severity: v1alpha1.SeverityCritical}, want: true},
+		{name: "severity equal to threshold", threshold: v1alpha1.VulnerabilityThreshold{Severity: v1alpha1.SeverityHigh}, vulnerability: v1alpha1.Vulnerability{Severity: v1alpha1.SeverityHigh}, want: true},
+		{name: "severity below threshold", threshold: v1alpha1.VulnerabilityThreshold{Severity: v1alpha1.SeverityHigh}, vulnerability: v1alpha1.Vulnerability{Severity: v1alpha1.SeverityMedium}, want: false},
+		{name: "fix available", threshold: v1alpha1.VulnerabilityThreshold{Severity: v1alpha1.SeverityLow, FixAvailable: true}, vulnerability: v1alpha1.Vulnerability{Severity: v1alpha1.SeverityLow, FixedVersion: "1.2.3"}, want: true},
+		{name: "fix not available", threshold: v1alpha1.VulnerabilityThreshold{Severity: v1alpha1.SeverityLow, FixAvailable: true}, vulnerability: v1alpha1.Vulnerability{Severity: v1alpha1.SeverityLow}, want: false},
+		{name: "older than threshold", threshold: v1alpha1.VulnerabilityThreshold{Severity: v1alpha1.SeverityLow, OlderThanDays: 30}, vulnerability: v1alpha1.Vulnerability{Severity: v1alpha1.SeverityLow, PublishedDate: &published}, want: true},
+		{name: "newer than threshold", threshold: v1alpha1.VulnerabilityThreshold{Severity: v1alpha1.SeverityLow, OlderThanDays: 30}, vulnerability: v1alpha1.Vulnerability{Severity: v1alpha1.SeverityLow, PublishedDate: &recent}, want: false},
+		{name: "published date unknown", threshold: v1alpha1.VulnerabilityThreshold{Severity: v1alpha1.SeverityLow, OlderThanDays: 30}, vulnerability: v1alpha1.Vulnerability{Severity: v1alpha1.SeverityLow}, want: false},
+		{name: "suppressed", threshold: v1alpha1.VulnerabilityThreshold{Severity: v1alpha1.SeverityLow}, vulnerability: v1alpha1.Vulnerability{Severity: v1alpha1.SeverityCritical, Suppressed: true}, want: false},
+		{name: "not affected", threshold: v1alpha1.VulnerabilityThreshold{Severity: v1alpha1.SeverityLow}, vulnerability: v1alpha1.Vulnerability{Severity: v1alpha1.SeverityCritical, VEXStatus: v1alpha1.VEXStatusNotAffected}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := vulnerability{clock: ext.NewFixedClock(now)}
+			assert.Equal(t, tt.want, v.matches(tt.threshold, tt.vulnerability))
+		})
+	}
+}
+
+func getVulnerability() vulnerability {
+	return vulnerability{
+		clock: ext.NewFixedClock(time.Date(2022, time.September, 1, 0, 0, 0, 0, time.UTC)),
+		thresholds: map[string]v1alpha1.VulnerabilityThreshold{
+			"critical-fixable":        {Severity: v1alpha1.SeverityCritical, FixAvailable: true},
+			"high-older-than-30-days": {Severity: v1alpha1.SeverityHigh, OlderThanDays: 30},
+		},
+	}
+}
+
+func getVulnerabilityReports() *v1alpha1.VulnerabilityReportList {
+	published := metav1.NewTime(time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC))
+	newReport := func(name, container string, vulnerabilities ...v1alpha1.Vulnerability) v1alpha1.VulnerabilityReport {
+		return v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-" + name + "-" + container,
+				Namespace: "default",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:  "ReplicaSet",
+					starboard.LabelResourceName:  name,
+					starboard.LabelContainerName: container,
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{Vulnerabilities: vulnerabilities},
+		}
+	}
+	return &v1alpha1.VulnerabilityReportList{Items: []v1alpha1.VulnerabilityReport{
+		newReport("nginx-6d4cf56db6", "nginx",
+			v1alpha1.Vulnerability{VulnerabilityID: "CVE-2022-0001", Severity: v1alpha1.SeverityCritical, FixedVersion: "1.0.1", PublishedDate: &published},
+			v1alpha1.Vulnerability{VulnerabilityID: "CVE-2022-0002", Severity: v1alpha1.SeverityCritical}),
+		newReport("nginx-6d4cf56db6", "sidecar",
+			v1alpha1.Vulnerability{VulnerabilityID: "CVE-2022-0001", Severity: v1alpha1.SeverityCritical, FixedVersion: "1.0.1"},
+			v1alpha1.Vulnerability{VulnerabilityID: "CVE-2022-0003", Severity: v1alpha1.SeverityHigh, PublishedDate: &published}),
+		newReport("redis-7b8d9c6f5", "redis",
+			v1alpha1.Vulnerability{VulnerabilityID: "CVE-2022-0004", Severity: v1alpha1.SeverityMedium, FixedVersion: "2.0.1", PublishedDate: &published}),
+	}}
+}
+
 func getCisInstance(testIds []string, testStatus []string, remediation []string) *v1alpha1.CISKubeBenchReportList {
 	return &v1alpha1.CISKubeBenchReportList{
 		Items: []v1alpha1.CISKubeBenchReport{{Report: v1alpha1.CISKubeBenchReportData{Sections: []v1alpha1.CISKubeBenchSection{
@@ -0,0 +1,240 @@
+package compliance
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/google/uuid"
+)
+
+const (
+	oscalVersion = "1.0.4"
+	// oscalNamespace is the namespace of Starboard specific properties.
+	oscalNamespace = "https://aquasecurity.github.io/starboard"
+
+	oscalStateSatisfied    = "satisfied"
+	oscalStateNotSatisfied = "not-satisfied"
+
+	// oscalMethodTest is the observation method of automated checks.
+	oscalMethodTest = "TEST"
+)
+
+type oscalDocument struct {
+	AssessmentResults oscalAssessmentResults `json:"assessment-results"`
+}
+
+type oscalAssessmentResults struct {
+	UUID       string          `json:"uuid"`
+	Metadata   oscalMetadata   `json:"metadata"`
+	ImportAP   oscalImportAP   `json:"import-ap"`
+	Results    []oscalResult   `json:"results"`
+	BackMatter oscalBackMatter `json:"back-matter"`
+}
+
+type oscalMetadata struct {
+	Title        string `json:"title"`
+	LastModified string `json:"last-modified"`
+	Version      string `json:"version"`
+	OSCALVersion string `json:"oscal-version"`
+}
+
+type oscalImportAP struct {
+	Href string `json:"href"`
+}
+
+type oscalBackMatter struct {
+	Resources []oscalResource `json:"resources"`
+}
+
+type oscalResource struct {
+	UUID        string          `json:"uuid"`
+	Title       string          `json:"title"`
+	Description string          `json:"description,omitempty"`
+	Props       []oscalProperty `json:"props,omitempty"`
+}
+
+type oscalResult struct {
+	UUID             string                `json:"uuid"`
+	Title            string                `json:"title"`
+	Description      string                `json:"description"`
+	Start            string                `json:"start"`
+	Props            []oscalProperty       `json:"props,omitempty"`
+	ReviewedControls oscalReviewedControls `json:"reviewed-controls"`
+	Observations     []oscalObservation    `json:"observations,omitempty"`
+	Findings         []oscalFinding        `json:"findings,omitempty"`
+}
+
+type oscalProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	NS    string `json:"ns,omitempty"`
+}
+
+type oscalReviewedControls struct {
+	ControlSelections []oscalControlSelection `json:"control-selections"`
+}
+
+type oscalControlSelection struct {
+	IncludeControls []oscalSelectControl `json:"include-controls,omitempty"`
+}
+
+type oscalSelectControl struct {
+	ControlID string `json:"control-id"`
+}
+
+type oscalObservation struct {
+	UUID        string         `json:"uuid"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Methods     []string       `json:"methods"`
+	Subjects    []oscalSubject `json:"subjects,omitempty"`
+	Collected   string         `json:"collected"`
+	Remarks     string         `json:"remarks,omitempty"`
+}
+
+type oscalSubject struct {
+	SubjectUUID string `json:"subject-uuid"`
+	Type        string `json:"type"`
+	Title       string `json:"title"`
+}
+
+type oscalFinding struct {
+	UUID                string                    `json:"uuid"`
+	Title               string                    `json:"title"`
+	Description         string                    `json:"description"`
+	Props               []oscalProperty           `json:"props,omitempty"`
+	Target              oscalFindingTarget        `json:"target"`
+	RelatedObservations []oscalRelatedObservation `json:"related-observations,omitempty"`
+}
+
+type oscalFindingTarget struct {
+	Type     string            `json:"type"`
+	TargetID string            `json:"target-id"`
+	Status   oscalTargetStatus `json:"status"`
+}
+
+type oscalTargetStatus struct {
+	State string `json:"state"`
+}
+
+type oscalRelatedObservation struct {
+	ObservationUUID string `json:"observation-uuid"`
+}
+
+// ExportOSCAL writes the given compliance reports as the OSCAL Assessment
+// Results JSON document. Each control of the spec is a reviewed control, and
+// each control that passed or failed is a finding, which is satisfied if the
+// control passed. Controls that were not checked or have the WARN status are
+// not assessed and have no finding, as they are skipped by ExportJUnit. Each
+// failing resource is an observation related to the finding. Control ids are
+// prefixed with the spec name to make them valid OSCAL tokens, e.g. nsa-1.0.
+// UUIDs are derived from the report name and update timestamp, so exporting
+// the same report twice yields the same document.
+//
+// Starboard does not maintain OSCAL Assessment Plans, hence the imported
+// assessment plan refers to a back-matter resource describing the compliance
+// spec the report was generated for.
+func ExportOSCAL(out io.Writer, report v1alpha1.ClusterComplianceReport, detail v1alpha1.ClusterComplianceDetailReport) error {
+	timestamp := report.Status.UpdateTimestamp.UTC().Format(time.RFC3339)
+	newUUID := func(parts ...string) string {
+		name := strings.Join(append([]string{report.Name, timestamp}, parts...), "/")
+		return uuid.NewSHA1(uuid.NameSpaceURL, []byte(name)).String()
+	}
+	checks := controlChecksByID(report)
+	failures := failuresByControl(detail)
+
+	result := oscalResult{
+		UUID:        newUUID("result"),
+		Title:       report.Spec.Name,
+		Description: report.Spec.Description,
+		Start:       timestamp,
+		Props: []oscalProperty{
+			{Name: "pass-count", Value: fmt.Sprintf("%d", report.Status.Summary.PassCount), NS: oscalNamespace},
+			{Name: "fail-count", Value: fmt.Sprintf("%d", report.Status.Summary.FailCount), NS: oscalNamespace},
+			{Name: "warn-count", Value: fmt.Sprintf("%d", report.Status.Summary.WarnCount), NS: oscalNamespace},
+		},
+	}
+	selection := oscalControlSelection{}
+	for _, control := range report.Spec.Controls {
+		controlID := oscalControlID(report.Spec.Name, control.ID)
+		selection.IncludeControls = append(selection.IncludeControls, oscalSelectControl{ControlID: controlID})
+
+		check, ok := checks[control.ID]
+		if !ok || controlStatus(check) == v1alpha1.WarnStatus {
+			continue
+		}
+		state := oscalStateSatisfied
+		if controlStatus(check) == v1alpha1.FailStatus {
+			state = oscalStateNotSatisfied
+		}
+		finding := oscalFinding{
+			UUID:        newUUID("finding", control.ID),
+			Title:       control.Name,
+			Description: control.Description,
+			Props: []oscalProperty{
+				{Name: "severity", Value: string(control.Severity), NS: oscalNamespace},
+			},
+			Target: oscalFindingTarget{
+				Type:     "objective-id",
+				TargetID: controlID,
+				Status:   oscalTargetStatus{State: state},
+			},
+		}
+		for i, failure := range failures[control.ID] {
+			observation := oscalObservation{
+				UUID:        newUUID("observation", control.ID, fmt.Sprintf("%d", i)),
+				Title:       fmt.Sprintf("%s failed %s", failure.resource(), failure.checkID),
+				Description: failure.msg,
+				Methods:     []string{oscalMethodTest},
+				Subjects: []oscalSubject{{
+					SubjectUUID: newUUID("subject", failure.resource()),
+					Type:        "resource",
+					Title:       failure.resource(),
+				}},
+				Collected: timestamp,
+				Remarks:   failure.remediation,
+			}
+			result.Observations = append(result.Observations, observation)
+			finding.RelatedObservations = append(finding.RelatedObservations, oscalRelatedObservation{ObservationUUID: observation.UUID})
+		}
+		result.Findings = append(result.Findings, finding)
+	}
+	result.ReviewedControls = oscalReviewedControls{ControlSelections: []oscalControlSelection{selection}}
+
+	spec := oscalResource{
+		UUID:        newUUID("spec"),
+		Title:       fmt.Sprintf("%s compliance spec", report.Spec.Name),
+		Description: report.Spec.Description,
+		Props: []oscalProperty{
+			{Name: "spec-name", Value: report.Spec.Name, NS: oscalNamespace},
+			{Name: "spec-version", Value: report.Spec.Version, NS: oscalNamespace},
+		},
+	}
+	doc := oscalDocument{
+		AssessmentResults: oscalAssessmentResults{
+			UUID: newUUID("assessment-results"),
+			Metadata: oscalMetadata{
+				Title:        fmt.Sprintf("%s compliance assessment results", report.Spec.Name),
+				LastModified: timestamp,
+				Version:      report.Spec.Version,
+				OSCALVersion: oscalVersion,
+			},
+			ImportAP:   oscalImportAP{Href: "#" + spec.UUID},
+			Results:    []oscalResult{result},
+			BackMatter: oscalBackMatter{Resources: []oscalResource{spec}},
+		},
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// oscalControlID returns the OSCAL token identifying the given control of the
+// spec with the given name.
+func oscalControlID(specName, controlID string) string {
+	return fmt.Sprintf("%s-%s", strings.ToLower(specName), controlID)
+}
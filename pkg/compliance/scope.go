@@ -0,0 +1,202 @@
+package compliance
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// scope selects security reports of resources in the v1alpha1.ComplianceScope
+// of a compliance spec. The nil scope selects all reports.
+type scope struct {
+	objectResolver kube.ObjectResolver
+	// namespaces holds names of namespaces in scope, or is nil if all
+	// namespaces are in scope.
+	namespaces map[string]bool
+	// tenants maps names of namespaces to their tenants.
+	tenants          map[string]string
+	resourceSelector labels.Selector
+}
+
+// newScope returns the scope of the given v1alpha1.ComplianceScope, or nil if
+// the given v1alpha1.ComplianceScope is nil.
+func newScope(ctx context.Context, objectResolver kube.ObjectResolver, spec *v1alpha1.ComplianceScope) (*scope, error) {
+	if spec == nil {
+		return nil, nil
+	}
+	namespaceSelector, resourceSelector, err := scopeSelectors(spec)
+	if err != nil {
+		return nil, err
+	}
+	var namespaceList corev1.NamespaceList
+	err = objectResolver.Client.List(ctx, &namespaceList)
+	if err != nil {
+		return nil, fmt.Errorf("listing namespaces: %w", err)
+	}
+	names := make(map[string]bool, len(spec.Namespaces))
+	for _, name := range spec.Namespaces {
+		names[name] = true
+	}
+	namespaces := make(map[string]bool)
+	tenants := make(map[string]string)
+	for _, namespace := range namespaceList.Items {
+		tenants[namespace.Name] = namespace.Name
+		if spec.TenantLabel != "" {
+			tenants[namespace.Name] = namespace.Labels[spec.TenantLabel]
+		}
+		if len(names) > 0 && !names[namespace.Name] {
+			continue
+		}
+		if namespaceSelector.Matches(labels.Set(namespace.Labels)) {
+			namespaces[namespace.Name] = true
+		}
+	}
+	s := &scope{
+		objectResolver: objectResolver,
+		namespaces:     namespaces,
+		tenants:        tenants,
+	}
+	if len(spec.Namespaces) == 0 && spec.NamespaceSelector == nil {
+		s.namespaces = nil
+	}
+	if spec.ResourceSelector != nil {
+		s.resourceSelector = resourceSelector
+	}
+	return s, nil
+}
+
+// scopeSelectors returns selectors of namespaces and resources of the given
+// v1alpha1.ComplianceScope. Nil selectors match everything.
+func scopeSelectors(spec *v1alpha1.ComplianceScope) (labels.Selector, labels.Selector, error) {
+	namespaceSelector := labels.Everything()
+	resourceSelector := labels.Everything()
+	var err error
+	if spec.NamespaceSelector != nil {
+		namespaceSelector, err = metav1.LabelSelectorAsSelector(spec.NamespaceSelector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("namespace selector: %w", err)
+		}
+	}
+	if spec.ResourceSelector != nil {
+		resourceSelector, err = metav1.LabelSelectorAsSelector(spec.ResourceSelector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("resource selector: %w", err)
+		}
+	}
+	return namespaceSelector, resourceSelector, nil
+}
+
+// filter removes reports of resources out of scope from the given lists of
+// reports by scanner and kind.
+func (s *scope) filter(ctx context.Context, scannerResourceMap map[string]map[string]client.ObjectList) error {
+	if s == nil {
+		return nil
+	}
+	for _, resourceListMap := range scannerResourceMap {
+		for _, objList := range resourceListMap {
+			items, err := meta.ExtractList(objList)
+			if err != nil {
+				return err
+			}
+			filtered := make([]runtime.Object, 0, len(items))
+			for _, item := range items {
+				obj, ok := item.(client.Object)
+				if !ok {
+					continue
+				}
+				inScope, err := s.inScope(ctx, obj)
+				if err != nil {
+					return err
+				}
+				if inScope {
+					filtered = append(filtered, item)
+				}
+			}
+			err = meta.SetList(objList, filtered)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// inScope returns true if the resource of the given report is in scope.
+// Resources that cannot be resolved are out of scope if resources are selected
+// by labels.
+func (s *scope) inScope(ctx context.Context, report client.Object) (bool, error) {
+	if s.namespaces != nil {
+		if !s.namespaces[report.GetNamespace()] {
+			return false, nil
+		}
+	}
+	if s.resourceSelector == nil {
+		return true, nil
+	}
+	resourceLabels, err := s.resourceLabels(ctx, report)
+	if err != nil {
+		return false, err
+	}
+	return resourceLabels != nil && s.resourceSelector.Matches(labels.Set(resourceLabels)), nil
+}
+
+// resourceLabels returns labels of the resource of the given report, or nil if
+// the resource cannot be resolved.
+func (s *scope) resourceLabels(ctx context.Context, report client.Object) (map[string]string, error) {
+	ref := kube.ObjectRef{
+		Kind:      kube.Kind(report.GetLabels()[starboard.LabelResourceKind]),
+		Name:      report.GetLabels()[starboard.LabelResourceName],
+		Namespace: report.GetNamespace(),
+	}
+	var obj client.Object
+	var err error
+	switch ref.Kind {
+	case kube.KindNode:
+		obj = &corev1.Node{}
+		err = s.objectResolver.Client.Get(ctx, client.ObjectKey{Name: ref.Name}, obj)
+	default:
+		obj, err = s.objectResolver.ObjectFromObjectRef(ctx, ref)
+	}
+	if err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting resource %s/%s: %w", ref.Kind, ref.Name, err)
+	}
+	return obj.GetLabels(), nil
+}
+
+// assignTenants sets the tenant of resources in the given check results by
+// their namespace.
+func (s *scope) assignTenants(checkIdsToResults map[string][]*ScannerCheckResult) {
+	if s == nil {
+		return
+	}
+	for _, results := range checkIdsToResults {
+		for _, result := range results {
+			for i, details := range result.Details {
+				if details.Namespace == "" {
+					continue
+				}
+				result.Details[i].Tenant = s.tenant(details.Namespace)
+			}
+		}
+	}
+}
+
+// tenant returns the tenant of resources in the given namespace.
+func (s *scope) tenant(namespace string) string {
+	if tenant, ok := s.tenants[namespace]; ok {
+		return tenant
+	}
+	return namespace
+}
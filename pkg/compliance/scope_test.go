@@ -0,0 +1,153 @@
+package compliance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func getScopedObjects() []client.Object {
+	namespace := func(name, tenant string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"tenant": tenant}}}
+	}
+	replicaSet := func(namespace, name, tier string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: map[string]string{"tier": tier}}}
+	}
+	report := func(namespace, name string, success bool) *v1alpha1.ConfigAuditReport {
+		return &v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "replicaset-" + name,
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "ReplicaSet",
+					starboard.LabelResourceName:      name,
+					starboard.LabelResourceNamespace: namespace,
+				},
+			},
+			Report: v1alpha1.ConfigAuditReportData{
+				Checks: []v1alpha1.Check{{ID: "KSV012", Success: success, Messages: []string{"Container should set runAsNonRoot to true"}}},
+			},
+		}
+	}
+	return []client.Object{
+		namespace("blue-dev", "blue"),
+		namespace("blue-prod", "blue"),
+		namespace("green-dev", "green"),
+		replicaSet("blue-dev", "api", "backend"),
+		replicaSet("blue-prod", "api", "backend"),
+		replicaSet("blue-prod", "web", "frontend"),
+		replicaSet("green-dev", "api", "backend"),
+		report("blue-dev", "api", false),
+		report("blue-prod", "api", true),
+		report("blue-prod", "web", false),
+		report("green-dev", "api", false),
+	}
+}
+
+func TestScope_Filter(t *testing.T) {
+	testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(getScopedObjects()...).Build()
+	objectResolver := kube.NewObjectResolver(testClient, nil)
+
+	testCases := []struct {
+		name     string
+		scope    *v1alpha1.ComplianceScope
+		expected []string
+	}{
+		{
+			name:     "Should select all reports without scope",
+			expected: []string{"blue-dev/replicaset-api", "blue-prod/replicaset-api", "blue-prod/replicaset-web", "green-dev/replicaset-api"},
+		},
+		{
+			name:     "Should select reports by namespace names",
+			scope:    &v1alpha1.ComplianceScope{Namespaces: []string{"blue-dev", "green-dev"}},
+			expected: []string{"blue-dev/replicaset-api", "green-dev/replicaset-api"},
+		},
+		{
+			name:     "Should select reports by namespace selector",
+			scope:    &v1alpha1.ComplianceScope{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "blue"}}},
+			expected: []string{"blue-dev/replicaset-api", "blue-prod/replicaset-api", "blue-prod/replicaset-web"},
+		},
+		{
+			name: "Should select reports by namespace names and selector",
+			scope: &v1alpha1.ComplianceScope{
+				Namespaces:        []string{"blue-prod", "green-dev"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "blue"}},
+			},
+			expected: []string{"blue-prod/replicaset-api", "blue-prod/replicaset-web"},
+		},
+		{
+			name:     "Should select reports by resource selector",
+			scope:    &v1alpha1.ComplianceScope{ResourceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "backend"}}},
+			expected: []string{"blue-dev/replicaset-api", "blue-prod/replicaset-api", "green-dev/replicaset-api"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := newScope(context.TODO(), objectResolver, tc.scope)
+			require.NoError(t, err)
+			var reportList v1alpha1.ConfigAuditReportList
+			require.NoError(t, testClient.List(context.TODO(), &reportList))
+			scannerResourceMap := map[string]map[string]client.ObjectList{ConfigAudit: {"ReplicaSet": &reportList}}
+
+			require.NoError(t, s.filter(context.TODO(), scannerResourceMap))
+			var names []string
+			for _, item := range reportList.Items {
+				names = append(names, item.Namespace+"/"+item.Name)
+			}
+			assert.ElementsMatch(t, tc.expected, names)
+		})
+	}
+}
+
+func TestMgr_GenerateComplianceReport_Scoped(t *testing.T) {
+	spec := &v1alpha1.ClusterComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "blue"},
+		Spec: v1alpha1.ReportSpec{
+			Name:    "blue",
+			Version: "1.0",
+			Cron:    "0 */3 * * *",
+			Controls: []v1alpha1.Control{{
+				ID:       "1.0",
+				Name:     "Non-root containers",
+				Kinds:    []string{"Workload"},
+				Mapping:  v1alpha1.Mapping{Scanner: ConfigAudit, Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}},
+				Severity: v1alpha1.SeverityMedium,
+			}},
+			Scope: &v1alpha1.ComplianceScope{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "blue"}},
+				TenantLabel:       "tenant",
+			},
+		},
+	}
+	testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(append(getScopedObjects(), spec)...).Build()
+	mgr := NewMgr(testClient, kube.NewObjectResolver(testClient, nil), log.Log, starboard.ConfigData{}, ext.NewSystemClock())
+
+	_, err := mgr.GenerateComplianceReport(context.TODO(), spec.Spec)
+	require.NoError(t, err)
+
+	var report v1alpha1.ClusterComplianceReport
+	require.NoError(t, testClient.Get(context.TODO(), types.NamespacedName{Name: "blue"}, &report))
+	assert.Equal(t, v1alpha1.ClusterComplianceSummary{PassCount: 1, FailCount: 2}, report.Status.Summary)
+
+	var detail v1alpha1.ClusterComplianceDetailReport
+	require.NoError(t, testClient.Get(context.TODO(), types.NamespacedName{Name: "blue-details"}, &detail))
+	require.Len(t, detail.Report.ControlChecks, 1)
+	require.Len(t, detail.Report.ControlChecks[0].ScannerCheckResult, 1)
+	assert.ElementsMatch(t, []v1alpha1.ResultDetails{
+		{Name: "replicaset-api", Namespace: "blue-dev", Tenant: "blue", Msg: "Container should set runAsNonRoot to true", Status: v1alpha1.FailStatus},
+		{Name: "replicaset-web", Namespace: "blue-prod", Tenant: "blue", Msg: "Container should set runAsNonRoot to true", Status: v1alpha1.FailStatus},
+	}, detail.Report.ControlChecks[0].ScannerCheckResult[0].Details)
+}
@@ -0,0 +1,191 @@
+package compliance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// shardContinuesControl is the value of the
+	// starboard.AnnotationComplianceShardContinues annotation of a shard whose
+	// first control check continues the last control check of the previous
+	// shard with the next check result.
+	shardContinuesControl = "control"
+	// shardContinuesCheck is the value of the
+	// starboard.AnnotationComplianceShardContinues annotation of a shard whose
+	// first control check continues the last check result of the previous
+	// shard with the next details.
+	shardContinuesCheck = "check"
+
+	// maxDetailShardBytes is the maximum JSON encoded size of control checks
+	// in a single shard. etcd rejects requests larger than 1.5 MiB by default,
+	// which leaves room for the metadata and the summary of the shard.
+	maxDetailShardBytes = 1024 * 1024
+)
+
+// detailShard holds control checks of a single ClusterComplianceDetailReport
+// shard.
+type detailShard struct {
+	controlChecks []v1alpha1.ControlCheckDetails
+	// continues is empty, shardContinuesControl or shardContinuesCheck.
+	continues string
+}
+
+// detailReportName returns the name of the ClusterComplianceDetailReport
+// shard with the given index. The first shard is named after the compliance
+// report with the details suffix, e.g. nsa-details, and subsequent shards
+// have their index appended, e.g. nsa-details-1.
+func detailReportName(name string, index int) string {
+	if index == 0 {
+		return fmt.Sprintf("%s-%s", name, "details")
+	}
+	return fmt.Sprintf("%s-%s-%d", name, "details", index)
+}
+
+// shardControlChecks splits the given control checks, sorted by id, into
+// shards with at most size result details each, whose JSON encoding takes at
+// most maxBytes, unless a single result detail is larger. A control check or
+// a check result which does not fit is continued in the next shard. There is
+// always at least one shard.
+func shardControlChecks(controlChecks []v1alpha1.ControlCheckDetails, size, maxBytes int) []detailShard {
+	sorted := append([]v1alpha1.ControlCheckDetails{}, controlChecks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+	shards := []detailShard{{controlChecks: make([]v1alpha1.ControlCheckDetails, 0)}}
+	// bytes starts with the brackets of the array of control checks
+	entries, bytes := 0, len("[]")
+	full := func(details v1alpha1.ResultDetails) bool {
+		return entries >= size || (entries > 0 && bytes+encodedSize(details) > maxBytes)
+	}
+	for _, controlCheck := range sorted {
+		piece := controlCheck
+		piece.ScannerCheckResult = make([]v1alpha1.ScannerCheckResult, 0)
+		pieceBytes := encodedSize(piece)
+		bytes += pieceBytes
+		placed := false
+		for _, result := range controlCheck.ScannerCheckResult {
+			details := result.Details
+			part := result
+			part.Details = make([]v1alpha1.ResultDetails, 0)
+			partBytes := encodedSize(part)
+			bytes += partBytes
+			started := false
+			for !started || len(details) > 0 {
+				if len(details) > 0 && full(details[0]) {
+					continues := ""
+					if placed {
+						continues = shardContinuesControl
+						shards[len(shards)-1].controlChecks = append(shards[len(shards)-1].controlChecks, piece)
+						piece = controlCheck
+						piece.ScannerCheckResult = make([]v1alpha1.ScannerCheckResult, 0)
+					}
+					if started {
+						continues = shardContinuesCheck
+					}
+					shards = append(shards, detailShard{controlChecks: make([]v1alpha1.ControlCheckDetails, 0), continues: continues})
+					entries, bytes = 0, len("[]")+pieceBytes+partBytes
+				}
+				n := 0
+				for n < len(details) && (n == 0 || !full(details[n])) {
+					bytes += encodedSize(details[n])
+					entries++
+					n++
+				}
+				part := result
+				part.Details = details[:n]
+				piece.ScannerCheckResult = append(piece.ScannerCheckResult, part)
+				details = details[n:]
+				started = true
+				placed = true
+			}
+		}
+		shards[len(shards)-1].controlChecks = append(shards[len(shards)-1].controlChecks, piece)
+	}
+	return shards
+}
+
+// encodedSize returns the size of the JSON encoding of the given value as an
+// array element, i.e. including the separating comma.
+func encodedSize(v interface{}) int {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return len(data) + len(",")
+}
+
+// mergeControlChecks puts control checks of the given shards back together.
+func mergeControlChecks(shards []detailShard) []v1alpha1.ControlCheckDetails {
+	merged := make([]v1alpha1.ControlCheckDetails, 0)
+	for _, shard := range shards {
+		for i, controlCheck := range shard.controlChecks {
+			controlCheck := *controlCheck.DeepCopy()
+			if i > 0 || shard.continues == "" || len(merged) == 0 {
+				merged = append(merged, controlCheck)
+				continue
+			}
+			last := &merged[len(merged)-1]
+			results := controlCheck.ScannerCheckResult
+			if shard.continues == shardContinuesCheck && len(results) > 0 && len(last.ScannerCheckResult) > 0 {
+				lastResult := &last.ScannerCheckResult[len(last.ScannerCheckResult)-1]
+				lastResult.Details = append(lastResult.Details, results[0].Details...)
+				results = results[1:]
+			}
+			last.ScannerCheckResult = append(last.ScannerCheckResult, results...)
+		}
+	}
+	return merged
+}
+
+// shardIndex returns the index of the given ClusterComplianceDetailReport
+// shard. Detail reports created before sharding have no index and are the
+// first shard.
+func shardIndex(report v1alpha1.ClusterComplianceDetailReport) int {
+	index, err := strconv.Atoi(report.Labels[starboard.LabelComplianceShard])
+	if err != nil {
+		return 0
+	}
+	return index
+}
+
+// GetDetailReport returns the ClusterComplianceDetailReport of the
+// ClusterComplianceReport with the given name, with control checks of all its
+// shards put back together.
+func GetDetailReport(ctx context.Context, c client.Client, name string) (*v1alpha1.ClusterComplianceDetailReport, error) {
+	var reportList v1alpha1.ClusterComplianceDetailReportList
+	err := c.List(ctx, &reportList, client.MatchingLabels{starboard.LabelComplianceReport: name})
+	if err != nil {
+		return nil, fmt.Errorf("listing compliance detail reports: %w", err)
+	}
+	if len(reportList.Items) == 0 {
+		// detail reports created before sharding are not labeled
+		var report v1alpha1.ClusterComplianceDetailReport
+		err = c.Get(ctx, types.NamespacedName{Name: detailReportName(name, 0)}, &report)
+		if err != nil {
+			return nil, err
+		}
+		return &report, nil
+	}
+	sort.Slice(reportList.Items, func(i, j int) bool {
+		return shardIndex(reportList.Items[i]) < shardIndex(reportList.Items[j])
+	})
+	shards := make([]detailShard, 0, len(reportList.Items))
+	for _, item := range reportList.Items {
+		shards = append(shards, detailShard{
+			controlChecks: item.Report.ControlChecks,
+			continues:     item.Annotations[starboard.AnnotationComplianceShardContinues],
+		})
+	}
+	report := reportList.Items[0].DeepCopy()
+	report.Report.ControlChecks = mergeControlChecks(shards)
+	return report, nil
+}
@@ -0,0 +1,206 @@
+package compliance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func getResultDetails(names ...string) []v1alpha1.ResultDetails {
+	details := make([]v1alpha1.ResultDetails, 0, len(names))
+	for _, name := range names {
+		details = append(details, v1alpha1.ResultDetails{Name: name, Namespace: "default", Msg: "failed", Status: v1alpha1.FailStatus})
+	}
+	return details
+}
+
+func getShardedControlChecks() []v1alpha1.ControlCheckDetails {
+	return []v1alpha1.ControlCheckDetails{
+		{ID: "1.1", Name: "Immutable container file systems", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ObjectType: "Pod", ID: "KSV014", Details: getResultDetails("a", "b", "c")},
+			{ObjectType: "ReplicaSet", ID: "KSV014", Details: getResultDetails("d", "e")},
+		}},
+		{ID: "1.0", Name: "Non-root containers", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ObjectType: "Pod", ID: "KSV012", Details: getResultDetails("f")},
+		}},
+		// controls with default results have several check results of the same kind
+		{ID: "5.0", Name: "Encrypt etcd communication", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ObjectType: "Node", Details: getResultDetails("g")},
+		}},
+		{ID: "5.0", Name: "Encrypt etcd communication", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ObjectType: "Node", Details: getResultDetails("h")},
+		}},
+	}
+}
+
+func TestShardControlChecks(t *testing.T) {
+	shards := shardControlChecks(getShardedControlChecks(), 3, maxDetailShardBytes)
+	require.Len(t, shards, 3)
+
+	assert.Equal(t, "", shards[0].continues)
+	assert.Equal(t, []v1alpha1.ControlCheckDetails{
+		{ID: "1.0", Name: "Non-root containers", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ObjectType: "Pod", ID: "KSV012", Details: getResultDetails("f")},
+		}},
+		{ID: "1.1", Name: "Immutable container file systems", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ObjectType: "Pod", ID: "KSV014", Details: getResultDetails("a", "b")},
+		}},
+	}, shards[0].controlChecks)
+
+	assert.Equal(t, shardContinuesCheck, shards[1].continues)
+	assert.Equal(t, []v1alpha1.ControlCheckDetails{
+		{ID: "1.1", Name: "Immutable container file systems", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ObjectType: "Pod", ID: "KSV014", Details: getResultDetails("c")},
+			{ObjectType: "ReplicaSet", ID: "KSV014", Details: getResultDetails("d", "e")},
+		}},
+	}, shards[1].controlChecks)
+
+	assert.Equal(t, "", shards[2].continues)
+	assert.Equal(t, []v1alpha1.ControlCheckDetails{
+		{ID: "5.0", Name: "Encrypt etcd communication", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ObjectType: "Node", Details: getResultDetails("g")},
+		}},
+		{ID: "5.0", Name: "Encrypt etcd communication", ScannerCheckResult: []v1alpha1.ScannerCheckResult{
+			{ObjectType: "Node", Details: getResultDetails("h")},
+		}},
+	}, shards[2].controlChecks)
+}
+
+func TestShardControlChecks_MaxBytes(t *testing.T) {
+	controlChecks := getShardedControlChecks()
+	for maxBytes := 300; maxBytes <= 1500; maxBytes += 100 {
+		t.Run(fmt.Sprintf("Should put back together shards of %d bytes", maxBytes), func(t *testing.T) {
+			shards := shardControlChecks(controlChecks, 1000, maxBytes)
+			for _, shard := range shards {
+				data, err := json.Marshal(shard.controlChecks)
+				require.NoError(t, err)
+				assert.LessOrEqual(t, len(data), maxBytes)
+			}
+			assert.ElementsMatch(t, controlChecks, mergeControlChecks(shards))
+		})
+	}
+	assert.Len(t, shardControlChecks(controlChecks, 1000, maxDetailShardBytes), 1)
+}
+
+func TestShardControlChecks_Empty(t *testing.T) {
+	shards := shardControlChecks(nil, 3, maxDetailShardBytes)
+	require.Len(t, shards, 1)
+	assert.Empty(t, shards[0].controlChecks)
+}
+
+func TestMergeControlChecks(t *testing.T) {
+	controlChecks := getShardedControlChecks()
+	for size := 1; size <= 9; size++ {
+		t.Run(fmt.Sprintf("Should put back together shards of size %d", size), func(t *testing.T) {
+			shards := shardControlChecks(controlChecks, size, maxDetailShardBytes)
+			for _, shard := range shards {
+				entries := 0
+				for _, controlCheck := range shard.controlChecks {
+					for _, result := range controlCheck.ScannerCheckResult {
+						entries += len(result.Details)
+					}
+				}
+				assert.LessOrEqual(t, entries, size)
+			}
+			assert.ElementsMatch(t, controlChecks, mergeControlChecks(shards))
+		})
+	}
+}
+
+func TestMgr_GenerateComplianceReport_Sharded(t *testing.T) {
+	spec := &v1alpha1.ClusterComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "nsa"},
+		Spec: v1alpha1.ReportSpec{
+			Name:    "nsa",
+			Version: "1.0",
+			Cron:    "0 */3 * * *",
+			Controls: []v1alpha1.Control{{
+				ID:       "1.0",
+				Name:     "Non-root containers",
+				Kinds:    []string{"Workload"},
+				Mapping:  v1alpha1.Mapping{Scanner: ConfigAudit, Checks: []v1alpha1.SpecCheck{{ID: "KSV012"}}},
+				Severity: v1alpha1.SeverityMedium,
+			}},
+		},
+	}
+	objects := []client.Object{spec}
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("app-%d", i)
+		objects = append(objects, &v1alpha1.ConfigAuditReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "replicaset-" + name,
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "ReplicaSet",
+					starboard.LabelResourceName:      name,
+					starboard.LabelResourceNamespace: "default",
+				},
+			},
+			Report: v1alpha1.ConfigAuditReportData{
+				Checks: []v1alpha1.Check{{ID: "KSV012", Messages: []string{"Container should set runAsNonRoot to true"}}},
+			},
+		})
+	}
+	testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(objects...).Build()
+	generate := func(shardSize string) {
+		config := starboard.ConfigData{
+			"compliance.failEntriesLimit": "100",
+			"compliance.detailShardSize":  shardSize,
+		}
+		mgr := NewMgr(testClient, kube.NewObjectResolver(testClient, nil), log.Log, config, ext.NewSystemClock())
+		_, err := mgr.GenerateComplianceReport(context.TODO(), spec.Spec)
+		require.NoError(t, err)
+	}
+	shardNames := func() []string {
+		var reportList v1alpha1.ClusterComplianceDetailReportList
+		require.NoError(t, testClient.List(context.TODO(), &reportList))
+		var names []string
+		for _, item := range reportList.Items {
+			names = append(names, item.Name)
+			require.Len(t, item.OwnerReferences, 1)
+			assert.Equal(t, "nsa", item.OwnerReferences[0].Name)
+		}
+		return names
+	}
+
+	generate("2")
+	assert.ElementsMatch(t, []string{"nsa-details", "nsa-details-1", "nsa-details-2"}, shardNames())
+	detail, err := GetDetailReport(context.TODO(), testClient, "nsa")
+	require.NoError(t, err)
+	assert.Equal(t, v1alpha1.ClusterComplianceSummary{FailCount: 5}, detail.Report.Summary)
+	require.Len(t, detail.Report.ControlChecks, 1)
+	require.Len(t, detail.Report.ControlChecks[0].ScannerCheckResult, 1)
+	assert.Len(t, detail.Report.ControlChecks[0].ScannerCheckResult[0].Details, 5)
+
+	generate("4")
+	assert.ElementsMatch(t, []string{"nsa-details", "nsa-details-1"}, shardNames())
+	detail, err = GetDetailReport(context.TODO(), testClient, "nsa")
+	require.NoError(t, err)
+	assert.Len(t, detail.Report.ControlChecks[0].ScannerCheckResult[0].Details, 5)
+}
+
+func TestGetDetailReport_NotSharded(t *testing.T) {
+	detail := &v1alpha1.ClusterComplianceDetailReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "nsa-details"},
+		Report: v1alpha1.ClusterComplianceDetailReportData{
+			ControlChecks: getShardedControlChecks(),
+		},
+	}
+	testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(detail).Build()
+
+	got, err := GetDetailReport(context.TODO(), testClient, "nsa")
+	require.NoError(t, err)
+	assert.Equal(t, getShardedControlChecks(), got.Report.ControlChecks)
+}
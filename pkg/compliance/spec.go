@@ -0,0 +1,139 @@
+package compliance
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// KeyComplianceSpec is the key of a ConfigMap labeled with
+	// starboard.LabelComplianceSpec that holds a ClusterComplianceReport
+	// manifest.
+	KeyComplianceSpec = "spec.yaml"
+)
+
+var (
+	policyIDRegexp    = regexp.MustCompile(`"id":\s*"([^"]+)"`)
+	policyAVDIDRegexp = regexp.MustCompile(`"avd_id":\s*"([^"]+)"`)
+)
+
+// kubeBenchChecks are the numbers of kube-bench tests by section of the
+// supported CIS Kubernetes Benchmark versions.
+var kubeBenchChecks = map[string]int{
+	"1.1": 21,
+	"1.2": 32,
+	"1.3": 7,
+	"1.4": 2,
+	"2":   7,
+	"3.1": 1,
+	"3.2": 2,
+	"4.1": 10,
+	"4.2": 13,
+	"5.1": 8,
+	"5.2": 13,
+	"5.3": 2,
+	"5.4": 2,
+	"5.5": 1,
+	"5.7": 4,
+}
+
+// CheckCatalog holds the ids of checks known to each scanner, which the
+// controls of compliance specs can be mapped to.
+type CheckCatalog map[string]map[string]bool
+
+// NewCheckCatalog returns the CheckCatalog with kube-bench test numbers of
+// supported CIS Kubernetes Benchmark versions and with ids of OPA Rego
+// policies stored in the given policies ConfigMap data. Policies are known
+// to the config-audit scanner by their ids, e.g. KSV001, and to the
+// trivy-misconfig scanner by their ids or AVD ids, e.g. AVD-KSV-0001.
+func NewCheckCatalog(policies map[string]string) CheckCatalog {
+	catalog := CheckCatalog{
+		KubeBench:      make(map[string]bool),
+		ConfigAudit:    make(map[string]bool),
+		TrivyMisconfig: make(map[string]bool),
+	}
+	for section, count := range kubeBenchChecks {
+		for i := 1; i <= count; i++ {
+			catalog[KubeBench][fmt.Sprintf("%s.%d", section, i)] = true
+		}
+	}
+	for key, value := range policies {
+		if !strings.HasPrefix(key, "policy.") || !strings.HasSuffix(key, ".rego") {
+			continue
+		}
+		if match := policyIDRegexp.FindStringSubmatch(value); match != nil {
+			catalog[ConfigAudit][match[1]] = true
+			catalog[TrivyMisconfig][match[1]] = true
+		}
+		if match := policyAVDIDRegexp.FindStringSubmatch(value); match != nil {
+			catalog[TrivyMisconfig][match[1]] = true
+		}
+	}
+	return catalog
+}
+
+// ParseSpec parses the given ClusterComplianceReport manifest.
+func ParseSpec(data []byte) (v1alpha1.ClusterComplianceReport, error) {
+	var report v1alpha1.ClusterComplianceReport
+	err := yaml.UnmarshalStrict(data, &report)
+	if err != nil {
+		return v1alpha1.ClusterComplianceReport{}, fmt.Errorf("parsing compliance spec: %w", err)
+	}
+	return report, nil
+}
+
+// ValidateSpec returns an error if the given spec has no name, has an invalid
+// scope selector, has duplicate control ids, or has a control mapped to an
+// unsupported scanner or to a check that is unknown to the scanner. Checks of the vulnerability scanner
+// are not looked up in the catalog, but must have a valid threshold and an
+// id that is unique within the spec. Manual controls must not have checks.
+func ValidateSpec(spec v1alpha1.ReportSpec, catalog CheckCatalog) error {
+	if spec.Name == "" {
+		return fmt.Errorf("spec name must not be empty")
+	}
+	if spec.Scope != nil {
+		if _, _, err := scopeSelectors(spec.Scope); err != nil {
+			return fmt.Errorf("scope: %w", err)
+		}
+	}
+	controlIDs := make(map[string]bool)
+	vulnerabilityCheckIDs := make(map[string]bool)
+	for _, control := range spec.Controls {
+		if controlIDs[control.ID] {
+			return fmt.Errorf("duplicate control id %q", control.ID)
+		}
+		controlIDs[control.ID] = true
+		if control.Mapping.Scanner == Manual {
+			if len(control.Mapping.Checks) > 0 {
+				return fmt.Errorf("control %q: manual control must not have checks", control.ID)
+			}
+			continue
+		}
+		for _, check := range control.Mapping.Checks {
+			switch control.Mapping.Scanner {
+			case KubeBench, ConfigAudit, TrivyMisconfig:
+				if !catalog[control.Mapping.Scanner][check.ID] {
+					return fmt.Errorf("control %q: unknown %s check id %q", control.ID, control.Mapping.Scanner, check.ID)
+				}
+			case Vulnerability:
+				if check.Threshold == nil {
+					return fmt.Errorf("control %q: vulnerability check %q must have a threshold", control.ID, check.ID)
+				}
+				if _, ok := severityRank[check.Threshold.Severity]; !ok {
+					return fmt.Errorf("control %q: vulnerability check %q: unsupported severity %q", control.ID, check.ID, check.Threshold.Severity)
+				}
+				if vulnerabilityCheckIDs[check.ID] {
+					return fmt.Errorf("control %q: duplicate vulnerability check id %q", control.ID, check.ID)
+				}
+				vulnerabilityCheckIDs[check.ID] = true
+			default:
+				return fmt.Errorf("control %q: mapper scanner: %s is not supported", control.ID, control.Mapping.Scanner)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package compliance
+
+import (
+	. "github.com/aquasecurity/starboard/pkg/operator/predicate"
+
+	"context"
+	"fmt"
+	"strings"
+
+	embedded "github.com/aquasecurity/starboard"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ReasonInvalidSpec is the reason of the event raised when a ConfigMap
+	// holds an invalid compliance spec.
+	ReasonInvalidSpec = "InvalidComplianceSpec"
+)
+
+// SpecController watches ConfigMaps that hold compliance specs in the
+// operator namespace and creates or updates the corresponding
+// v1alpha1.ClusterComplianceReport instances. Invalid specs are rejected with
+// a warning event raised for the ConfigMap.
+//
+// Deleting the ConfigMap does not delete the ClusterComplianceReport.
+type SpecController struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	record.EventRecorder
+}
+
+func (r *SpecController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("compliancespec").
+		For(&corev1.ConfigMap{}, builder.WithPredicates(
+			InNamespace(r.Config.Namespace),
+			IsComplianceSpec,
+			Not(IsBeingTerminated),
+		)).
+		Complete(r.reconcileSpec())
+}
+
+func (r *SpecController) reconcileSpec() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("configMap", req.NamespacedName)
+
+		var cm corev1.ConfigMap
+		err := r.Client.Get(ctx, req.NamespacedName, &cm)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.V(1).Info("Ignoring cached configmap that must have been deleted")
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting configmap from cache: %w", err)
+		}
+
+		catalog, err := r.checkCatalog(ctx)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		report, err := LoadSpec(cm, catalog)
+		if err != nil {
+			log.Error(err, "Rejecting invalid compliance spec")
+			r.EventRecorder.Eventf(&cm, corev1.EventTypeWarning, ReasonInvalidSpec, "Compliance spec rejected: %v", err)
+			return ctrl.Result{}, nil
+		}
+
+		err = CreateOrUpdateSpec(ctx, r.Client, report)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("creating or updating compliance spec %s: %w", report.Name, err)
+		}
+		log.V(1).Info("Loaded compliance spec", "name", report.Name)
+		return ctrl.Result{}, nil
+	}
+}
+
+// checkCatalog returns the CheckCatalog of built-in policies and policies
+// stored in the policies ConfigMap in the operator namespace.
+func (r *SpecController) checkCatalog(ctx context.Context) (CheckCatalog, error) {
+	builtIn, err := embedded.PoliciesConfigMap()
+	if err != nil {
+		return nil, fmt.Errorf("getting built-in policies: %w", err)
+	}
+	policies := make(map[string]string)
+	for key, value := range builtIn.Data {
+		policies[key] = value
+	}
+	var cm corev1.ConfigMap
+	err = r.Client.Get(ctx, client.ObjectKey{
+		Namespace: r.Config.Namespace,
+		Name:      starboard.PoliciesConfigMapName,
+	}, &cm)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("getting policies from configmap: %s/%s: %w", r.Config.Namespace, starboard.PoliciesConfigMapName, err)
+	}
+	for key, value := range cm.Data {
+		policies[key] = value
+	}
+	return NewCheckCatalog(policies), nil
+}
+
+// LoadSpec parses and validates the ClusterComplianceReport manifest stored
+// under the KeyComplianceSpec key of the given ConfigMap. The returned report
+// is named after its spec, which is how compliance reports are looked up.
+func LoadSpec(cm corev1.ConfigMap, catalog CheckCatalog) (v1alpha1.ClusterComplianceReport, error) {
+	data, ok := cm.Data[KeyComplianceSpec]
+	if !ok {
+		return v1alpha1.ClusterComplianceReport{}, fmt.Errorf("configmap %s/%s has no %s key", cm.Namespace, cm.Name, KeyComplianceSpec)
+	}
+	report, err := ParseSpec([]byte(data))
+	if err != nil {
+		return v1alpha1.ClusterComplianceReport{}, err
+	}
+	err = ValidateSpec(report.Spec, catalog)
+	if err != nil {
+		return v1alpha1.ClusterComplianceReport{}, err
+	}
+	return v1alpha1.ClusterComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   strings.ToLower(report.Spec.Name),
+			Labels: report.Labels,
+		},
+		Spec: report.Spec,
+	}, nil
+}
+
+// CreateOrUpdateSpec creates the given v1alpha1.ClusterComplianceReport or
+// updates the spec of the existing one, leaving its status intact.
+func CreateOrUpdateSpec(ctx context.Context, c client.Client, report v1alpha1.ClusterComplianceReport) error {
+	var existing v1alpha1.ClusterComplianceReport
+	err := c.Get(ctx, types.NamespacedName{Name: report.Name}, &existing)
+	switch {
+	case err == nil:
+		if equality.Semantic.DeepEqual(existing.Spec, report.Spec) {
+			return nil
+		}
+		copied := existing.DeepCopy()
+		copied.Spec = report.Spec
+		return c.Update(ctx, copied)
+	case errors.IsNotFound(err):
+		return c.Create(ctx, &report)
+	}
+	return err
+}
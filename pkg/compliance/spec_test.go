@@ -0,0 +1,224 @@
+package compliance
+
+import (
+	"context"
+	"testing"
+
+	embedded "github.com/aquasecurity/starboard"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewCheckCatalog(t *testing.T) {
+	catalog := NewCheckCatalog(map[string]string{
+		"policy.1_host_ipc.kinds": "Workload",
+		"policy.1_host_ipc.rego":  "package appshield.kubernetes.KSV008\n\n__rego_metadata__ := {\n\t\"id\": \"KSV008\",\n\t\"avd_id\": \"AVD-KSV-0008\",\n}",
+		"library.utils.rego":      "package lib.utils\n\n__rego_metadata__ := {\n\t\"id\": \"LIB001\",\n}",
+	})
+	assert.True(t, catalog[ConfigAudit]["KSV008"])
+	assert.False(t, catalog[ConfigAudit]["AVD-KSV-0008"])
+	assert.True(t, catalog[TrivyMisconfig]["KSV008"])
+	assert.True(t, catalog[TrivyMisconfig]["AVD-KSV-0008"])
+	assert.False(t, catalog[ConfigAudit]["LIB001"])
+	assert.True(t, catalog[KubeBench]["1.2.32"])
+	assert.True(t, catalog[KubeBench]["2.7"])
+	assert.False(t, catalog[KubeBench]["1.2.33"])
+}
+
+func TestValidateSpec(t *testing.T) {
+	catalog := CheckCatalog{
+		KubeBench:      {"1.2.1": true},
+		ConfigAudit:    {"KSV012": true},
+		TrivyMisconfig: {"AVD-KSV-0012": true},
+	}
+	control := func(id, scanner string, checks ...v1alpha1.SpecCheck) v1alpha1.Control {
+		return v1alpha1.Control{ID: id, Name: id, Kinds: []string{"Workload"}, Mapping: v1alpha1.Mapping{Scanner: scanner, Checks: checks}}
+	}
+	threshold := &v1alpha1.VulnerabilityThreshold{Severity: v1alpha1.SeverityCritical}
+	tests := []struct {
+		name    string
+		spec    v1alpha1.ReportSpec
+		wantErr string
+	}{
+		{name: "valid spec", spec: v1alpha1.ReportSpec{Name: "custom", Controls: []v1alpha1.Control{
+			control("1", KubeBench, v1alpha1.SpecCheck{ID: "1.2.1"}),
+			control("2", ConfigAudit, v1alpha1.SpecCheck{ID: "KSV012"}),
+			control("3", TrivyMisconfig, v1alpha1.SpecCheck{ID: "AVD-KSV-0012"}),
+			control("4", Vulnerability, v1alpha1.SpecCheck{ID: "critical", Threshold: threshold}),
+			control("5", Manual),
+		}}},
+		{name: "no name", spec: v1alpha1.ReportSpec{}, wantErr: "spec name must not be empty"},
+		{name: "invalid scope selector", spec: v1alpha1.ReportSpec{Name: "custom", Scope: &v1alpha1.ComplianceScope{
+			NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tenant", Operator: "Equals"}}},
+		}}, wantErr: `scope: namespace selector: "Equals" is not a valid pod selector operator`},
+		{name: "duplicate control id", spec: v1alpha1.ReportSpec{Name: "custom", Controls: []v1alpha1.Control{
+			control("1", ConfigAudit, v1alpha1.SpecCheck{ID: "KSV012"}),
+			control("1", KubeBench, v1alpha1.SpecCheck{ID: "1.2.1"}),
+		}}, wantErr: `duplicate control id "1"`},
+		{name: "unknown kube-bench check", spec: v1alpha1.ReportSpec{Name: "custom", Controls: []v1alpha1.Control{
+			control("1", KubeBench, v1alpha1.SpecCheck{ID: "9.9.9"}),
+		}}, wantErr: `control "1": unknown kube-bench check id "9.9.9"`},
+		{name: "unknown config-audit check", spec: v1alpha1.ReportSpec{Name: "custom", Controls: []v1alpha1.Control{
+			control("1", ConfigAudit, v1alpha1.SpecCheck{ID: "AVD-KSV-0012"}),
+		}}, wantErr: `control "1": unknown config-audit check id "AVD-KSV-0012"`},
+		{name: "unsupported scanner", spec: v1alpha1.ReportSpec{Name: "custom", Controls: []v1alpha1.Control{
+			control("1", "polaris", v1alpha1.SpecCheck{ID: "runAsRootAllowed"}),
+		}}, wantErr: `control "1": mapper scanner: polaris is not supported`},
+		{name: "vulnerability check without threshold", spec: v1alpha1.ReportSpec{Name: "custom", Controls: []v1alpha1.Control{
+			control("1", Vulnerability, v1alpha1.SpecCheck{ID: "critical"}),
+		}}, wantErr: `control "1": vulnerability check "critical" must have a threshold`},
+		{name: "vulnerability check with unsupported severity", spec: v1alpha1.ReportSpec{Name: "custom", Controls: []v1alpha1.Control{
+			control("1", Vulnerability, v1alpha1.SpecCheck{ID: "critical", Threshold: &v1alpha1.VulnerabilityThreshold{Severity: "DANGER"}}),
+		}}, wantErr: `control "1": vulnerability check "critical": unsupported severity "DANGER"`},
+		{name: "duplicate vulnerability check id", spec: v1alpha1.ReportSpec{Name: "custom", Controls: []v1alpha1.Control{
+			control("1", Vulnerability, v1alpha1.SpecCheck{ID: "critical", Threshold: threshold}),
+			control("2", Vulnerability, v1alpha1.SpecCheck{ID: "critical", Threshold: threshold}),
+		}}, wantErr: `control "2": duplicate vulnerability check id "critical"`},
+		{name: "manual control with checks", spec: v1alpha1.ReportSpec{Name: "custom", Controls: []v1alpha1.Control{
+			control("1", Manual, v1alpha1.SpecCheck{ID: "KSV012"}),
+		}}, wantErr: `control "1": manual control must not have checks`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSpec(tt.spec, catalog)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestValidateSpec_BundledSpecs(t *testing.T) {
+	policies, err := embedded.PoliciesConfigMap()
+	require.NoError(t, err)
+	catalog := NewCheckCatalog(policies.Data)
+
+	specs, err := embedded.GetComplianceSpecs()
+	require.NoError(t, err)
+	require.Len(t, specs, 4)
+	for _, spec := range specs {
+		t.Run(spec.Name, func(t *testing.T) {
+			assert.Equal(t, spec.Name, spec.Spec.Name)
+			assert.NotEmpty(t, spec.Spec.Controls)
+			assert.NoError(t, ValidateSpec(spec.Spec, catalog))
+		})
+	}
+}
+
+func TestLoadSpec(t *testing.T) {
+	catalog := CheckCatalog{ConfigAudit: {"KSV012": true}}
+	configMap := func(data string) corev1.ConfigMap {
+		return corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "custom-spec",
+				Namespace: "starboard-system",
+				Labels:    map[string]string{starboard.LabelComplianceSpec: "true"},
+			},
+			Data: map[string]string{KeyComplianceSpec: data},
+		}
+	}
+
+	t.Run("Should load valid spec", func(t *testing.T) {
+		report, err := LoadSpec(configMap(`apiVersion: aquasecurity.github.io/v1alpha1
+kind: ClusterComplianceReport
+metadata:
+  name: ignored
+  labels:
+    team: platform
+spec:
+  name: Custom
+  description: Custom compliance spec
+  version: "1.0"
+  cron: "0 */3 * * *"
+  controls:
+    - name: Non-root containers
+      id: '1.0'
+      kinds:
+        - Workload
+      mapping:
+        scanner: config-audit
+        checks:
+          - id: KSV012
+      severity: MEDIUM
+`), catalog)
+		require.NoError(t, err)
+		assert.Equal(t, "custom", report.Name)
+		assert.Equal(t, map[string]string{"team": "platform"}, report.Labels)
+		assert.Equal(t, "Custom", report.Spec.Name)
+		assert.Len(t, report.Spec.Controls, 1)
+	})
+
+	t.Run("Should reject unknown check id", func(t *testing.T) {
+		_, err := LoadSpec(configMap(`spec:
+  name: custom
+  controls:
+    - name: Non-root containers
+      id: '1.0'
+      mapping:
+        scanner: config-audit
+        checks:
+          - id: KSV999
+`), catalog)
+		assert.EqualError(t, err, `control "1.0": unknown config-audit check id "KSV999"`)
+	})
+
+	t.Run("Should reject unknown field", func(t *testing.T) {
+		_, err := LoadSpec(configMap(`spec:
+  name: custom
+  controls:
+    - name: Non-root containers
+      id: '1.0'
+      mapping:
+        scanner: vulnerability
+        checks:
+          - id: critical
+            treshold:
+              severity: CRITICAL
+`), catalog)
+		assert.ErrorContains(t, err, "parsing compliance spec")
+	})
+
+	t.Run("Should reject configmap without spec", func(t *testing.T) {
+		_, err := LoadSpec(corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "custom-spec", Namespace: "starboard-system"}}, catalog)
+		assert.EqualError(t, err, "configmap starboard-system/custom-spec has no spec.yaml key")
+	})
+}
+
+func TestCreateOrUpdateSpec(t *testing.T) {
+	existing := &v1alpha1.ClusterComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+		Spec:       v1alpha1.ReportSpec{Name: "custom", Cron: "0 */3 * * *"},
+		Status: v1alpha1.ReportStatus{
+			Summary: v1alpha1.ClusterComplianceSummary{PassCount: 3, FailCount: 1},
+		},
+	}
+	testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(existing).Build()
+
+	err := CreateOrUpdateSpec(context.TODO(), testClient, v1alpha1.ClusterComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+		Spec:       v1alpha1.ReportSpec{Name: "custom", Cron: "0 * * * *"},
+	})
+	require.NoError(t, err)
+	err = CreateOrUpdateSpec(context.TODO(), testClient, v1alpha1.ClusterComplianceReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "other"},
+		Spec:       v1alpha1.ReportSpec{Name: "other"},
+	})
+	require.NoError(t, err)
+
+	var updated v1alpha1.ClusterComplianceReport
+	require.NoError(t, testClient.Get(context.TODO(), types.NamespacedName{Name: "custom"}, &updated))
+	assert.Equal(t, "0 * * * *", updated.Spec.Cron)
+	assert.Equal(t, existing.Status.Summary, updated.Status.Summary)
+
+	var created v1alpha1.ClusterComplianceReport
+	require.NoError(t, testClient.Get(context.TODO(), types.NamespacedName{Name: "other"}, &created))
+	assert.Equal(t, "other", created.Spec.Name)
+}
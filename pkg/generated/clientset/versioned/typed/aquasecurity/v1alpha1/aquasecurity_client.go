@@ -17,8 +17,12 @@ type AquasecurityV1alpha1Interface interface {
 	ClusterComplianceReportsGetter
 	ClusterConfigAuditReportsGetter
 	ClusterVulnerabilityReportsGetter
+	ComplianceAttestationsGetter
 	ConfigAuditReportsGetter
 	KubeHunterReportsGetter
+	MisconfigurationReportsGetter
+	SBOMReportsGetter
+	VulnerabilityExceptionsGetter
 	VulnerabilityReportsGetter
 }
 
@@ -47,6 +51,10 @@ func (c *AquasecurityV1alpha1Client) ClusterVulnerabilityReports() ClusterVulner
 	return newClusterVulnerabilityReports(c)
 }
 
+func (c *AquasecurityV1alpha1Client) ComplianceAttestations() ComplianceAttestationInterface {
+	return newComplianceAttestations(c)
+}
+
 func (c *AquasecurityV1alpha1Client) ConfigAuditReports(namespace string) ConfigAuditReportInterface {
 	return newConfigAuditReports(c, namespace)
 }
@@ -55,6 +63,18 @@ func (c *AquasecurityV1alpha1Client) KubeHunterReports() KubeHunterReportInterfa
 	return newKubeHunterReports(c)
 }
 
+func (c *AquasecurityV1alpha1Client) MisconfigurationReports(namespace string) MisconfigurationReportInterface {
+	return newMisconfigurationReports(c, namespace)
+}
+
+func (c *AquasecurityV1alpha1Client) SBOMReports(namespace string) SBOMReportInterface {
+	return newSBOMReports(c, namespace)
+}
+
+func (c *AquasecurityV1alpha1Client) VulnerabilityExceptions(namespace string) VulnerabilityExceptionInterface {
+	return newVulnerabilityExceptions(c, namespace)
+}
+
 func (c *AquasecurityV1alpha1Client) VulnerabilityReports(namespace string) VulnerabilityReportInterface {
 	return newVulnerabilityReports(c, namespace)
 }
@@ -0,0 +1,152 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	scheme "github.com/aquasecurity/starboard/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ComplianceAttestationsGetter has a method to return a ComplianceAttestationInterface.
+// A group's client should implement this interface.
+type ComplianceAttestationsGetter interface {
+	ComplianceAttestations() ComplianceAttestationInterface
+}
+
+// ComplianceAttestationInterface has methods to work with ComplianceAttestation resources.
+type ComplianceAttestationInterface interface {
+	Create(ctx context.Context, complianceAttestation *v1alpha1.ComplianceAttestation, opts v1.CreateOptions) (*v1alpha1.ComplianceAttestation, error)
+	Update(ctx context.Context, complianceAttestation *v1alpha1.ComplianceAttestation, opts v1.UpdateOptions) (*v1alpha1.ComplianceAttestation, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ComplianceAttestation, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ComplianceAttestationList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ComplianceAttestation, err error)
+	ComplianceAttestationExpansion
+}
+
+// complianceAttestations implements ComplianceAttestationInterface
+type complianceAttestations struct {
+	client rest.Interface
+}
+
+// newComplianceAttestations returns a ComplianceAttestations
+func newComplianceAttestations(c *AquasecurityV1alpha1Client) *complianceAttestations {
+	return &complianceAttestations{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the complianceAttestation, and returns the corresponding complianceAttestation object, and an error if there is any.
+func (c *complianceAttestations) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ComplianceAttestation, err error) {
+	result = &v1alpha1.ComplianceAttestation{}
+	err = c.client.Get().
+		Resource("complianceattestations").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ComplianceAttestations that match those selectors.
+func (c *complianceAttestations) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ComplianceAttestationList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ComplianceAttestationList{}
+	err = c.client.Get().
+		Resource("complianceattestations").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested complianceAttestations.
+func (c *complianceAttestations) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("complianceattestations").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a complianceAttestation and creates it.  Returns the server's representation of the complianceAttestation, and an error, if there is any.
+func (c *complianceAttestations) Create(ctx context.Context, complianceAttestation *v1alpha1.ComplianceAttestation, opts v1.CreateOptions) (result *v1alpha1.ComplianceAttestation, err error) {
+	result = &v1alpha1.ComplianceAttestation{}
+	err = c.client.Post().
+		Resource("complianceattestations").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(complianceAttestation).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a complianceAttestation and updates it. Returns the server's representation of the complianceAttestation, and an error, if there is any.
+func (c *complianceAttestations) Update(ctx context.Context, complianceAttestation *v1alpha1.ComplianceAttestation, opts v1.UpdateOptions) (result *v1alpha1.ComplianceAttestation, err error) {
+	result = &v1alpha1.ComplianceAttestation{}
+	err = c.client.Put().
+		Resource("complianceattestations").
+		Name(complianceAttestation.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(complianceAttestation).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the complianceAttestation and deletes it. Returns an error if one occurs.
+func (c *complianceAttestations) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("complianceattestations").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *complianceAttestations) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("complianceattestations").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched complianceAttestation.
+func (c *complianceAttestations) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ComplianceAttestation, err error) {
+	result = &v1alpha1.ComplianceAttestation{}
+	err = c.client.Patch(pt).
+		Resource("complianceattestations").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
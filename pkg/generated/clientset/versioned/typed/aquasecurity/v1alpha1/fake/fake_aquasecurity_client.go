@@ -32,6 +32,10 @@ func (c *FakeAquasecurityV1alpha1) ClusterVulnerabilityReports() v1alpha1.Cluste
 	return &FakeClusterVulnerabilityReports{c}
 }
 
+func (c *FakeAquasecurityV1alpha1) ComplianceAttestations() v1alpha1.ComplianceAttestationInterface {
+	return &FakeComplianceAttestations{c}
+}
+
 func (c *FakeAquasecurityV1alpha1) ConfigAuditReports(namespace string) v1alpha1.ConfigAuditReportInterface {
 	return &FakeConfigAuditReports{c, namespace}
 }
@@ -40,6 +44,18 @@ func (c *FakeAquasecurityV1alpha1) KubeHunterReports() v1alpha1.KubeHunterReport
 	return &FakeKubeHunterReports{c}
 }
 
+func (c *FakeAquasecurityV1alpha1) MisconfigurationReports(namespace string) v1alpha1.MisconfigurationReportInterface {
+	return &FakeMisconfigurationReports{c, namespace}
+}
+
+func (c *FakeAquasecurityV1alpha1) SBOMReports(namespace string) v1alpha1.SBOMReportInterface {
+	return &FakeSBOMReports{c, namespace}
+}
+
+func (c *FakeAquasecurityV1alpha1) VulnerabilityExceptions(namespace string) v1alpha1.VulnerabilityExceptionInterface {
+	return &FakeVulnerabilityExceptions{c, namespace}
+}
+
 func (c *FakeAquasecurityV1alpha1) VulnerabilityReports(namespace string) v1alpha1.VulnerabilityReportInterface {
 	return &FakeVulnerabilityReports{c, namespace}
 }
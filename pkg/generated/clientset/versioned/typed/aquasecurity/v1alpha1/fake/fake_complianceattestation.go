@@ -0,0 +1,106 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeComplianceAttestations implements ComplianceAttestationInterface
+type FakeComplianceAttestations struct {
+	Fake *FakeAquasecurityV1alpha1
+}
+
+var complianceattestationsResource = schema.GroupVersionResource{Group: "aquasecurity.github.io", Version: "v1alpha1", Resource: "complianceattestations"}
+
+var complianceattestationsKind = schema.GroupVersionKind{Group: "aquasecurity.github.io", Version: "v1alpha1", Kind: "ComplianceAttestation"}
+
+// Get takes name of the complianceAttestation, and returns the corresponding complianceAttestation object, and an error if there is any.
+func (c *FakeComplianceAttestations) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ComplianceAttestation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(complianceattestationsResource, name), &v1alpha1.ComplianceAttestation{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ComplianceAttestation), err
+}
+
+// List takes label and field selectors, and returns the list of ComplianceAttestations that match those selectors.
+func (c *FakeComplianceAttestations) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ComplianceAttestationList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(complianceattestationsResource, complianceattestationsKind, opts), &v1alpha1.ComplianceAttestationList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ComplianceAttestationList{ListMeta: obj.(*v1alpha1.ComplianceAttestationList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ComplianceAttestationList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested complianceAttestations.
+func (c *FakeComplianceAttestations) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(complianceattestationsResource, opts))
+}
+
+// Create takes the representation of a complianceAttestation and creates it.  Returns the server's representation of the complianceAttestation, and an error, if there is any.
+func (c *FakeComplianceAttestations) Create(ctx context.Context, complianceAttestation *v1alpha1.ComplianceAttestation, opts v1.CreateOptions) (result *v1alpha1.ComplianceAttestation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(complianceattestationsResource, complianceAttestation), &v1alpha1.ComplianceAttestation{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ComplianceAttestation), err
+}
+
+// Update takes the representation of a complianceAttestation and updates it. Returns the server's representation of the complianceAttestation, and an error, if there is any.
+func (c *FakeComplianceAttestations) Update(ctx context.Context, complianceAttestation *v1alpha1.ComplianceAttestation, opts v1.UpdateOptions) (result *v1alpha1.ComplianceAttestation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(complianceattestationsResource, complianceAttestation), &v1alpha1.ComplianceAttestation{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ComplianceAttestation), err
+}
+
+// Delete takes name of the complianceAttestation and deletes it. Returns an error if one occurs.
+func (c *FakeComplianceAttestations) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteActionWithOptions(complianceattestationsResource, name, opts), &v1alpha1.ComplianceAttestation{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeComplianceAttestations) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(complianceattestationsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ComplianceAttestationList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched complianceAttestation.
+func (c *FakeComplianceAttestations) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ComplianceAttestation, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(complianceattestationsResource, name, pt, data, subresources...), &v1alpha1.ComplianceAttestation{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ComplianceAttestation), err
+}
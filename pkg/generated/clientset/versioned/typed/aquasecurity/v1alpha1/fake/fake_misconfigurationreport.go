@@ -0,0 +1,114 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeMisconfigurationReports implements MisconfigurationReportInterface
+type FakeMisconfigurationReports struct {
+	Fake *FakeAquasecurityV1alpha1
+	ns   string
+}
+
+var misconfigurationreportsResource = schema.GroupVersionResource{Group: "aquasecurity.github.io", Version: "v1alpha1", Resource: "misconfigurationreports"}
+
+var misconfigurationreportsKind = schema.GroupVersionKind{Group: "aquasecurity.github.io", Version: "v1alpha1", Kind: "MisconfigurationReport"}
+
+// Get takes name of the misconfigurationReport, and returns the corresponding misconfigurationReport object, and an error if there is any.
+func (c *FakeMisconfigurationReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.MisconfigurationReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(misconfigurationreportsResource, c.ns, name), &v1alpha1.MisconfigurationReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.MisconfigurationReport), err
+}
+
+// List takes label and field selectors, and returns the list of MisconfigurationReports that match those selectors.
+func (c *FakeMisconfigurationReports) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.MisconfigurationReportList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(misconfigurationreportsResource, misconfigurationreportsKind, c.ns, opts), &v1alpha1.MisconfigurationReportList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.MisconfigurationReportList{ListMeta: obj.(*v1alpha1.MisconfigurationReportList).ListMeta}
+	for _, item := range obj.(*v1alpha1.MisconfigurationReportList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested misconfigurationReports.
+func (c *FakeMisconfigurationReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(misconfigurationreportsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a misconfigurationReport and creates it.  Returns the server's representation of the misconfigurationReport, and an error, if there is any.
+func (c *FakeMisconfigurationReports) Create(ctx context.Context, misconfigurationReport *v1alpha1.MisconfigurationReport, opts v1.CreateOptions) (result *v1alpha1.MisconfigurationReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(misconfigurationreportsResource, c.ns, misconfigurationReport), &v1alpha1.MisconfigurationReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.MisconfigurationReport), err
+}
+
+// Update takes the representation of a misconfigurationReport and updates it. Returns the server's representation of the misconfigurationReport, and an error, if there is any.
+func (c *FakeMisconfigurationReports) Update(ctx context.Context, misconfigurationReport *v1alpha1.MisconfigurationReport, opts v1.UpdateOptions) (result *v1alpha1.MisconfigurationReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(misconfigurationreportsResource, c.ns, misconfigurationReport), &v1alpha1.MisconfigurationReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.MisconfigurationReport), err
+}
+
+// Delete takes name of the misconfigurationReport and deletes it. Returns an error if one occurs.
+func (c *FakeMisconfigurationReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(misconfigurationreportsResource, c.ns, name, opts), &v1alpha1.MisconfigurationReport{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeMisconfigurationReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(misconfigurationreportsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.MisconfigurationReportList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched misconfigurationReport.
+func (c *FakeMisconfigurationReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.MisconfigurationReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(misconfigurationreportsResource, c.ns, name, pt, data, subresources...), &v1alpha1.MisconfigurationReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.MisconfigurationReport), err
+}
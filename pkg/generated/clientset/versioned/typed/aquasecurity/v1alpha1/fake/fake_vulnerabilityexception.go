@@ -0,0 +1,126 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeVulnerabilityExceptions implements VulnerabilityExceptionInterface
+type FakeVulnerabilityExceptions struct {
+	Fake *FakeAquasecurityV1alpha1
+	ns   string
+}
+
+var vulnerabilityexceptionsResource = schema.GroupVersionResource{Group: "aquasecurity.github.io", Version: "v1alpha1", Resource: "vulnerabilityexceptions"}
+
+var vulnerabilityexceptionsKind = schema.GroupVersionKind{Group: "aquasecurity.github.io", Version: "v1alpha1", Kind: "VulnerabilityException"}
+
+// Get takes name of the vulnerabilityException, and returns the corresponding vulnerabilityException object, and an error if there is any.
+func (c *FakeVulnerabilityExceptions) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.VulnerabilityException, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(vulnerabilityexceptionsResource, c.ns, name), &v1alpha1.VulnerabilityException{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VulnerabilityException), err
+}
+
+// List takes label and field selectors, and returns the list of VulnerabilityExceptions that match those selectors.
+func (c *FakeVulnerabilityExceptions) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.VulnerabilityExceptionList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(vulnerabilityexceptionsResource, vulnerabilityexceptionsKind, c.ns, opts), &v1alpha1.VulnerabilityExceptionList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.VulnerabilityExceptionList{ListMeta: obj.(*v1alpha1.VulnerabilityExceptionList).ListMeta}
+	for _, item := range obj.(*v1alpha1.VulnerabilityExceptionList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested vulnerabilityExceptions.
+func (c *FakeVulnerabilityExceptions) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(vulnerabilityexceptionsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a vulnerabilityException and creates it.  Returns the server's representation of the vulnerabilityException, and an error, if there is any.
+func (c *FakeVulnerabilityExceptions) Create(ctx context.Context, vulnerabilityException *v1alpha1.VulnerabilityException, opts v1.CreateOptions) (result *v1alpha1.VulnerabilityException, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(vulnerabilityexceptionsResource, c.ns, vulnerabilityException), &v1alpha1.VulnerabilityException{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VulnerabilityException), err
+}
+
+// Update takes the representation of a vulnerabilityException and updates it. Returns the server's representation of the vulnerabilityException, and an error, if there is any.
+func (c *FakeVulnerabilityExceptions) Update(ctx context.Context, vulnerabilityException *v1alpha1.VulnerabilityException, opts v1.UpdateOptions) (result *v1alpha1.VulnerabilityException, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(vulnerabilityexceptionsResource, c.ns, vulnerabilityException), &v1alpha1.VulnerabilityException{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VulnerabilityException), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeVulnerabilityExceptions) UpdateStatus(ctx context.Context, vulnerabilityException *v1alpha1.VulnerabilityException, opts v1.UpdateOptions) (*v1alpha1.VulnerabilityException, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(vulnerabilityexceptionsResource, "status", c.ns, vulnerabilityException), &v1alpha1.VulnerabilityException{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VulnerabilityException), err
+}
+
+// Delete takes name of the vulnerabilityException and deletes it. Returns an error if one occurs.
+func (c *FakeVulnerabilityExceptions) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(vulnerabilityexceptionsResource, c.ns, name, opts), &v1alpha1.VulnerabilityException{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeVulnerabilityExceptions) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(vulnerabilityexceptionsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.VulnerabilityExceptionList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched vulnerabilityException.
+func (c *FakeVulnerabilityExceptions) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VulnerabilityException, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(vulnerabilityexceptionsResource, c.ns, name, pt, data, subresources...), &v1alpha1.VulnerabilityException{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.VulnerabilityException), err
+}
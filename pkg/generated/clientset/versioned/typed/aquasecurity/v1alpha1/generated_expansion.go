@@ -12,8 +12,16 @@ type ClusterConfigAuditReportExpansion interface{}
 
 type ClusterVulnerabilityReportExpansion interface{}
 
+type ComplianceAttestationExpansion interface{}
+
 type ConfigAuditReportExpansion interface{}
 
 type KubeHunterReportExpansion interface{}
 
+type MisconfigurationReportExpansion interface{}
+
+type SBOMReportExpansion interface{}
+
+type VulnerabilityExceptionExpansion interface{}
+
 type VulnerabilityReportExpansion interface{}
@@ -0,0 +1,162 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	scheme "github.com/aquasecurity/starboard/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// MisconfigurationReportsGetter has a method to return a MisconfigurationReportInterface.
+// A group's client should implement this interface.
+type MisconfigurationReportsGetter interface {
+	MisconfigurationReports(namespace string) MisconfigurationReportInterface
+}
+
+// MisconfigurationReportInterface has methods to work with MisconfigurationReport resources.
+type MisconfigurationReportInterface interface {
+	Create(ctx context.Context, misconfigurationReport *v1alpha1.MisconfigurationReport, opts v1.CreateOptions) (*v1alpha1.MisconfigurationReport, error)
+	Update(ctx context.Context, misconfigurationReport *v1alpha1.MisconfigurationReport, opts v1.UpdateOptions) (*v1alpha1.MisconfigurationReport, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.MisconfigurationReport, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.MisconfigurationReportList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.MisconfigurationReport, err error)
+	MisconfigurationReportExpansion
+}
+
+// misconfigurationReports implements MisconfigurationReportInterface
+type misconfigurationReports struct {
+	client rest.Interface
+	ns     string
+}
+
+// newMisconfigurationReports returns a MisconfigurationReports
+func newMisconfigurationReports(c *AquasecurityV1alpha1Client, namespace string) *misconfigurationReports {
+	return &misconfigurationReports{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the misconfigurationReport, and returns the corresponding misconfigurationReport object, and an error if there is any.
+func (c *misconfigurationReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.MisconfigurationReport, err error) {
+	result = &v1alpha1.MisconfigurationReport{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("misconfigurationreports").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of MisconfigurationReports that match those selectors.
+func (c *misconfigurationReports) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.MisconfigurationReportList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.MisconfigurationReportList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("misconfigurationreports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested misconfigurationReports.
+func (c *misconfigurationReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("misconfigurationreports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a misconfigurationReport and creates it.  Returns the server's representation of the misconfigurationReport, and an error, if there is any.
+func (c *misconfigurationReports) Create(ctx context.Context, misconfigurationReport *v1alpha1.MisconfigurationReport, opts v1.CreateOptions) (result *v1alpha1.MisconfigurationReport, err error) {
+	result = &v1alpha1.MisconfigurationReport{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("misconfigurationreports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(misconfigurationReport).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a misconfigurationReport and updates it. Returns the server's representation of the misconfigurationReport, and an error, if there is any.
+func (c *misconfigurationReports) Update(ctx context.Context, misconfigurationReport *v1alpha1.MisconfigurationReport, opts v1.UpdateOptions) (result *v1alpha1.MisconfigurationReport, err error) {
+	result = &v1alpha1.MisconfigurationReport{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("misconfigurationreports").
+		Name(misconfigurationReport.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(misconfigurationReport).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the misconfigurationReport and deletes it. Returns an error if one occurs.
+func (c *misconfigurationReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("misconfigurationreports").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *misconfigurationReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("misconfigurationreports").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched misconfigurationReport.
+func (c *misconfigurationReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.MisconfigurationReport, err error) {
+	result = &v1alpha1.MisconfigurationReport{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("misconfigurationreports").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,162 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	scheme "github.com/aquasecurity/starboard/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// SBOMReportsGetter has a method to return a SBOMReportInterface.
+// A group's client should implement this interface.
+type SBOMReportsGetter interface {
+	SBOMReports(namespace string) SBOMReportInterface
+}
+
+// SBOMReportInterface has methods to work with SBOMReport resources.
+type SBOMReportInterface interface {
+	Create(ctx context.Context, sBOMReport *v1alpha1.SBOMReport, opts v1.CreateOptions) (*v1alpha1.SBOMReport, error)
+	Update(ctx context.Context, sBOMReport *v1alpha1.SBOMReport, opts v1.UpdateOptions) (*v1alpha1.SBOMReport, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.SBOMReport, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.SBOMReportList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.SBOMReport, err error)
+	SBOMReportExpansion
+}
+
+// sBOMReports implements SBOMReportInterface
+type sBOMReports struct {
+	client rest.Interface
+	ns     string
+}
+
+// newSBOMReports returns a SBOMReports
+func newSBOMReports(c *AquasecurityV1alpha1Client, namespace string) *sBOMReports {
+	return &sBOMReports{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the sBOMReport, and returns the corresponding sBOMReport object, and an error if there is any.
+func (c *sBOMReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.SBOMReport, err error) {
+	result = &v1alpha1.SBOMReport{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("sbomreports").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of SBOMReports that match those selectors.
+func (c *sBOMReports) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.SBOMReportList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.SBOMReportList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("sbomreports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested sBOMReports.
+func (c *sBOMReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("sbomreports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a sBOMReport and creates it.  Returns the server's representation of the sBOMReport, and an error, if there is any.
+func (c *sBOMReports) Create(ctx context.Context, sBOMReport *v1alpha1.SBOMReport, opts v1.CreateOptions) (result *v1alpha1.SBOMReport, err error) {
+	result = &v1alpha1.SBOMReport{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("sbomreports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(sBOMReport).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a sBOMReport and updates it. Returns the server's representation of the sBOMReport, and an error, if there is any.
+func (c *sBOMReports) Update(ctx context.Context, sBOMReport *v1alpha1.SBOMReport, opts v1.UpdateOptions) (result *v1alpha1.SBOMReport, err error) {
+	result = &v1alpha1.SBOMReport{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("sbomreports").
+		Name(sBOMReport.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(sBOMReport).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the sBOMReport and deletes it. Returns an error if one occurs.
+func (c *sBOMReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("sbomreports").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *sBOMReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("sbomreports").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched sBOMReport.
+func (c *sBOMReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.SBOMReport, err error) {
+	result = &v1alpha1.SBOMReport{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("sbomreports").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
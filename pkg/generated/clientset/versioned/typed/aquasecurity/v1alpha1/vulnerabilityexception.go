@@ -0,0 +1,179 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	scheme "github.com/aquasecurity/starboard/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// VulnerabilityExceptionsGetter has a method to return a VulnerabilityExceptionInterface.
+// A group's client should implement this interface.
+type VulnerabilityExceptionsGetter interface {
+	VulnerabilityExceptions(namespace string) VulnerabilityExceptionInterface
+}
+
+// VulnerabilityExceptionInterface has methods to work with VulnerabilityException resources.
+type VulnerabilityExceptionInterface interface {
+	Create(ctx context.Context, vulnerabilityException *v1alpha1.VulnerabilityException, opts v1.CreateOptions) (*v1alpha1.VulnerabilityException, error)
+	Update(ctx context.Context, vulnerabilityException *v1alpha1.VulnerabilityException, opts v1.UpdateOptions) (*v1alpha1.VulnerabilityException, error)
+	UpdateStatus(ctx context.Context, vulnerabilityException *v1alpha1.VulnerabilityException, opts v1.UpdateOptions) (*v1alpha1.VulnerabilityException, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.VulnerabilityException, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.VulnerabilityExceptionList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VulnerabilityException, err error)
+	VulnerabilityExceptionExpansion
+}
+
+// vulnerabilityExceptions implements VulnerabilityExceptionInterface
+type vulnerabilityExceptions struct {
+	client rest.Interface
+	ns     string
+}
+
+// newVulnerabilityExceptions returns a VulnerabilityExceptions
+func newVulnerabilityExceptions(c *AquasecurityV1alpha1Client, namespace string) *vulnerabilityExceptions {
+	return &vulnerabilityExceptions{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the vulnerabilityException, and returns the corresponding vulnerabilityException object, and an error if there is any.
+func (c *vulnerabilityExceptions) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.VulnerabilityException, err error) {
+	result = &v1alpha1.VulnerabilityException{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("vulnerabilityexceptions").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of VulnerabilityExceptions that match those selectors.
+func (c *vulnerabilityExceptions) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.VulnerabilityExceptionList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.VulnerabilityExceptionList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("vulnerabilityexceptions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested vulnerabilityExceptions.
+func (c *vulnerabilityExceptions) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("vulnerabilityexceptions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a vulnerabilityException and creates it.  Returns the server's representation of the vulnerabilityException, and an error, if there is any.
+func (c *vulnerabilityExceptions) Create(ctx context.Context, vulnerabilityException *v1alpha1.VulnerabilityException, opts v1.CreateOptions) (result *v1alpha1.VulnerabilityException, err error) {
+	result = &v1alpha1.VulnerabilityException{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("vulnerabilityexceptions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(vulnerabilityException).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a vulnerabilityException and updates it. Returns the server's representation of the vulnerabilityException, and an error, if there is any.
+func (c *vulnerabilityExceptions) Update(ctx context.Context, vulnerabilityException *v1alpha1.VulnerabilityException, opts v1.UpdateOptions) (result *v1alpha1.VulnerabilityException, err error) {
+	result = &v1alpha1.VulnerabilityException{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("vulnerabilityexceptions").
+		Name(vulnerabilityException.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(vulnerabilityException).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *vulnerabilityExceptions) UpdateStatus(ctx context.Context, vulnerabilityException *v1alpha1.VulnerabilityException, opts v1.UpdateOptions) (result *v1alpha1.VulnerabilityException, err error) {
+	result = &v1alpha1.VulnerabilityException{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("vulnerabilityexceptions").
+		Name(vulnerabilityException.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(vulnerabilityException).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the vulnerabilityException and deletes it. Returns an error if one occurs.
+func (c *vulnerabilityExceptions) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("vulnerabilityexceptions").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *vulnerabilityExceptions) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("vulnerabilityexceptions").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched vulnerabilityException.
+func (c *vulnerabilityExceptions) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.VulnerabilityException, err error) {
+	result = &v1alpha1.VulnerabilityException{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("vulnerabilityexceptions").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -18,10 +18,18 @@ type Interface interface {
 	ClusterConfigAuditReports() ClusterConfigAuditReportInformer
 	// ClusterVulnerabilityReports returns a ClusterVulnerabilityReportInformer.
 	ClusterVulnerabilityReports() ClusterVulnerabilityReportInformer
+	// ComplianceAttestations returns a ComplianceAttestationInformer.
+	ComplianceAttestations() ComplianceAttestationInformer
 	// ConfigAuditReports returns a ConfigAuditReportInformer.
 	ConfigAuditReports() ConfigAuditReportInformer
 	// KubeHunterReports returns a KubeHunterReportInformer.
 	KubeHunterReports() KubeHunterReportInformer
+	// MisconfigurationReports returns a MisconfigurationReportInformer.
+	MisconfigurationReports() MisconfigurationReportInformer
+	// SBOMReports returns a SBOMReportInformer.
+	SBOMReports() SBOMReportInformer
+	// VulnerabilityExceptions returns a VulnerabilityExceptionInformer.
+	VulnerabilityExceptions() VulnerabilityExceptionInformer
 	// VulnerabilityReports returns a VulnerabilityReportInformer.
 	VulnerabilityReports() VulnerabilityReportInformer
 }
@@ -62,6 +70,11 @@ func (v *version) ClusterVulnerabilityReports() ClusterVulnerabilityReportInform
 	return &clusterVulnerabilityReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ComplianceAttestations returns a ComplianceAttestationInformer.
+func (v *version) ComplianceAttestations() ComplianceAttestationInformer {
+	return &complianceAttestationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ConfigAuditReports returns a ConfigAuditReportInformer.
 func (v *version) ConfigAuditReports() ConfigAuditReportInformer {
 	return &configAuditReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -72,6 +85,21 @@ func (v *version) KubeHunterReports() KubeHunterReportInformer {
 	return &kubeHunterReportInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// MisconfigurationReports returns a MisconfigurationReportInformer.
+func (v *version) MisconfigurationReports() MisconfigurationReportInformer {
+	return &misconfigurationReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// SBOMReports returns a SBOMReportInformer.
+func (v *version) SBOMReports() SBOMReportInformer {
+	return &sBOMReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VulnerabilityExceptions returns a VulnerabilityExceptionInformer.
+func (v *version) VulnerabilityExceptions() VulnerabilityExceptionInformer {
+	return &vulnerabilityExceptionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VulnerabilityReports returns a VulnerabilityReportInformer.
 func (v *version) VulnerabilityReports() VulnerabilityReportInformer {
 	return &vulnerabilityReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -0,0 +1,52 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ComplianceAttestationLister helps list ComplianceAttestations.
+// All objects returned here must be treated as read-only.
+type ComplianceAttestationLister interface {
+	// List lists all ComplianceAttestations in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.ComplianceAttestation, err error)
+	// Get retrieves the ComplianceAttestation from the index for a given name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.ComplianceAttestation, error)
+	ComplianceAttestationListerExpansion
+}
+
+// complianceAttestationLister implements the ComplianceAttestationLister interface.
+type complianceAttestationLister struct {
+	indexer cache.Indexer
+}
+
+// NewComplianceAttestationLister returns a new ComplianceAttestationLister.
+func NewComplianceAttestationLister(indexer cache.Indexer) ComplianceAttestationLister {
+	return &complianceAttestationLister{indexer: indexer}
+}
+
+// List lists all ComplianceAttestations in the indexer.
+func (s *complianceAttestationLister) List(selector labels.Selector) (ret []*v1alpha1.ComplianceAttestation, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.ComplianceAttestation))
+	})
+	return ret, err
+}
+
+// Get retrieves the ComplianceAttestation from the index for a given name.
+func (s *complianceAttestationLister) Get(name string) (*v1alpha1.ComplianceAttestation, error) {
+	obj, exists, err := s.indexer.GetByKey(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("complianceattestation"), name)
+	}
+	return obj.(*v1alpha1.ComplianceAttestation), nil
+}
@@ -30,6 +30,10 @@ type ClusterConfigAuditReportListerExpansion interface{}
 // ClusterVulnerabilityReportLister.
 type ClusterVulnerabilityReportListerExpansion interface{}
 
+// ComplianceAttestationListerExpansion allows custom methods to be added to
+// ComplianceAttestationLister.
+type ComplianceAttestationListerExpansion interface{}
+
 // ConfigAuditReportListerExpansion allows custom methods to be added to
 // ConfigAuditReportLister.
 type ConfigAuditReportListerExpansion interface{}
@@ -42,6 +46,30 @@ type ConfigAuditReportNamespaceListerExpansion interface{}
 // KubeHunterReportLister.
 type KubeHunterReportListerExpansion interface{}
 
+// MisconfigurationReportListerExpansion allows custom methods to be added to
+// MisconfigurationReportLister.
+type MisconfigurationReportListerExpansion interface{}
+
+// MisconfigurationReportNamespaceListerExpansion allows custom methods to be added to
+// MisconfigurationReportNamespaceLister.
+type MisconfigurationReportNamespaceListerExpansion interface{}
+
+// SBOMReportListerExpansion allows custom methods to be added to
+// SBOMReportLister.
+type SBOMReportListerExpansion interface{}
+
+// SBOMReportNamespaceListerExpansion allows custom methods to be added to
+// SBOMReportNamespaceLister.
+type SBOMReportNamespaceListerExpansion interface{}
+
+// VulnerabilityExceptionListerExpansion allows custom methods to be added to
+// VulnerabilityExceptionLister.
+type VulnerabilityExceptionListerExpansion interface{}
+
+// VulnerabilityExceptionNamespaceListerExpansion allows custom methods to be added to
+// VulnerabilityExceptionNamespaceLister.
+type VulnerabilityExceptionNamespaceListerExpansion interface{}
+
 // VulnerabilityReportListerExpansion allows custom methods to be added to
 // VulnerabilityReportLister.
 type VulnerabilityReportListerExpansion interface{}
@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// MisconfigurationReportLister helps list MisconfigurationReports.
+// All objects returned here must be treated as read-only.
+type MisconfigurationReportLister interface {
+	// List lists all MisconfigurationReports in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.MisconfigurationReport, err error)
+	// MisconfigurationReports returns an object that can list and get MisconfigurationReports.
+	MisconfigurationReports(namespace string) MisconfigurationReportNamespaceLister
+	MisconfigurationReportListerExpansion
+}
+
+// misconfigurationReportLister implements the MisconfigurationReportLister interface.
+type misconfigurationReportLister struct {
+	indexer cache.Indexer
+}
+
+// NewMisconfigurationReportLister returns a new MisconfigurationReportLister.
+func NewMisconfigurationReportLister(indexer cache.Indexer) MisconfigurationReportLister {
+	return &misconfigurationReportLister{indexer: indexer}
+}
+
+// List lists all MisconfigurationReports in the indexer.
+func (s *misconfigurationReportLister) List(selector labels.Selector) (ret []*v1alpha1.MisconfigurationReport, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.MisconfigurationReport))
+	})
+	return ret, err
+}
+
+// MisconfigurationReports returns an object that can list and get MisconfigurationReports.
+func (s *misconfigurationReportLister) MisconfigurationReports(namespace string) MisconfigurationReportNamespaceLister {
+	return misconfigurationReportNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// MisconfigurationReportNamespaceLister helps list and get MisconfigurationReports.
+// All objects returned here must be treated as read-only.
+type MisconfigurationReportNamespaceLister interface {
+	// List lists all MisconfigurationReports in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.MisconfigurationReport, err error)
+	// Get retrieves the MisconfigurationReport from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.MisconfigurationReport, error)
+	MisconfigurationReportNamespaceListerExpansion
+}
+
+// misconfigurationReportNamespaceLister implements the MisconfigurationReportNamespaceLister
+// interface.
+type misconfigurationReportNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all MisconfigurationReports in the indexer for a given namespace.
+func (s misconfigurationReportNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.MisconfigurationReport, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.MisconfigurationReport))
+	})
+	return ret, err
+}
+
+// Get retrieves the MisconfigurationReport from the indexer for a given namespace and name.
+func (s misconfigurationReportNamespaceLister) Get(name string) (*v1alpha1.MisconfigurationReport, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("misconfigurationreport"), name)
+	}
+	return obj.(*v1alpha1.MisconfigurationReport), nil
+}
@@ -0,0 +1,83 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// VulnerabilityExceptionLister helps list VulnerabilityExceptions.
+// All objects returned here must be treated as read-only.
+type VulnerabilityExceptionLister interface {
+	// List lists all VulnerabilityExceptions in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.VulnerabilityException, err error)
+	// VulnerabilityExceptions returns an object that can list and get VulnerabilityExceptions.
+	VulnerabilityExceptions(namespace string) VulnerabilityExceptionNamespaceLister
+	VulnerabilityExceptionListerExpansion
+}
+
+// vulnerabilityExceptionLister implements the VulnerabilityExceptionLister interface.
+type vulnerabilityExceptionLister struct {
+	indexer cache.Indexer
+}
+
+// NewVulnerabilityExceptionLister returns a new VulnerabilityExceptionLister.
+func NewVulnerabilityExceptionLister(indexer cache.Indexer) VulnerabilityExceptionLister {
+	return &vulnerabilityExceptionLister{indexer: indexer}
+}
+
+// List lists all VulnerabilityExceptions in the indexer.
+func (s *vulnerabilityExceptionLister) List(selector labels.Selector) (ret []*v1alpha1.VulnerabilityException, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.VulnerabilityException))
+	})
+	return ret, err
+}
+
+// VulnerabilityExceptions returns an object that can list and get VulnerabilityExceptions.
+func (s *vulnerabilityExceptionLister) VulnerabilityExceptions(namespace string) VulnerabilityExceptionNamespaceLister {
+	return vulnerabilityExceptionNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// VulnerabilityExceptionNamespaceLister helps list and get VulnerabilityExceptions.
+// All objects returned here must be treated as read-only.
+type VulnerabilityExceptionNamespaceLister interface {
+	// List lists all VulnerabilityExceptions in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.VulnerabilityException, err error)
+	// Get retrieves the VulnerabilityException from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.VulnerabilityException, error)
+	VulnerabilityExceptionNamespaceListerExpansion
+}
+
+// vulnerabilityExceptionNamespaceLister implements the VulnerabilityExceptionNamespaceLister
+// interface.
+type vulnerabilityExceptionNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all VulnerabilityExceptions in the indexer for a given namespace.
+func (s vulnerabilityExceptionNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.VulnerabilityException, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.VulnerabilityException))
+	})
+	return ret, err
+}
+
+// Get retrieves the VulnerabilityException from the indexer for a given namespace and name.
+func (s vulnerabilityExceptionNamespaceLister) Get(name string) (*v1alpha1.VulnerabilityException, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("vulnerabilityexception"), name)
+	}
+	return obj.(*v1alpha1.VulnerabilityException), nil
+}
@@ -55,12 +55,16 @@ func (r *logsReader) GetTerminatedContainersStatusesByJob(ctx context.Context, j
 }
 
 func (r *logsReader) getPodByJob(ctx context.Context, job *batchv1.Job) (*corev1.Pod, error) {
-	refreshedJob, err := r.clientset.BatchV1().Jobs(job.Namespace).Get(ctx, job.Name, metav1.GetOptions{})
+	return getPodByJob(ctx, r.clientset, job)
+}
+
+func getPodByJob(ctx context.Context, clientset kubernetes.Interface, job *batchv1.Job) (*corev1.Pod, error) {
+	refreshedJob, err := clientset.BatchV1().Jobs(job.Namespace).Get(ctx, job.Name, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
 	selector := fmt.Sprintf("controller-uid=%s", refreshedJob.Spec.Selector.MatchLabels["controller-uid"])
-	podList, err := r.clientset.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+	podList, err := clientset.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: selector})
 	if err != nil {
 		return nil, err
@@ -689,6 +689,31 @@ func (o *ObjectResolver) GetNodeName(ctx context.Context, obj client.Object) (st
 	}
 }
 
+// PodsByWorkload returns pods controlled by the given workload. For a Pod it
+// returns the Pod itself. For workloads that do not select pods directly,
+// e.g. a CronJob, an empty slice is returned.
+func (o *ObjectResolver) PodsByWorkload(ctx context.Context, obj client.Object) ([]corev1.Pod, error) {
+	var selector *metav1.LabelSelector
+	switch w := obj.(type) {
+	case *corev1.Pod:
+		return []corev1.Pod{*w}, nil
+	case *corev1.ReplicationController:
+		selector = &metav1.LabelSelector{MatchLabels: w.Spec.Selector}
+	case *appsv1.ReplicaSet:
+		selector = w.Spec.Selector
+	case *appsv1.StatefulSet:
+		selector = w.Spec.Selector
+	case *appsv1.DaemonSet:
+		selector = w.Spec.Selector
+	case *batchv1.Job:
+		selector = w.Spec.Selector
+	}
+	if selector == nil || len(selector.MatchLabels) == 0 {
+		return nil, nil
+	}
+	return o.GetPodsByLabelSelector(ctx, obj.GetNamespace(), selector.MatchLabels)
+}
+
 func (o *ObjectResolver) IsActiveReplicaSet(ctx context.Context, workloadObj client.Object, controller *metav1.OwnerReference) (bool, error) {
 	if controller != nil && controller.Kind == string(KindDeployment) {
 		deploymentObject := &appsv1.Deployment{}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"hash"
 	"hash/fnv"
+	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/davecgh/go-spew/spew"
@@ -41,6 +42,80 @@ func GetContainerImagesFromJob(job *batchv1.Job) (ContainerImages, error) {
 	return containerImages, nil
 }
 
+// GetContainerImageDigests returns a map of container names to digests of
+// container images from the specified v1.PodSpec. The digest is taken from
+// the image reference if it's pinned by digest, otherwise from the image ID
+// reported in the status of any of the given pods running the same image.
+// Containers with unknown image digests are omitted.
+func GetContainerImageDigests(spec corev1.PodSpec, pods []corev1.Pod) ContainerImages {
+	digests := ContainerImages{}
+	for _, container := range spec.Containers {
+		if digest := digestFromImageRef(container.Image); digest != "" {
+			digests[container.Name] = digest
+			continue
+		}
+		for _, pod := range pods {
+			if digest := getImageDigestFromPod(pod, container); digest != "" {
+				digests[container.Name] = digest
+				break
+			}
+		}
+	}
+	return digests
+}
+
+func getImageDigestFromPod(pod corev1.Pod, container corev1.Container) string {
+	// Make sure the pod runs the same image, e.g. it's not a pod of the
+	// previous revision of a StatefulSet that is being rolled out.
+	var image string
+	for _, c := range pod.Spec.Containers {
+		if c.Name == container.Name {
+			image = c.Image
+		}
+	}
+	if image != container.Image {
+		return ""
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container.Name {
+			return digestFromImageRef(status.ImageID)
+		}
+	}
+	return ""
+}
+
+// digestFromImageRef returns the digest of the given image reference, e.g.
+// sha256:9dd...4f1 for docker-pullable://nginx@sha256:9dd...4f1, or an empty
+// string if the reference is not pinned by digest.
+func digestFromImageRef(ref string) string {
+	index := strings.LastIndex(ref, "@")
+	if index < 0 {
+		return ""
+	}
+	digest := ref[index+1:]
+	if !strings.Contains(digest, ":") {
+		return ""
+	}
+	return digest
+}
+
+// GetContainerImageDigestsFromJob returns a map of container names to digests
+// of container images from the specified v1.Job. The mapping is encoded as
+// JSON value of the AnnotationContainerImageDigests annotation, which is
+// optional.
+func GetContainerImageDigestsFromJob(job *batchv1.Job) (ContainerImages, error) {
+	digests := ContainerImages{}
+	digestsAsJSON, ok := job.Annotations[starboard.AnnotationContainerImageDigests]
+	if !ok {
+		return digests, nil
+	}
+	err := digests.FromJSON(digestsAsJSON)
+	if err != nil {
+		return nil, fmt.Errorf("parsing annotation: %s: %w", starboard.AnnotationContainerImageDigests, err)
+	}
+	return digests, nil
+}
+
 // ComputeHash returns a hash value calculated from a given object.
 // The hash will be safe encoded to avoid bad words.
 func ComputeHash(obj interface{}) string {
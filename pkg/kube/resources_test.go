@@ -65,6 +65,82 @@ func TestGetContainerImagesFromJob(t *testing.T) {
 	})
 }
 
+func TestGetContainerImageDigests(t *testing.T) {
+	spec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name:  "nginx",
+				Image: "nginx:1.16",
+			},
+			{
+				Name:  "pinned",
+				Image: "busybox@sha256:b5cfd4befc119a590ca1a81d6bb0fa1fb19f1fbebd0397f25fae164abe1e8a6a",
+			},
+			{
+				Name:  "sidecar",
+				Image: "sidecar:1.32.7",
+			},
+		},
+	}
+	pods := []corev1.Pod{
+		{
+			// Pod of the previous revision running a different image.
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "nginx", Image: "nginx:1.15"},
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "nginx", ImageID: "docker-pullable://nginx@sha256:0000000000000000000000000000000000000000000000000000000000000000"},
+				},
+			},
+		},
+		{
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "nginx", Image: "nginx:1.16"},
+					{Name: "sidecar", Image: "sidecar:1.32.7"},
+				},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "nginx", ImageID: "docker-pullable://nginx@sha256:d20aa6d1cae56fd17cd458f4807e0de462caf2336f0b70b5eeb69fcaaf30dd9c"},
+					{Name: "sidecar", ImageID: ""},
+				},
+			},
+		},
+	}
+	digests := kube.GetContainerImageDigests(spec, pods)
+	assert.Equal(t, kube.ContainerImages{
+		"nginx":  "sha256:d20aa6d1cae56fd17cd458f4807e0de462caf2336f0b70b5eeb69fcaaf30dd9c",
+		"pinned": "sha256:b5cfd4befc119a590ca1a81d6bb0fa1fb19f1fbebd0397f25fae164abe1e8a6a",
+	}, digests)
+}
+
+func TestGetContainerImageDigestsFromJob(t *testing.T) {
+
+	t.Run("Should return empty ContainerImages when annotation is not set", func(t *testing.T) {
+		digests, err := kube.GetContainerImageDigestsFromJob(&batchv1.Job{})
+		require.NoError(t, err)
+		assert.Empty(t, digests)
+	})
+
+	t.Run("Should return ContainerImages when annotation is set", func(t *testing.T) {
+		digests, err := kube.GetContainerImageDigestsFromJob(&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"starboard.container-image-digests": `{"nginx":"sha256:d20aa6d1"}`,
+				},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, kube.ContainerImages{
+			"nginx": "sha256:d20aa6d1",
+		}, digests)
+	})
+}
+
 func TestComputeHash(t *testing.T) {
 
 	booleanValue1 := true
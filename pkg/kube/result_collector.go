@@ -0,0 +1,216 @@
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// ResultCollectorContainerName is the name of the sidecar container that
+	// serves the result file written by a scanner container.
+	ResultCollectorContainerName = "result-collector"
+	// ResultCollectorPort is the port the result collector sidecar listens on.
+	ResultCollectorPort = 8080
+
+	resultCollectorReadyPath   = "/ready"
+	resultCollectorResultPath  = "/result"
+	resultCollectorReleasePath = "/release"
+)
+
+var defaultResultPollInterval = 2 * time.Second
+
+// ErrResultNotProduced is returned by the ResultCollector when the scanner
+// container terminates without producing the result file.
+var ErrResultNotProduced = errors.New("scanner terminated without producing result")
+
+// ResultCollector retrieves results of scan jobs through the result collector
+// sidecar running next to the scanner container. Unlike container logs, the
+// result file is neither truncated nor rotated by the kubelet.
+//
+// GetResultByJob blocks until the result of the given job is ready and then
+// streams it.
+//
+// ReleaseByJob tells the sidecar to delete the result file, which lets the
+// scanner container and the sidecar exit so that the job completes.
+type ResultCollector interface {
+	GetResultByJob(ctx context.Context, job *batchv1.Job) (io.ReadCloser, error)
+	ReleaseByJob(ctx context.Context, job *batchv1.Job) error
+}
+
+type resultCollector struct {
+	clientset    kubernetes.Interface
+	pollInterval time.Duration
+}
+
+// NewResultCollector constructs a new ResultCollector, which reaches the
+// result collector sidecar through the API server pod proxy.
+func NewResultCollector(clientset kubernetes.Interface) ResultCollector {
+	return &resultCollector{
+		clientset:    clientset,
+		pollInterval: defaultResultPollInterval,
+	}
+}
+
+func (c *resultCollector) GetResultByJob(ctx context.Context, job *batchv1.Job) (io.ReadCloser, error) {
+	var pod *corev1.Pod
+	err := wait.PollImmediateUntil(c.pollInterval, func() (bool, error) {
+		var err error
+		pod, err = getPodByJob(ctx, c.clientset, job)
+		if err != nil {
+			if k8sapierror.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if pod == nil || pod.Status.Phase == corev1.PodPending {
+			return false, nil
+		}
+		if c.isReady(ctx, pod) {
+			return true, nil
+		}
+		if scannerTerminated(pod) {
+			return false, ErrResultNotProduced
+		}
+		return false, nil
+	}, ctx.Done())
+	if err != nil {
+		return nil, fmt.Errorf("waiting for result of job %q: %w", job.Namespace+"/"+job.Name, err)
+	}
+	klog.V(3).Infof("Collecting result from pod %q", pod.Namespace+"/"+pod.Name)
+	return c.clientset.CoreV1().Pods(pod.Namespace).
+		ProxyGet("http", pod.Name, strconv.Itoa(ResultCollectorPort), resultCollectorResultPath, nil).
+		Stream(ctx)
+}
+
+func (c *resultCollector) ReleaseByJob(ctx context.Context, job *batchv1.Job) error {
+	pod, err := getPodByJob(ctx, c.clientset, job)
+	if err != nil {
+		return fmt.Errorf("getting pod controlled by job: %q: %w", job.Namespace+"/"+job.Name, err)
+	}
+	if pod == nil {
+		return fmt.Errorf("getting pod controlled by job: %q: %w", job.Namespace+"/"+job.Name, podControlledByJobNotFoundErr)
+	}
+	klog.V(3).Infof("Releasing pod %q", pod.Namespace+"/"+pod.Name)
+	return c.clientset.CoreV1().RESTClient().Post().
+		Namespace(pod.Namespace).
+		Resource("pods").
+		Name(fmt.Sprintf("%s:%d", pod.Name, ResultCollectorPort)).
+		SubResource("proxy").
+		Suffix(resultCollectorReleasePath).
+		Do(ctx).
+		Error()
+}
+
+func (c *resultCollector) isReady(ctx context.Context, pod *corev1.Pod) bool {
+	_, err := c.clientset.CoreV1().Pods(pod.Namespace).
+		ProxyGet("http", pod.Name, strconv.Itoa(ResultCollectorPort), resultCollectorReadyPath, nil).
+		DoRaw(ctx)
+	return err == nil
+}
+
+// scannerTerminated returns true if any container other than the result
+// collector sidecar has terminated.
+func scannerTerminated(pod *corev1.Pod) bool {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == ResultCollectorContainerName {
+			continue
+		}
+		if status.State.Terminated != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ResultServer is the HTTP handler run by the result collector sidecar. It
+// serves the result file once the scanner container has created the ready
+// file next to it, and deletes both files when released.
+type ResultServer struct {
+	resultPath string
+	readyPath  string
+	released   chan struct{}
+	once       sync.Once
+}
+
+// NewResultServer constructs a new ResultServer for the given result and
+// ready files.
+func NewResultServer(resultPath, readyPath string) *ResultServer {
+	return &ResultServer{
+		resultPath: resultPath,
+		readyPath:  readyPath,
+		released:   make(chan struct{}),
+	}
+}
+
+// Released returns a channel that is closed when the result has been released.
+func (s *ResultServer) Released() <-chan struct{} {
+	return s.released
+}
+
+func (s *ResultServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == resultCollectorReadyPath && r.Method == http.MethodGet:
+		if !s.ready() {
+			http.Error(w, "result not ready", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case r.URL.Path == resultCollectorResultPath && r.Method == http.MethodGet:
+		s.serveResult(w)
+	case r.URL.Path == resultCollectorReleasePath && r.Method == http.MethodPost:
+		s.release(w)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *ResultServer) ready() bool {
+	_, err := os.Stat(s.readyPath)
+	return err == nil
+}
+
+func (s *ResultServer) serveResult(w http.ResponseWriter) {
+	if !s.ready() {
+		http.Error(w, "result not ready", http.StatusServiceUnavailable)
+		return
+	}
+	file, err := os.Open(s.resultPath)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := io.Copy(w, file); err != nil {
+		klog.Errorf("Streaming result file %s: %v", s.resultPath, err)
+	}
+}
+
+func (s *ResultServer) release(w http.ResponseWriter) {
+	for _, path := range []string{s.resultPath, s.readyPath} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+	s.once.Do(func() {
+		close(s.released)
+	})
+}
@@ -0,0 +1,57 @@
+package kube_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/aquasecurity/starboard/pkg/kube"
+)
+
+func TestResultServer(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	dir := t.TempDir()
+	resultPath := filepath.Join(dir, "trivy.json")
+	readyPath := filepath.Join(dir, "done.txt")
+	server := kube.NewResultServer(resultPath, readyPath)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+		return recorder
+	}
+
+	t.Run("should not serve result before it is ready", func(t *testing.T) {
+		g.Expect(os.WriteFile(resultPath, []byte(`{"Vulnerabilities":`), 0600)).To(Succeed())
+
+		g.Expect(serve(http.MethodGet, "/ready").Code).To(Equal(http.StatusServiceUnavailable))
+		g.Expect(serve(http.MethodGet, "/result").Code).To(Equal(http.StatusServiceUnavailable))
+	})
+
+	t.Run("should serve result once it is ready", func(t *testing.T) {
+		g.Expect(os.WriteFile(resultPath, []byte(`{"Vulnerabilities":[]}`), 0600)).To(Succeed())
+		g.Expect(os.WriteFile(readyPath, nil, 0600)).To(Succeed())
+
+		g.Expect(serve(http.MethodGet, "/ready").Code).To(Equal(http.StatusOK))
+		response := serve(http.MethodGet, "/result")
+		g.Expect(response.Code).To(Equal(http.StatusOK))
+		g.Expect(response.Body.String()).To(Equal(`{"Vulnerabilities":[]}`))
+		g.Expect(server.Released()).NotTo(BeClosed())
+	})
+
+	t.Run("should delete result when released", func(t *testing.T) {
+		g.Expect(serve(http.MethodGet, "/release").Code).To(Equal(http.StatusNotFound))
+		g.Expect(serve(http.MethodPost, "/release").Code).To(Equal(http.StatusNoContent))
+
+		g.Expect(resultPath).NotTo(BeAnExistingFile())
+		g.Expect(readyPath).NotTo(BeAnExistingFile())
+		g.Expect(server.Released()).To(BeClosed())
+
+		g.Expect(serve(http.MethodPost, "/release").Code).To(Equal(http.StatusNoContent))
+	})
+}
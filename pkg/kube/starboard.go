@@ -1,6 +1,7 @@
 package kube
 
 import (
+	"io"
 	"time"
 )
 
@@ -9,4 +10,7 @@ import (
 type ScannerOpts struct {
 	ScanJobTimeout time.Duration
 	DeleteScanJob  bool
+	// RawReportWriter, if set, receives a copy of the report produced by the
+	// scan job as it is read, before it is converted.
+	RawReportWriter io.Writer
 }
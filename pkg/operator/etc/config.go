@@ -26,6 +26,10 @@ type Config struct {
 	VulnerabilityScannerEnabled                  bool           `env:"OPERATOR_VULNERABILITY_SCANNER_ENABLED" envDefault:"true"`
 	VulnerabilityScannerScanOnlyCurrentRevisions bool           `env:"OPERATOR_VULNERABILITY_SCANNER_SCAN_ONLY_CURRENT_REVISIONS" envDefault:"false"`
 	VulnerabilityScannerReportTTL                *time.Duration `env:"OPERATOR_VULNERABILITY_SCANNER_REPORT_TTL"`
+	VulnerabilityScannerDBCacheRefreshSchedule   string         `env:"OPERATOR_VULNERABILITY_SCANNER_DB_CACHE_REFRESH_SCHEDULE" envDefault:"0 */6 * * *"`
+	VulnerabilityScannerCacheTTL                 time.Duration  `env:"OPERATOR_VULNERABILITY_SCANNER_CACHE_TTL" envDefault:"24h"`
+	SBOMGenerationEnabled                        bool           `env:"OPERATOR_SBOM_GENERATION_ENABLED" envDefault:"false"`
+	SBOMFormat                                   string         `env:"OPERATOR_SBOM_FORMAT" envDefault:"cyclonedx"`
 	ClusterComplianceEnabled                     bool           `env:"OPERATOR_CLUSTER_COMPLIANCE_ENABLED" envDefault:"true"`
 	ConfigAuditScannerEnabled                    bool           `env:"OPERATOR_CONFIG_AUDIT_SCANNER_ENABLED" envDefault:"false"`
 	ConfigAuditScannerScanOnlyCurrentRevisions   bool           `env:"OPERATOR_CONFIG_AUDIT_SCANNER_SCAN_ONLY_CURRENT_REVISIONS" envDefault:"false"`
@@ -42,6 +46,13 @@ type Config struct {
 
 	LeaderElectionEnabled bool   `env:"OPERATOR_LEADER_ELECTION_ENABLED" envDefault:"false"`
 	LeaderElectionID      string `env:"OPERATOR_LEADER_ELECTION_ID" envDefault:"starboard-lock"`
+
+	// AdmissionWebhookEnabled tells Starboard to serve the validating
+	// admission webhook, which denies or warns about Pods and workloads whose
+	// container images have vulnerabilities that violate the admission policy.
+	AdmissionWebhookEnabled bool   `env:"OPERATOR_ADMISSION_WEBHOOK_ENABLED" envDefault:"false"`
+	AdmissionWebhookPort    int    `env:"OPERATOR_ADMISSION_WEBHOOK_PORT" envDefault:"9443"`
+	AdmissionWebhookCertDir string `env:"OPERATOR_ADMISSION_WEBHOOK_CERT_DIR" envDefault:"/tmp/k8s-webhook-server/serving-certs"`
 }
 
 // GetOperatorConfig loads Config from environment variables.
@@ -12,6 +12,8 @@ import (
 	"github.com/aquasecurity/starboard/pkg/operator/controller"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
 	"github.com/aquasecurity/starboard/pkg/plugin"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
 	"k8s.io/client-go/kubernetes"
@@ -20,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 var (
@@ -45,6 +48,11 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 		HealthProbeBindAddress: operatorConfig.HealthProbeBindAddress,
 	}
 
+	if operatorConfig.AdmissionWebhookEnabled {
+		options.Port = operatorConfig.AdmissionWebhookPort
+		options.CertDir = operatorConfig.AdmissionWebhookCertDir
+	}
+
 	if operatorConfig.LeaderElectionEnabled {
 		options.LeaderElection = operatorConfig.LeaderElectionEnabled
 		options.LeaderElectionID = operatorConfig.LeaderElectionID
@@ -150,6 +158,14 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 			return fmt.Errorf("initializing %s plugin: %w", pluginContext.GetName(), err)
 		}
 
+		// Scan results are cached by image digests unless the TTL is zero.
+		var imageCache vulnerabilityreport.ImageCache
+		if operatorConfig.VulnerabilityScannerCacheTTL > 0 {
+			imageCache = vulnerabilityreport.NewImageCache(mgr.GetClient(), ext.NewSystemClock(),
+				operatorConfig.VulnerabilityScannerCacheTTL)
+		}
+		exploitCache := vulnerabilityreport.NewExploitCatalogCache(mgr.GetClient(), operatorNamespace)
+
 		if err = (&vulnerabilityreport.WorkloadController{
 			Logger:         ctrl.Log.WithName("reconciler").WithName("vulnerabilityreport"),
 			Config:         operatorConfig,
@@ -162,10 +178,34 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 			Plugin:         plugin,
 			PluginContext:  pluginContext,
 			ReadWriter:     vulnerabilityreport.NewReadWriter(&objectResolver),
+			Clock:          ext.NewSystemClock(),
+			EventRecorder:  mgr.GetEventRecorderFor("starboard-operator"),
+			ImageCache:     imageCache,
+			ExploitCache:   exploitCache,
+			APIReader:      mgr.GetAPIReader(),
 		}).SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup vulnerabilityreport reconciler: %w", err)
 		}
 
+		if err = (&vulnerabilityreport.ExceptionController{
+			Logger:         ctrl.Log.WithName("reconciler").WithName("vulnerabilityexception"),
+			Client:         mgr.GetClient(),
+			ObjectResolver: objectResolver,
+			Clock:          ext.NewSystemClock(),
+			EventRecorder:  mgr.GetEventRecorderFor("starboard-operator"),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup vulnerabilityexception reconciler: %w", err)
+		}
+
+		if err = (&vulnerabilityreport.VEXController{
+			Logger:        ctrl.Log.WithName("reconciler").WithName("vex"),
+			Config:        operatorConfig,
+			Client:        mgr.GetClient(),
+			EventRecorder: mgr.GetEventRecorderFor("starboard-operator"),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup vex reconciler: %w", err)
+		}
+
 		if operatorConfig.VulnerabilityScannerReportTTL != nil {
 			if err = (&controller.TTLReportReconciler{
 				Logger: ctrl.Log.WithName("reconciler").WithName("ttlreport"),
@@ -176,6 +216,52 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 				return fmt.Errorf("unable to setup TTLreport reconciler: %w", err)
 			}
 		}
+
+		if pluginContext.GetName() == trivy.Plugin {
+			if err = (&trivy.DBCacheController{
+				Logger:     ctrl.Log.WithName("reconciler").WithName("trivydbcache"),
+				Config:     operatorConfig,
+				Client:     mgr.GetClient(),
+				Clock:      ext.NewSystemClock(),
+				ImageCache: imageCache,
+			}).SetupWithManager(mgr); err != nil {
+				return fmt.Errorf("unable to setup trivy DB cache reconciler: %w", err)
+			}
+		}
+	}
+
+	if operatorConfig.SBOMGenerationEnabled {
+		plugin, pluginContext, err := plugin.NewResolver().
+			WithBuildInfo(buildInfo).
+			WithNamespace(operatorNamespace).
+			WithServiceAccountName(operatorConfig.ServiceAccount).
+			WithConfig(starboardConfig).
+			WithClient(mgr.GetClient()).
+			GetSBOMPlugin()
+		if err != nil {
+			return err
+		}
+
+		err = plugin.Init(pluginContext)
+		if err != nil {
+			return fmt.Errorf("initializing %s plugin: %w", pluginContext.GetName(), err)
+		}
+
+		if err = (&sbomreport.WorkloadController{
+			Logger:         ctrl.Log.WithName("reconciler").WithName("sbomreport"),
+			Config:         operatorConfig,
+			ConfigData:     starboardConfig,
+			Client:         mgr.GetClient(),
+			ObjectResolver: objectResolver,
+			LimitChecker:   limitChecker,
+			LogsReader:     logsReader,
+			SecretsReader:  secretsReader,
+			Plugin:         plugin,
+			PluginContext:  pluginContext,
+			ReadWriter:     sbomreport.NewReadWriter(&objectResolver),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup sbomreport reconciler: %w", err)
+		}
 	}
 
 	if operatorConfig.ConfigAuditScannerEnabled {
@@ -254,15 +340,43 @@ func Start(ctx context.Context, buildInfo starboard.BuildInfo, operatorConfig et
 	if operatorConfig.ClusterComplianceEnabled {
 		logger := ctrl.Log.WithName("reconciler").WithName("clustercompliancereport")
 		cc := &compliance.ClusterComplianceReportReconciler{
-			Logger: logger,
-			Client: mgr.GetClient(),
-			Mgr:    compliance.NewMgr(mgr.GetClient(), logger, starboardConfig),
-			Clock:  ext.NewSystemClock(),
+			Logger:        logger,
+			Client:        mgr.GetClient(),
+			Mgr:           compliance.NewMgr(mgr.GetClient(), objectResolver, logger, starboardConfig, ext.NewSystemClock()),
+			Clock:         ext.NewSystemClock(),
+			EventRecorder: mgr.GetEventRecorderFor("starboard-operator"),
 		}
 		if err := cc.SetupWithManager(mgr); err != nil {
 			return fmt.Errorf("unable to setup clustercompliancereport reconciler: %w", err)
 		}
+		if err := (&compliance.SpecController{
+			Logger:        ctrl.Log.WithName("reconciler").WithName("compliancespec"),
+			Config:        operatorConfig,
+			Client:        mgr.GetClient(),
+			EventRecorder: mgr.GetEventRecorderFor("starboard-operator"),
+		}).SetupWithManager(mgr); err != nil {
+			return fmt.Errorf("unable to setup compliancespec reconciler: %w", err)
+		}
+	}
+	if operatorConfig.AdmissionWebhookEnabled {
+		policy, err := vulnerabilityreport.GetAdmissionPolicy(starboardConfig)
+		if err != nil {
+			return fmt.Errorf("getting admission policy: %w", err)
+		}
+		setupLog.Info("Registering admission webhook", "path", vulnerabilityreport.AdmissionWebhookPath)
+		mgr.GetWebhookServer().Register(vulnerabilityreport.AdmissionWebhookPath, &webhook.Admission{
+			Handler: &vulnerabilityreport.AdmissionHandler{
+				Logger:    ctrl.Log.WithName("webhook").WithName("vulnerabilityreport"),
+				Client:    mgr.GetClient(),
+				Reader:    vulnerabilityreport.NewReadWriter(&objectResolver),
+				Clock:     ext.NewSystemClock(),
+				Policy:    policy,
+				Namespace: operatorNamespace,
+				APIReader: mgr.GetAPIReader(),
+			},
+		})
 	}
+
 	setupLog.Info("Starting controllers manager")
 	if err := mgr.Start(ctx); err != nil {
 		return fmt.Errorf("starting controllers manager: %w", err)
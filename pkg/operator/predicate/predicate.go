@@ -111,6 +111,13 @@ var IsVulnerabilityReportScan = predicate.NewPredicateFuncs(func(obj client.Obje
 	return false
 })
 
+var IsSBOMReportScan = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	if _, ok := obj.GetLabels()[starboard.LabelSBOMReportScanner]; ok {
+		return true
+	}
+	return false
+})
+
 var IsConfigAuditReportScan = predicate.NewPredicateFuncs(func(obj client.Object) bool {
 	if _, ok := obj.GetLabels()[starboard.LabelConfigAuditReportScanner]; ok {
 		return true
@@ -125,6 +132,24 @@ var IsKubeBenchReportScan = predicate.NewPredicateFuncs(func(obj client.Object)
 	return false
 })
 
+// IsVEXDocument is a predicate.Predicate that returns true if the specified
+// client.Object is labeled as an OpenVEX document.
+var IsVEXDocument = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	if _, ok := obj.GetLabels()[starboard.LabelVEXDocument]; ok {
+		return true
+	}
+	return false
+})
+
+// IsComplianceSpec is a predicate.Predicate that returns true if the
+// specified client.Object is labeled as a compliance spec.
+var IsComplianceSpec = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	if _, ok := obj.GetLabels()[starboard.LabelComplianceSpec]; ok {
+		return true
+	}
+	return false
+})
+
 var IsLinuxNode = predicate.NewPredicateFuncs(func(obj client.Object) bool {
 	if os, exists := obj.GetLabels()[corev1.LabelOSStable]; exists && os == "linux" {
 		return true
@@ -10,6 +10,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/plugin/polaris"
 	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
 	trivymisconfigplugin "github.com/aquasecurity/starboard/pkg/plugin/trivymisconfig"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/trivymisconfig"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
@@ -89,11 +90,37 @@ func (r *Resolver) GetVulnerabilityPlugin() (vulnerabilityreport.Plugin, starboa
 
 	switch scanner {
 	case Trivy:
-		return trivy.NewPlugin(ext.NewSystemClock(), ext.NewGoogleUUIDGenerator(), r.objectResolver), pluginContext, nil
+		return trivy.NewPlugin(ext.NewSystemClock(), ext.NewGoogleUUIDGenerator(), r.objectResolver, r.buildInfo), pluginContext, nil
 	}
 	return nil, nil, fmt.Errorf("unsupported vulnerability scanner plugin: %s", scanner)
 }
 
+// GetSBOMPlugin is a factory method that instantiates the sbomreport.Plugin.
+//
+// SBOM documents are generated by the configured vulnerability scanner, which
+// must be Trivy, and the returned starboard.PluginContext gives access to its
+// configuration.
+func (r *Resolver) GetSBOMPlugin() (sbomreport.Plugin, starboard.PluginContext, error) {
+	scanner, err := r.config.GetVulnerabilityReportsScanner()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pluginContext := starboard.NewPluginContext().
+		WithName(string(scanner)).
+		WithNamespace(r.namespace).
+		WithServiceAccountName(r.serviceAccountName).
+		WithClient(r.client).
+		WithStarboardConfig(r.config).
+		Get()
+
+	switch scanner {
+	case Trivy:
+		return trivy.NewSBOMPlugin(ext.NewSystemClock()), pluginContext, nil
+	}
+	return nil, nil, fmt.Errorf("unsupported SBOM generator plugin: %s", scanner)
+}
+
 // GetConfigAuditPlugin is a factory method that instantiates the configauditreport.Plugin.
 //
 // Starboard supports Polaris and Conftest as configuration auditing tools.
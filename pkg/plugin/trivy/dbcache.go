@@ -0,0 +1,501 @@
+package trivy
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+)
+
+const (
+	keyTrivyDBCacheClaimName        = "trivy.dbCache.claimName"
+	keyTrivyDBCacheStorageClassName = "trivy.dbCache.storageClassName"
+	keyTrivyDBCacheStorageSize      = "trivy.dbCache.storageSize"
+	keyTrivyDBCacheAccessMode       = "trivy.dbCache.accessMode"
+)
+
+const (
+	defaultDBCacheStorageSize = "1Gi"
+	// defaultDBCacheAccessMode allows concurrent scan pods on different nodes
+	// to mount the DB cache volume.
+	defaultDBCacheAccessMode = corev1.ReadWriteMany
+
+	dbCacheVolumeName    = "trivy-db-cache"
+	dbCacheDirVolumeName = "trivy-cache"
+	// DBCacheMountPath is where the DB cache volume is mounted. The DB itself
+	// is stored in a versioned db-* subdirectory, which the db symlink points
+	// to. A new DB is swapped in by renaming a new symlink over the db one.
+	DBCacheMountPath = "/var/trivy-db-cache"
+	// dbCacheDir is the Trivy cache directory of scan pods, which the DB is
+	// copied to from the read-only DB cache volume.
+	dbCacheDir = "/tmp/trivy/.cache"
+
+	// DBCacheRefreshJobName is the name of the job that downloads the Trivy DB
+	// to the DB cache.
+	DBCacheRefreshJobName = "trivy-db-cache-refresh"
+	// DBImportPodName is the prefix of the generated name of the pod that
+	// imports an offline Trivy DB bundle to the DB cache.
+	DBImportPodName = "trivy-db-import"
+
+	dbLinkName     = "db"
+	dbDirPrefix    = "db-"
+	dbNextLinkName = "db.next"
+
+	// DBImportPort is the port the DB import server listens on.
+	DBImportPort = 8080
+
+	dbImportReadyPath  = "/ready"
+	dbImportUploadPath = "/import"
+)
+
+// DBCache is the persistent volume claim shared by scan pods to avoid
+// downloading the Trivy DB in each of them.
+type DBCache struct {
+	ClaimName        string
+	StorageClassName string
+	StorageSize      resource.Quantity
+	AccessMode       corev1.PersistentVolumeAccessMode
+}
+
+// GetDBCache returns the DBCache configured for scan pods. The returned flag
+// is false if scan pods download the Trivy DB themselves.
+func (c Config) GetDBCache() (DBCache, bool, error) {
+	claimName, ok := c.Data[keyTrivyDBCacheClaimName]
+	if !ok || claimName == "" {
+		return DBCache{}, false, nil
+	}
+	size := defaultDBCacheStorageSize
+	if value, ok := c.Data[keyTrivyDBCacheStorageSize]; ok && value != "" {
+		size = value
+	}
+	storageSize, err := resource.ParseQuantity(size)
+	if err != nil {
+		return DBCache{}, false, fmt.Errorf("parsing %s: %w", keyTrivyDBCacheStorageSize, err)
+	}
+	accessMode := defaultDBCacheAccessMode
+	if value, ok := c.Data[keyTrivyDBCacheAccessMode]; ok && value != "" {
+		accessMode = corev1.PersistentVolumeAccessMode(value)
+	}
+	switch accessMode {
+	case corev1.ReadWriteMany, corev1.ReadWriteOnce, corev1.ReadWriteOncePod:
+	default:
+		return DBCache{}, false, fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s, %s)",
+			accessMode, keyTrivyDBCacheAccessMode, corev1.ReadWriteMany, corev1.ReadWriteOnce, corev1.ReadWriteOncePod)
+	}
+	return DBCache{
+		ClaimName:        claimName,
+		StorageClassName: c.Data[keyTrivyDBCacheStorageClassName],
+		StorageSize:      storageSize,
+		AccessMode:       accessMode,
+	}, true, nil
+}
+
+// NewDBCacheClaim returns the persistent volume claim of the given DBCache.
+func NewDBCacheClaim(cache DBCache, namespace string) *corev1.PersistentVolumeClaim {
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cache.ClaimName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{cache.AccessMode},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: cache.StorageSize,
+				},
+			},
+		},
+	}
+	if cache.StorageClassName != "" {
+		claim.Spec.StorageClassName = pointer.String(cache.StorageClassName)
+	}
+	return claim
+}
+
+// shared returns true if the DB cache volume can be mounted by pods on
+// different nodes at the same time.
+func (c DBCache) shared() bool {
+	return c.AccessMode == corev1.ReadWriteMany
+}
+
+func (c DBCache) volume(readOnly bool) corev1.Volume {
+	return corev1.Volume{
+		Name: dbCacheVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: c.ClaimName,
+				ReadOnly:  readOnly,
+			},
+		},
+	}
+}
+
+func (c DBCache) volumeMount(readOnly bool) corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      dbCacheVolumeName,
+		MountPath: DBCacheMountPath,
+		ReadOnly:  readOnly,
+	}
+}
+
+// copyDBContainer returns the init container that copies the Trivy DB from
+// the read-only DB cache volume to the given Trivy cache directory. Trivy
+// opens the DB for writing, so it cannot be used in place. The db symlink is
+// resolved once, so that a DB swapped in meanwhile does not mix with the
+// copied one.
+func (c DBCache) copyDBContainer(name, trivyImageRef, cacheDir string, cacheDirMount corev1.VolumeMount) corev1.Container {
+	return corev1.Container{
+		Name:                     name,
+		Image:                    trivyImageRef,
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		Command: []string{
+			"sh", "-c",
+		},
+		Args: []string{
+			fmt.Sprintf("db=$(readlink -f %[1]s/db); test -f \"$db/trivy.db\" || { echo 'Trivy DB cache is empty' >&2; exit 1; }; mkdir -p %[2]s/db && cp -r \"$db\"/. %[2]s/db/",
+				DBCacheMountPath, cacheDir),
+		},
+		VolumeMounts: []corev1.VolumeMount{c.volumeMount(true), cacheDirMount},
+	}
+}
+
+// NewDBCacheRefreshPodSpec returns the spec of the pod that downloads the
+// Trivy DB to the given DBCache. The DB is downloaded next to the current
+// one and swapped once complete by atomically renaming a symlink to it over
+// the db symlink, so that scan pods never copy a partial DB. The previous DB
+// is kept for scan pods that are still copying it, older ones are removed.
+func NewDBCacheRefreshPodSpec(config Config, cache DBCache, serviceAccountName string) (corev1.PodSpec, error) {
+	trivyImageRef, err := config.GetImageRef()
+	if err != nil {
+		return corev1.PodSpec{}, err
+	}
+	dbRepository, err := config.GetDBRepository()
+	if err != nil {
+		return corev1.PodSpec{}, err
+	}
+	trivyConfigName := starboard.GetPluginConfigMapName(Plugin)
+
+	return corev1.PodSpec{
+		RestartPolicy:                corev1.RestartPolicyNever,
+		ServiceAccountName:           serviceAccountName,
+		AutomountServiceAccountToken: pointer.BoolPtr(false),
+		Affinity:                     starboard.LinuxNodeAffinity(),
+		Containers: []corev1.Container{
+			{
+				Name:                     "trivy",
+				Image:                    trivyImageRef,
+				ImagePullPolicy:          corev1.PullIfNotPresent,
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				Env: []corev1.EnvVar{
+					{
+						Name:  "TRIVY_DB_REPOSITORY",
+						Value: dbRepository,
+					},
+					constructEnvVarSourceFromConfigMap("HTTP_PROXY", trivyConfigName, keyTrivyHTTPProxy),
+					constructEnvVarSourceFromConfigMap("HTTPS_PROXY", trivyConfigName, keyTrivyHTTPSProxy),
+					constructEnvVarSourceFromConfigMap("NO_PROXY", trivyConfigName, keyTrivyNoProxy),
+					{
+						Name: "GITHUB_TOKEN",
+						ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{
+									Name: trivyConfigName,
+								},
+								Key:      keyTrivyGitHubToken,
+								Optional: pointer.BoolPtr(true),
+							},
+						},
+					},
+				},
+				Command: []string{
+					"sh", "-c",
+				},
+				Args: []string{
+					fmt.Sprintf(`set -e
+cd %[1]s
+rm -rf next %[4]s
+trivy --quiet --cache-dir next image --download-db-only --db-repository "$TRIVY_DB_REPOSITORY"
+current=%[3]s$(date +%%s)
+mv next/db "$current" && rm -rf next
+ln -s "$current" %[4]s
+previous=$(readlink %[2]s || true)
+# A cache written by an earlier version holds the DB in a plain directory.
+[ -L %[2]s ] || rm -rf %[2]s
+mv -T %[4]s %[2]s
+for dir in %[3]s*; do [ "$dir" = "$current" ] || [ "$dir" = "$previous" ] || rm -rf "$dir"; done`,
+						DBCacheMountPath, dbLinkName, dbDirPrefix, dbNextLinkName),
+				},
+				VolumeMounts: []corev1.VolumeMount{cache.volumeMount(false)},
+			},
+		},
+		Volumes: []corev1.Volume{cache.volume(false)},
+	}, nil
+}
+
+// NewDBImportPodSpec returns the spec of the pod that runs the DB import
+// server, which extracts an offline Trivy DB bundle to the given DBCache. The
+// server runs in the result collector image, see
+// Config.GetResultCollectorImageRef.
+func NewDBImportPodSpec(config Config, buildInfo starboard.BuildInfo, cache DBCache, serviceAccountName string) corev1.PodSpec {
+	return corev1.PodSpec{
+		RestartPolicy:                corev1.RestartPolicyNever,
+		ServiceAccountName:           serviceAccountName,
+		AutomountServiceAccountToken: pointer.BoolPtr(false),
+		Affinity:                     starboard.LinuxNodeAffinity(),
+		Containers: []corev1.Container{
+			{
+				Name:                     "db-import",
+				Image:                    config.GetResultCollectorImageRef(buildInfo),
+				ImagePullPolicy:          corev1.PullIfNotPresent,
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				Args: []string{
+					"db-import-server",
+					"--cache-dir=" + DBCacheMountPath,
+					fmt.Sprintf("--port=%d", DBImportPort),
+				},
+				Ports: []corev1.ContainerPort{
+					{
+						Name:          "http",
+						ContainerPort: DBImportPort,
+					},
+				},
+				VolumeMounts: []corev1.VolumeMount{cache.volumeMount(false)},
+			},
+		},
+		Volumes: []corev1.Volume{cache.volume(false)},
+	}
+}
+
+// ImportDB loads the offline Trivy DB bundle read from the given stream into
+// the given DBCache. It ensures the persistent volume claim of the cache and
+// runs the DB import pod with a generated name in the given namespace, so that
+// concurrent imports do not collide. The pod is deleted once the bundle has
+// been uploaded to it.
+func ImportDB(ctx context.Context, clientset kubernetes.Interface, config Config, buildInfo starboard.BuildInfo,
+	cache DBCache, namespace, serviceAccountName string, bundle io.Reader) error {
+	_, err := clientset.CoreV1().PersistentVolumeClaims(namespace).
+		Create(ctx, NewDBCacheClaim(cache, namespace), metav1.CreateOptions{})
+	if err != nil && !k8sapierror.IsAlreadyExists(err) {
+		return fmt.Errorf("creating persistent volume claim: %w", err)
+	}
+
+	pod, err := clientset.CoreV1().Pods(namespace).Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: DBImportPodName + "-",
+			Namespace:    namespace,
+			Labels: map[string]string{
+				starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+			},
+		},
+		Spec: NewDBImportPodSpec(config, buildInfo, cache, serviceAccountName),
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("creating DB import pod: %w", err)
+	}
+	defer func() {
+		klog.V(3).Infof("Deleting DB import pod %q", pod.Namespace+"/"+pod.Name)
+		_ = clientset.CoreV1().Pods(pod.Namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+	}()
+
+	err = wait.PollImmediateUntil(2*time.Second, func() (bool, error) {
+		current, err := clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		switch current.Status.Phase {
+		case corev1.PodRunning:
+			_, err := clientset.CoreV1().Pods(pod.Namespace).
+				ProxyGet("http", pod.Name, strconv.Itoa(DBImportPort), dbImportReadyPath, nil).
+				DoRaw(ctx)
+			return err == nil, nil
+		case corev1.PodSucceeded, corev1.PodFailed:
+			return false, fmt.Errorf("pod terminated with phase %s", current.Status.Phase)
+		default:
+			return false, nil
+		}
+	}, ctx.Done())
+	if err != nil {
+		return fmt.Errorf("waiting for DB import pod %q: %w", pod.Namespace+"/"+pod.Name, err)
+	}
+
+	klog.V(3).Infof("Uploading DB bundle to pod %q", pod.Namespace+"/"+pod.Name)
+	err = clientset.CoreV1().RESTClient().Post().
+		Namespace(pod.Namespace).
+		Resource("pods").
+		Name(fmt.Sprintf("%s:%d", pod.Name, DBImportPort)).
+		SubResource("proxy").
+		Suffix(dbImportUploadPath).
+		Body(bundle).
+		Do(ctx).
+		Error()
+	if err != nil {
+		return fmt.Errorf("uploading DB bundle: %w", err)
+	}
+	return nil
+}
+
+// dbBundleFiles are the files of an offline Trivy DB bundle.
+var dbBundleFiles = map[string]bool{
+	"trivy.db":      true,
+	"metadata.json": true,
+}
+
+// ExtractDBBundle extracts the offline Trivy DB bundle read from the given
+// gzipped tar stream to a new db-* subdirectory of the given cache directory.
+// The current DB is replaced only if the bundle is complete, the same way as
+// by the DB cache refresh pod.
+func ExtractDBBundle(r io.Reader, cacheDir string) (err error) {
+	next, err := os.MkdirTemp(cacheDir, dbDirPrefix)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.RemoveAll(next)
+		}
+	}()
+
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("reading DB bundle: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	extracted := make(map[string]bool)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading DB bundle: %w", err)
+		}
+		name := filepath.Base(filepath.Clean(header.Name))
+		if header.Typeflag != tar.TypeReg || !dbBundleFiles[name] {
+			continue
+		}
+		if err := extractFile(tr, filepath.Join(next, name)); err != nil {
+			return err
+		}
+		extracted[name] = true
+	}
+	for name := range dbBundleFiles {
+		if !extracted[name] {
+			return fmt.Errorf("reading DB bundle: missing %s", name)
+		}
+	}
+
+	return swapDB(cacheDir, filepath.Base(next))
+}
+
+// swapDB points the db symlink of the given cache directory to the given DB
+// subdirectory by atomically renaming a new symlink over it. DB subdirectories
+// other than the given and the previous one are removed.
+func swapDB(cacheDir, current string) error {
+	link := filepath.Join(cacheDir, dbLinkName)
+	nextLink := filepath.Join(cacheDir, dbNextLinkName)
+	if err := os.RemoveAll(nextLink); err != nil {
+		return err
+	}
+	if err := os.Symlink(current, nextLink); err != nil {
+		return err
+	}
+	previous, err := os.Readlink(link)
+	if err != nil {
+		// A cache written by an earlier version holds the DB in a plain
+		// directory, which cannot be replaced atomically.
+		if err := os.RemoveAll(link); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(nextLink, link); err != nil {
+		return err
+	}
+
+	dirs, err := filepath.Glob(filepath.Join(cacheDir, dbDirPrefix+"*"))
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		if name := filepath.Base(dir); name != current && name != previous {
+			_ = os.RemoveAll(dir)
+		}
+	}
+	return nil
+}
+
+func extractFile(r io.Reader, path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("extracting %s: %w", filepath.Base(path), err)
+	}
+	return file.Close()
+}
+
+// DBImportServer is the HTTP handler run by the DB import pod. It extracts
+// the offline Trivy DB bundle posted to it with ExtractDBBundle.
+type DBImportServer struct {
+	cacheDir string
+	mu       sync.Mutex
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewDBImportServer constructs a new DBImportServer for the given cache
+// directory.
+func NewDBImportServer(cacheDir string) *DBImportServer {
+	return &DBImportServer{
+		cacheDir: cacheDir,
+		done:     make(chan struct{}),
+	}
+}
+
+// Done returns a channel that is closed once a bundle has been imported.
+func (s *DBImportServer) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *DBImportServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == dbImportReadyPath && r.Method == http.MethodGet:
+		w.WriteHeader(http.StatusOK)
+	case r.URL.Path == dbImportUploadPath && r.Method == http.MethodPost:
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := ExtractDBBundle(r.Body, s.cacheDir); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+		s.once.Do(func() {
+			close(s.done)
+		})
+	default:
+		http.NotFound(w, r)
+	}
+}
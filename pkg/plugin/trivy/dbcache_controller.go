@@ -0,0 +1,188 @@
+package trivy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/operator/predicate"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/utils"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/go-logr/logr"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DBCacheController maintains the shared Trivy DB cache configured in the
+// Trivy plugin ConfigMap. It ensures the persistent volume claim of the
+// cache and runs a single refresh job on the schedule set by
+// etc.Config.VulnerabilityScannerDBCacheRefreshSchedule, so that scan jobs
+// do not have to download the Trivy DB themselves.
+//
+// If the ImageCache is set, scan results cached before the last refresh are
+// invalidated, so that images are rescanned with the updated Trivy DB.
+type DBCacheController struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	ext.Clock
+	ImageCache vulnerabilityreport.ImageCache
+}
+
+func (r *DBCacheController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}, builder.WithPredicates(
+			predicate.Not(predicate.IsBeingTerminated),
+			predicate.HasName(starboard.GetPluginConfigMapName(Plugin)),
+			predicate.InNamespace(r.Config.Namespace))).
+		Owns(&batchv1.Job{}).
+		Complete(r.reconcileConfig())
+}
+
+func (r *DBCacheController) reconcileConfig() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("configMap", req.NamespacedName)
+
+		cm := &corev1.ConfigMap{}
+		err := r.Client.Get(ctx, req.NamespacedName, cm)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.V(1).Info("Ignoring cached ConfigMap that must have been deleted")
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, fmt.Errorf("getting ConfigMap from cache: %w", err)
+		}
+
+		config := Config{PluginConfig: starboard.PluginConfig{Data: cm.Data}}
+		cache, enabled, err := config.GetDBCache()
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !enabled {
+			log.V(1).Info("Ignoring ConfigMap without Trivy DB cache")
+			return ctrl.Result{}, nil
+		}
+
+		err = r.ensureClaim(ctx, cache)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		job := &batchv1.Job{}
+		err = r.Client.Get(ctx, client.ObjectKey{Namespace: r.Config.Namespace, Name: DBCacheRefreshJobName}, job)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				log.V(1).Info("Creating Trivy DB cache refresh job")
+				return ctrl.Result{}, r.createRefreshJob(ctx, cm, config, cache)
+			}
+			return ctrl.Result{}, fmt.Errorf("getting job from cache: %w", err)
+		}
+
+		if len(job.Status.Conditions) == 0 {
+			log.V(1).Info("Trivy DB cache refresh job is still running")
+			return ctrl.Result{}, nil
+		}
+
+		// A failed job is retried sooner than the next scheduled refresh.
+		var durationToRefresh time.Duration
+		switch jobCondition := job.Status.Conditions[0]; jobCondition.Type {
+		case batchv1.JobComplete:
+			if r.ImageCache != nil && job.Status.CompletionTime != nil {
+				err = r.ImageCache.Invalidate(ctx, job.Status.CompletionTime.Time)
+				if err != nil {
+					return ctrl.Result{}, fmt.Errorf("invalidating cached scan results: %w", err)
+				}
+			}
+			durationToRefresh, err = utils.NextCronDuration(r.Config.VulnerabilityScannerDBCacheRefreshSchedule,
+				job.CreationTimestamp.Time, r.Clock)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("parsing Trivy DB cache refresh schedule: %w", err)
+			}
+		case batchv1.JobFailed:
+			log.Info("Trivy DB cache refresh job failed", "reason", jobCondition.Reason, "message", jobCondition.Message)
+			_, durationToRefresh = utils.IsTTLExpired(r.Config.ScanJobRetryAfter, jobCondition.LastTransitionTime.Time, r.Clock)
+		default:
+			return ctrl.Result{}, fmt.Errorf("unrecognized job condition: %v", jobCondition.Type)
+		}
+
+		if !utils.DurationExceeded(durationToRefresh) {
+			log.V(1).Info("RequeueAfter", "durationToRefresh", durationToRefresh)
+			return ctrl.Result{RequeueAfter: durationToRefresh}, nil
+		}
+
+		// The deletion of the job triggers the reconciliation that creates
+		// the next one.
+		log.V(1).Info("Deleting finished Trivy DB cache refresh job")
+		err = r.Client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !errors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("deleting job: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+}
+
+func (r *DBCacheController) ensureClaim(ctx context.Context, cache DBCache) error {
+	claim := &corev1.PersistentVolumeClaim{}
+	err := r.Client.Get(ctx, client.ObjectKey{Namespace: r.Config.Namespace, Name: cache.ClaimName}, claim)
+	if err == nil {
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return fmt.Errorf("getting persistent volume claim: %w", err)
+	}
+	r.Logger.V(1).Info("Creating Trivy DB cache persistent volume claim", "claim", cache.ClaimName)
+	err = r.Client.Create(ctx, NewDBCacheClaim(cache, r.Config.Namespace))
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("creating persistent volume claim: %w", err)
+	}
+	return nil
+}
+
+func (r *DBCacheController) createRefreshJob(ctx context.Context, cm *corev1.ConfigMap, config Config, cache DBCache) error {
+	templateSpec, err := NewDBCacheRefreshPodSpec(config, cache, r.Config.ServiceAccount)
+	if err != nil {
+		return err
+	}
+	labels := map[string]string{
+		starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+	}
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DBCacheRefreshJobName,
+			Namespace: r.Config.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          pointer.Int32Ptr(0),
+			Completions:           pointer.Int32Ptr(1),
+			ActiveDeadlineSeconds: kube.GetActiveDeadlineSeconds(r.Config.ScanJobTimeout),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: templateSpec,
+			},
+		},
+	}
+	err = controllerutil.SetControllerReference(cm, job, r.Client.Scheme())
+	if err != nil {
+		return fmt.Errorf("setting controller reference: %w", err)
+	}
+	err = r.Client.Create(ctx, job)
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("creating job: %w", err)
+	}
+	return nil
+}
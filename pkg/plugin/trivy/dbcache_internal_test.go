@@ -0,0 +1,75 @@
+package trivy
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPlugin_getPodSpecForStandaloneFSMode_DBCache(t *testing.T) {
+	testCases := []struct {
+		name               string
+		accessMode         string
+		expectedCacheMount bool
+	}{
+		{
+			name:               "Should copy DB from shared cache",
+			accessMode:         "ReadWriteMany",
+			expectedCacheMount: true,
+		},
+		{
+			name:       "Should download DB when cache might not be attached to the node of the workload",
+			accessMode: "ReadWriteOnce",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeclient := fake.NewClientBuilder().Build()
+			pluginContext := starboard.NewPluginContext().
+				WithName(Plugin).
+				WithNamespace("starboard-ns").
+				WithServiceAccountName("starboard-sa").
+				WithClient(fakeclient).
+				Get()
+			config := Config{PluginConfig: starboard.PluginConfig{Data: map[string]string{
+				"trivy.imageRef":           "docker.io/aquasec/trivy:0.25.2",
+				"trivy.dbRepository":       defaultDBRepository,
+				"trivy.dbCache.claimName":  "trivy-db-cache",
+				"trivy.dbCache.accessMode": tc.accessMode,
+			}}}
+			objectResolver := kube.NewObjectResolver(fakeclient, &kube.CompatibleObjectMapper{})
+			instance := NewPlugin(ext.NewSystemClock(), ext.NewSimpleIDGenerator(), &objectResolver, starboard.BuildInfo{}).(*plugin)
+			jobSpec, _, err := instance.getPodSpecForStandaloneFSMode(pluginContext, config, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "prod-ns"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "nginx", Image: "nginx:1.9.1"}},
+					NodeName:   "worker-2",
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, "worker-2", jobSpec.NodeName)
+
+			var cacheMounted bool
+			for _, volume := range jobSpec.Volumes {
+				if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == "trivy-db-cache" {
+					cacheMounted = true
+				}
+			}
+			assert.Equal(t, tc.expectedCacheMount, cacheMounted)
+			require.Len(t, jobSpec.InitContainers, 2)
+			if tc.expectedCacheMount {
+				assert.Equal(t, []string{"sh", "-c"}, jobSpec.InitContainers[1].Command)
+			} else {
+				assert.Equal(t, []string{"trivy"}, jobSpec.InitContainers[1].Command)
+				assert.Contains(t, jobSpec.InitContainers[1].Args, "--download-db-only")
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package trivy_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestConfig_GetDBCache(t *testing.T) {
+	testCases := []struct {
+		name            string
+		configData      map[string]string
+		expectedError   string
+		expectedEnabled bool
+		expectedCache   trivy.DBCache
+	}{
+		{
+			name:       "Should return disabled cache by default",
+			configData: map[string]string{},
+		},
+		{
+			name: "Should return cache with defaults",
+			configData: map[string]string{
+				"trivy.dbCache.claimName": "trivy-db-cache",
+			},
+			expectedEnabled: true,
+			expectedCache: trivy.DBCache{
+				ClaimName:   "trivy-db-cache",
+				StorageSize: resource.MustParse("1Gi"),
+				AccessMode:  corev1.ReadWriteMany,
+			},
+		},
+		{
+			name: "Should return configured cache",
+			configData: map[string]string{
+				"trivy.dbCache.claimName":        "trivy-db-cache",
+				"trivy.dbCache.storageClassName": "nfs",
+				"trivy.dbCache.storageSize":      "2Gi",
+				"trivy.dbCache.accessMode":       "ReadWriteOnce",
+			},
+			expectedEnabled: true,
+			expectedCache: trivy.DBCache{
+				ClaimName:        "trivy-db-cache",
+				StorageClassName: "nfs",
+				StorageSize:      resource.MustParse("2Gi"),
+				AccessMode:       corev1.ReadWriteOnce,
+			},
+		},
+		{
+			name: "Should return error when access mode is not allowed",
+			configData: map[string]string{
+				"trivy.dbCache.claimName":  "trivy-db-cache",
+				"trivy.dbCache.accessMode": "ReadOnlyMany",
+			},
+			expectedError: "invalid value (ReadOnlyMany) of trivy.dbCache.accessMode; allowed values (ReadWriteMany, ReadWriteOnce, ReadWriteOncePod)",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: tc.configData}}
+			cache, enabled, err := config.GetDBCache()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedEnabled, enabled)
+			assert.Equal(t, tc.expectedCache, cache)
+		})
+	}
+}
+
+func TestNewDBCacheClaim(t *testing.T) {
+	claim := trivy.NewDBCacheClaim(trivy.DBCache{
+		ClaimName:        "trivy-db-cache",
+		StorageClassName: "nfs",
+		StorageSize:      resource.MustParse("1Gi"),
+		AccessMode:       corev1.ReadWriteMany,
+	}, "starboard-system")
+	assert.Equal(t, &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "trivy-db-cache",
+			Namespace: "starboard-system",
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "starboard",
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+			StorageClassName: pointer.String("nfs"),
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}, claim)
+}
+
+func newDBBundle(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestExtractDBBundle(t *testing.T) {
+	t.Run("Should replace current DB written by an earlier version", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(cacheDir, "db"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(cacheDir, "db", "trivy.db"), []byte("old"), 0644))
+
+		bundle := newDBBundle(t, map[string]string{
+			"trivy.db":         "new",
+			"./metadata.json":  `{"Version":2}`,
+			"../../etc/passwd": "ignored",
+		})
+		require.NoError(t, trivy.ExtractDBBundle(bytes.NewReader(bundle), cacheDir))
+
+		db, err := os.ReadFile(filepath.Join(cacheDir, "db", "trivy.db"))
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(db))
+		metadata, err := os.ReadFile(filepath.Join(cacheDir, "db", "metadata.json"))
+		require.NoError(t, err)
+		assert.Equal(t, `{"Version":2}`, string(metadata))
+		link, err := os.Readlink(filepath.Join(cacheDir, "db"))
+		require.NoError(t, err)
+		assert.DirExists(t, filepath.Join(cacheDir, link))
+		entries, err := os.ReadDir(cacheDir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 2)
+	})
+
+	t.Run("Should swap current DB and keep previous one", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		var links []string
+		for _, content := range []string{"first", "second", "third"} {
+			bundle := newDBBundle(t, map[string]string{
+				"trivy.db":      content,
+				"metadata.json": "{}",
+			})
+			require.NoError(t, trivy.ExtractDBBundle(bytes.NewReader(bundle), cacheDir))
+			link, err := os.Readlink(filepath.Join(cacheDir, "db"))
+			require.NoError(t, err)
+			links = append(links, link)
+		}
+
+		db, err := os.ReadFile(filepath.Join(cacheDir, "db", "trivy.db"))
+		require.NoError(t, err)
+		assert.Equal(t, "third", string(db))
+		assert.NoDirExists(t, filepath.Join(cacheDir, links[0]))
+		assert.DirExists(t, filepath.Join(cacheDir, links[1]))
+		assert.DirExists(t, filepath.Join(cacheDir, links[2]))
+		assert.NoFileExists(t, filepath.Join(cacheDir, "db.next"))
+	})
+
+	t.Run("Should keep current DB when bundle is incomplete", func(t *testing.T) {
+		cacheDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(cacheDir, "db"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(cacheDir, "db", "trivy.db"), []byte("old"), 0644))
+
+		bundle := newDBBundle(t, map[string]string{
+			"trivy.db": "new",
+		})
+		err := trivy.ExtractDBBundle(bytes.NewReader(bundle), cacheDir)
+		require.EqualError(t, err, "reading DB bundle: missing metadata.json")
+
+		db, err := os.ReadFile(filepath.Join(cacheDir, "db", "trivy.db"))
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(db))
+	})
+}
+
+func TestDBImportServer(t *testing.T) {
+	cacheDir := t.TempDir()
+	server := trivy.NewDBImportServer(cacheDir)
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/import", bytes.NewReader([]byte("not a bundle"))))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	select {
+	case <-server.Done():
+		t.Fatal("expected import server not to be done")
+	default:
+	}
+
+	bundle := newDBBundle(t, map[string]string{
+		"trivy.db":      "db",
+		"metadata.json": "{}",
+	})
+	rec = httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/import", bytes.NewReader(bundle)))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	<-server.Done()
+	assert.FileExists(t, filepath.Join(cacheDir, "db", "trivy.db"))
+}
@@ -1,6 +1,7 @@
 package trivy
 
 import (
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 )
 
@@ -27,9 +28,9 @@ type Vulnerability struct {
 	Cvss             map[string]*CVSS  `json:"CVSS"`
 }
 
-type CVSS struct {
-	V3Score *float64 `json:"V3Score,omitempty"`
-}
+// CVSS is the same as aquasecurity.CVSS so that scores are computed the same
+// way for image and cluster scans.
+type CVSS = aquasecurity.CVSS
 
 type Layer struct {
 	Digest string `json:"Digest"`
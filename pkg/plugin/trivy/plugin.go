@@ -53,6 +53,8 @@ const (
 	keyTrivySkipDirs               = "trivy.skipDirs"
 	keyTrivyDBRepository           = "trivy.dbRepository"
 
+	keyTrivyResultCollectorImageRef = "trivy.resultCollectorImageRef"
+
 	keyTrivyServerURL           = "trivy.serverURL"
 	keyTrivyServerTokenHeader   = "trivy.serverTokenHeader"
 	keyTrivyServerInsecure      = "trivy.serverInsecure"
@@ -67,6 +69,10 @@ const (
 
 const defaultDBRepository = "ghcr.io/aquasecurity/trivy-db"
 
+// defaultResultCollectorImageTag is the tag of the latest published Starboard
+// image, which is run as the result collector by development builds.
+const defaultResultCollectorImageTag = "0.15.10"
+
 // Mode in which Trivy client operates.
 type Mode string
 
@@ -98,13 +104,33 @@ func (c Config) GetTrivyTimeout() (string, error) {
 	return c.GetRequiredData(keyTrivyTimeout)
 }
 
-// GetStartupScript returns arguments that should be passed to the trivy command
+// GetResultCollectorImageRef returns the image reference of the result
+// collector sidecar, which serves the report file written by Trivy. Unless it
+// is set explicitly, it is the Starboard image of the version in the given
+// starboard.BuildInfo, so that the sidecar is upgraded together with
+// Starboard.
+func (c Config) GetResultCollectorImageRef(buildInfo starboard.BuildInfo) string {
+	if imageRef, ok := c.Data[keyTrivyResultCollectorImageRef]; ok && imageRef != "" {
+		return imageRef
+	}
+	tag := buildInfo.Version
+	if tag == "" || tag == "dev" {
+		tag = defaultResultCollectorImageTag
+	}
+	return "docker.io/aquasec/starboard:" + tag
+}
+
+// GetStartupScript returns the script run by the scan pod. It is built from
+// the ScanScope returned by GetScanScope unless a custom script is set.
 func (c Config) GetStartupScript() (string, error) {
-	strScript, err := c.GetRequiredData(keyTrivyStartupScript)
+	if strScript, ok := c.Data[keyTrivyStartupScript]; ok && strScript != "" {
+		return strScript, nil
+	}
+	scope, err := c.GetScanScope()
 	if err != nil {
 		return "", err
 	}
-	return strScript, nil
+	return scope.Script(), nil
 }
 
 func (c Config) GetMode() (Mode, error) {
@@ -244,6 +270,7 @@ type plugin struct {
 	clock          ext.Clock
 	idGenerator    ext.IDGenerator
 	objectResolver *kube.ObjectResolver
+	buildInfo      starboard.BuildInfo
 }
 
 // NewPlugin constructs a new vulnerabilityreport.Plugin, which is using an
@@ -257,11 +284,15 @@ type plugin struct {
 // on the settings returned by Config.GetMode. The ClientServer mode is usually
 // more performant, however it requires a Trivy server accessible at the
 // configurable Config.GetServerURL.
-func NewPlugin(clock ext.Clock, idGenerator ext.IDGenerator, objectResolver *kube.ObjectResolver) vulnerabilityreport.Plugin {
+//
+// The given starboard.BuildInfo determines the tag of the Starboard image run
+// as the result collector sidecar by default.
+func NewPlugin(clock ext.Clock, idGenerator ext.IDGenerator, objectResolver *kube.ObjectResolver, buildInfo starboard.BuildInfo) vulnerabilityreport.Plugin {
 	return &plugin{
 		clock:          clock,
 		idGenerator:    idGenerator,
 		objectResolver: objectResolver,
+		buildInfo:      buildInfo,
 	}
 }
 
@@ -292,7 +323,6 @@ func (p *plugin) InitWithPluginConfig(ctx starboard.PluginContext, providedConfi
 	defaultConfig := p.getDefaultConfig()
 	finalConfig := map[string]string{
 		"temp.config": "true",
-		"trivy.args":  `["trivy --quiet k8s --format=json --output=/var/report/trivy.json --no-progress --include-non-failures cluster && touch /tmp/done.txt && until [ ! -f /var/report/trivy.json ]; do sleep 5; done"]`,
 	}
 	for key, val := range defaultConfig {
 		finalConfig[key] = val
@@ -345,6 +375,7 @@ const (
 	ignoreFileVolumeName        = "ignorefile"
 	FsSharedVolumeName          = "starboard"
 	reportVolumeName            = "jsonreport"
+	reportVolumeMountPath       = "/var/report"
 	SharedVolumeLocationOfTrivy = "/var/starboard/trivy"
 )
 
@@ -392,16 +423,39 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 		{
 			Name:      reportVolumeName,
 			ReadOnly:  false,
-			MountPath: "/var/report",
+			MountPath: reportVolumeMountPath,
 		},
 	}
+	trivyVolumeMounts := append([]corev1.VolumeMount{}, volumeMounts...)
+	var initContainers []corev1.Container
+	cache, cacheEnabled, err := config.GetDBCache()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	if cacheEnabled {
+		cacheDirMount := corev1.VolumeMount{
+			Name:      dbCacheDirVolumeName,
+			MountPath: dbCacheDir,
+		}
+		volumes = append(volumes, cache.volume(true), corev1.Volume{
+			Name: dbCacheDirVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumDefault,
+				},
+			},
+		})
+		initContainers = append(initContainers, cache.copyDBContainer("trivy-db", trivyImageRef, dbCacheDir, cacheDirMount))
+		trivyVolumeMounts = append(trivyVolumeMounts, cacheDirMount)
+	}
+
 	var containers []corev1.Container
 	containers = append(containers, corev1.Container{
 		Name:                     "trivy",
 		Image:                    trivyImageRef,
 		ImagePullPolicy:          corev1.PullIfNotPresent,
 		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
-		VolumeMounts:             volumeMounts,
+		VolumeMounts:             trivyVolumeMounts,
 		// TODO: Get every config as a env var
 		Env: []corev1.EnvVar{{
 			Name:  "TRIVY_TIMEOUT",
@@ -413,9 +467,33 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 		Args: []string{script},
 	})
 
+	// The result collector sidecar serves the report written by the startup
+	// script, which is expected to create the done.txt file once the report
+	// is complete and to wait until the report is deleted.
+	containers = append(containers, corev1.Container{
+		Name:                     kube.ResultCollectorContainerName,
+		Image:                    config.GetResultCollectorImageRef(p.buildInfo),
+		ImagePullPolicy:          corev1.PullIfNotPresent,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		VolumeMounts:             volumeMounts,
+		Args: []string{
+			"result-collector",
+			"--result-file=" + scanReportPath,
+			"--ready-file=" + scanReadyPath,
+			fmt.Sprintf("--port=%d", kube.ResultCollectorPort),
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "http",
+				ContainerPort: kube.ResultCollectorPort,
+			},
+		},
+	})
+
 	return corev1.PodSpec{
 		RestartPolicy:      corev1.RestartPolicyNever,
 		ServiceAccountName: ctx.GetServiceAccountName(),
+		InitContainers:     initContainers,
 		Containers:         containers,
 		Volumes:            volumes,
 	}, secrets, nil
@@ -830,6 +908,20 @@ func (p *plugin) getPodSpecForStandaloneFSMode(ctx starboard.PluginContext, conf
 		},
 	}
 
+	// The DB cache claim lives in the operator namespace, so it can be used
+	// only if scan jobs are not created in the namespace of the workload. Scan
+	// pods are pinned to the node of the workload, which the volume might not
+	// be attached to unless it is shared, so they download the DB otherwise.
+	cache, cacheEnabled, err := config.GetDBCache()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+	if cacheEnabled && !ctx.GetStarboardConfig().VulnerabilityScanJobsInSameNamespace() && cache.shared() {
+		initContainerDB = cache.copyDBContainer(initContainerDB.Name, trivyImageRef, "/var/starboard/trivy-db", volumeMounts[0])
+		initContainerDB.Resources = requirements
+		volumes = append(volumes, cache.volume(true))
+	}
+
 	//TODO Move this to function and refactor the code to use it
 	if config.IgnoreFileExists() {
 		volumes = append(volumes, corev1.Volume{
@@ -973,6 +1065,13 @@ func (p *plugin) appendTrivyNonSSLEnv(config Config, image string, env []corev1.
 
 	return env, nil
 }
+
+// ParsesVulnerabilities returns false because ParseVulnerabilityReportData
+// does not convert vulnerabilities of the Trivy report yet.
+func (p *plugin) ParsesVulnerabilities() bool {
+	return false
+}
+
 func (p *plugin) ParseVulnerabilityReportData(ctx starboard.PluginContext, _ string, logsReader io.ReadCloser) (v1alpha1.VulnerabilityReportData, error) {
 
 	var report ScanReport
@@ -1020,44 +1119,18 @@ func (p *plugin) ParseVulnerabilityReportData(ctx starboard.PluginContext, _ str
 }
 
 func (p *plugin) ParseVulnerabilityReportDataNew(ctx starboard.PluginContext, logsReader io.ReadCloser) (*aquasecurity.TrivyReport, error) {
-	var report aquasecurity.ScanReport
-
-	var b byte
-	var leadingStr strings.Builder
-	br := bufio.NewReaderSize(logsReader, 2048)
-	for {
-		nextBytes, err := br.Peek(1)
-		if err != nil {
-			return nil, err
-		}
-		if string(nextBytes) == "{" {
-			break
-		}
-		b, err = br.ReadByte()
-		if err != nil {
-			return nil, err
-		}
-		err = leadingStr.WriteByte(b)
-		if err != nil {
-			return nil, err
-		}
-	}
-	err := json.NewDecoder(br).Decode(&report)
+	stream, err := p.GetJSONLogStream(ctx, logsReader)
 	if err != nil {
 		return nil, err
 	}
-
-	return &aquasecurity.TrivyReport{
-		Report: &report,
-		Errors: leadingStr.String(),
-	}, nil
+	return vulnerabilityreport.ReadTrivyReport(vulnerabilityreport.NewScanReportIterator(stream))
 }
 
-// GetJSONLogStream returns json report
+// GetJSONLogStream returns json report. Text that Trivy prints before the
+// report is discarded from the stream and kept as scanner errors, see
+// vulnerabilityreport.LeadingTextReader.
 func (p *plugin) GetJSONLogStream(ctx starboard.PluginContext, logsReader io.ReadCloser) (io.ReadCloser, error) {
-	return logsReader, nil
-	// TODO: return the jsonIterator
-	// return NewReader(logsReader), nil
+	return NewReader(logsReader), nil
 }
 
 func (p *plugin) newConfigFrom(ctx starboard.PluginContext) (Config, error) {
@@ -1108,21 +1181,7 @@ func (p *plugin) parseImageRef(imageRef string) (v1alpha1.Registry, v1alpha1.Art
 }
 
 func GetScoreFromCVSS(CVSSs map[string]*CVSS) *float64 {
-	var nvdScore, vendorScore *float64
-
-	for name, cvss := range CVSSs {
-		if name == "nvd" {
-			nvdScore = cvss.V3Score
-		} else {
-			vendorScore = cvss.V3Score
-		}
-	}
-
-	if vendorScore != nil {
-		return vendorScore
-	}
-
-	return nvdScore
+	return aquasecurity.GetScoreFromCVSS(CVSSs)
 }
 
 func GetMirroredImage(image string, mirrors map[string]string) (string, error) {
@@ -28,6 +28,7 @@ import (
 var (
 	fixedTime  = time.Now()
 	fixedClock = ext.NewFixedClock(fixedTime)
+	buildInfo  = starboard.BuildInfo{Version: "0.15.10"}
 )
 
 const defaultDBRepository = "ghcr.io/aquasecurity/trivy-db"
@@ -68,6 +69,44 @@ func TestConfig_GetImageRef(t *testing.T) {
 	}
 }
 
+func TestConfig_GetResultCollectorImageRef(t *testing.T) {
+	testCases := []struct {
+		name             string
+		configData       trivy.Config
+		buildInfo        starboard.BuildInfo
+		expectedImageRef string
+	}{
+		{
+			name:             "Should return image reference of the build version",
+			configData:       trivy.Config{PluginConfig: starboard.PluginConfig{}},
+			buildInfo:        starboard.BuildInfo{Version: "0.16.0"},
+			expectedImageRef: "docker.io/aquasec/starboard:0.16.0",
+		},
+		{
+			name:             "Should return image reference of the latest release for development builds",
+			configData:       trivy.Config{PluginConfig: starboard.PluginConfig{}},
+			buildInfo:        starboard.BuildInfo{Version: "dev"},
+			expectedImageRef: "docker.io/aquasec/starboard:0.15.10",
+		},
+		{
+			name: "Should return image reference from config data",
+			configData: trivy.Config{PluginConfig: starboard.PluginConfig{
+				Data: map[string]string{
+					"trivy.resultCollectorImageRef": "registry.example.com/aquasec/starboard:0.15.10",
+				},
+			}},
+			buildInfo:        starboard.BuildInfo{Version: "0.16.0"},
+			expectedImageRef: "registry.example.com/aquasec/starboard:0.15.10",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedImageRef, tc.configData.GetResultCollectorImageRef(tc.buildInfo))
+		})
+	}
+}
+
 func TestConfig_GetMode(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -443,7 +482,7 @@ func TestPlugin_Init(t *testing.T) {
 	t.Run("Should create the default config", func(t *testing.T) {
 		testClient := fake.NewClientBuilder().WithObjects().Build()
 		objectResolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
-		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
+		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver, buildInfo)
 
 		pluginContext := starboard.NewPluginContext().
 			WithName(trivy.Plugin).
@@ -507,7 +546,7 @@ func TestPlugin_Init(t *testing.T) {
 				},
 			}).Build()
 		objectResolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
-		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
+		instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver, buildInfo)
 
 		pluginContext := starboard.NewPluginContext().
 			WithName(trivy.Plugin).
@@ -3164,7 +3203,7 @@ CVE-2019-1543`,
 				WithClient(fakeclient).
 				Get()
 			objectResolver := kube.NewObjectResolver(fakeclient, &kube.CompatibleObjectMapper{})
-			instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
+			instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver, buildInfo)
 			jobSpec, secrets, err := instance.GetScanJobSpec(pluginContext, tc.workloadSpec, nil)
 			require.NoError(t, err)
 			assert.Empty(t, secrets)
@@ -3482,7 +3521,7 @@ CVE-2019-1543`,
 				WithStarboardConfig(map[string]string{starboard.KeyVulnerabilityScansInSameNamespace: "true"}).
 				Get()
 			objectResolver := kube.NewObjectResolver(fakeclient, &kube.CompatibleObjectMapper{})
-			instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
+			instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver, buildInfo)
 			jobSpec, secrets, err := instance.GetScanJobSpec(pluginContext, tc.workloadSpec, nil)
 			require.NoError(t, err)
 			assert.Empty(t, secrets)
@@ -3645,7 +3684,7 @@ func TestPlugin_ParseVulnerabilityReportData(t *testing.T) {
 				WithClient(fakeClient).
 				Get()
 			objectResolver := kube.NewObjectResolver(fakeClient, &kube.CompatibleObjectMapper{})
-			instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver)
+			instance := trivy.NewPlugin(fixedClock, ext.NewSimpleIDGenerator(), &objectResolver, buildInfo)
 			report, err := instance.ParseVulnerabilityReportData(ctx, tc.imageRef, io.NopCloser(strings.NewReader(tc.input)))
 			switch {
 			case tc.expectedError == nil:
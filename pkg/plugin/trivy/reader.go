@@ -17,40 +17,44 @@ type reader struct {
 	io.ReadCloser
 	br         *bufio.Reader
 	leadingStr strings.Builder
+	err        error
 }
 
 func (r *reader) Read(p []byte) (int, error) {
 	if r.br == nil {
-		r.discardUptoJSON()
+		r.err = r.discardUptoJSON()
 	}
-	return r.Read(p)
+	if r.err != nil {
+		return 0, r.err
+	}
+	return r.br.Read(p)
 }
 
 // discardUptoJSON discards everything that appears before valid json
 func (r *reader) discardUptoJSON() error {
-	var b byte
-	var leadingStr strings.Builder
 	r.br = bufio.NewReaderSize(r.ReadCloser, 2048)
 	for {
 		nextBytes, err := r.br.Peek(1)
 		if err != nil {
 			return err
 		}
-		if string(nextBytes) == "{" {
-			break
+		if nextBytes[0] == '{' {
+			return nil
 		}
-		b, err = r.br.ReadByte()
+		b, err := r.br.ReadByte()
 		if err != nil {
 			return err
 		}
-		err = leadingStr.WriteByte(b)
+		err = r.leadingStr.WriteByte(b)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
 }
 
+// GetLeadingString returns the text discarded before json, which is where
+// Trivy prints errors and warnings. It is complete once the first byte of the
+// json has been read.
 func (r *reader) GetLeadingString() string {
 	return r.leadingStr.String()
 }
@@ -0,0 +1,31 @@
+package trivy_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReader(t *testing.T) {
+
+	t.Run("Should discard text before json", func(t *testing.T) {
+		reader := trivy.NewReader(io.NopCloser(strings.NewReader("2022-08-01T10:00:00.000Z\tERROR\tunable to scan\n{\"Vulnerabilities\": []}")))
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, `{"Vulnerabilities": []}`, string(data))
+		assert.Equal(t, "2022-08-01T10:00:00.000Z\tERROR\tunable to scan\n",
+			reader.(vulnerabilityreport.LeadingTextReader).GetLeadingString())
+	})
+
+	t.Run("Should return EOF when there is no json", func(t *testing.T) {
+		reader := trivy.NewReader(io.NopCloser(strings.NewReader("FATAL\tcluster unreachable")))
+		_, err := reader.Read(make([]byte, 8))
+		assert.ErrorIs(t, err, io.EOF)
+		assert.Equal(t, "FATAL\tcluster unreachable", reader.(vulnerabilityreport.LeadingTextReader).GetLeadingString())
+	})
+}
@@ -0,0 +1,269 @@
+package trivy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	sbomCacheDir = "/tmp/trivy/.cache"
+)
+
+type sbomPlugin struct {
+	*plugin
+}
+
+// NewSBOMPlugin constructs a new sbomreport.Plugin, which is using an
+// upstream Trivy container image to generate CycloneDX or SPDX documents for
+// container images of Kubernetes workloads. It shares the configuration with
+// the vulnerability scanner plugin.
+//
+// Each container of the pod created by the scan job runs the following
+// command for the corresponding container image of the workload:
+//
+//	trivy --quiet image --format <cyclonedx|spdx-json> <container image>
+func NewSBOMPlugin(clock ext.Clock) sbomreport.Plugin {
+	return &sbomPlugin{
+		plugin: &plugin{
+			clock: clock,
+		},
+	}
+}
+
+// GetSBOMFormatArg returns the value of the Trivy --format flag for the given
+// v1alpha1.SBOMFormat.
+func GetSBOMFormatArg(format v1alpha1.SBOMFormat) (string, error) {
+	switch format {
+	case v1alpha1.SBOMFormatCycloneDX:
+		return "cyclonedx", nil
+	case v1alpha1.SBOMFormatSPDX:
+		return "spdx-json", nil
+	default:
+		return "", fmt.Errorf("unsupported SBOM format: %s", format)
+	}
+}
+
+func (p *sbomPlugin) GetScanJobSpec(ctx starboard.PluginContext, workload client.Object, format v1alpha1.SBOMFormat, credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	spec, err := kube.GetPodSpec(workload)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	formatArg, err := GetSBOMFormatArg(format)
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	trivyImageRef, err := config.GetImageRef()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	requirements, err := config.GetResourceRequirements()
+	if err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	var secret *corev1.Secret
+	var secrets []*corev1.Secret
+	if len(credentials) > 0 {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: sbomreport.RegistryCredentialsSecretName(workload),
+			},
+			Data: kube.AggregateImagePullSecretsData(kube.GetContainerImagesFromPodSpec(spec), credentials),
+		}
+		secrets = append(secrets, secret)
+	}
+
+	trivyConfigName := starboard.GetPluginConfigMapName(Plugin)
+
+	var containers []corev1.Container
+	for _, container := range spec.Containers {
+		env := []corev1.EnvVar{
+			constructEnvVarSourceFromConfigMap("HTTP_PROXY", trivyConfigName, keyTrivyHTTPProxy),
+			constructEnvVarSourceFromConfigMap("HTTPS_PROXY", trivyConfigName, keyTrivyHTTPSProxy),
+			constructEnvVarSourceFromConfigMap("NO_PROXY", trivyConfigName, keyTrivyNoProxy),
+			constructEnvVarSourceFromConfigMap("TRIVY_TIMEOUT", trivyConfigName, keyTrivyTimeout),
+			constructEnvVarSourceFromConfigMap("TRIVY_SKIP_FILES", trivyConfigName, keyTrivySkipFiles),
+			constructEnvVarSourceFromConfigMap("TRIVY_SKIP_DIRS", trivyConfigName, keyTrivySkipDirs),
+		}
+
+		if _, ok := credentials[container.Name]; ok && secret != nil {
+			env = append(env, corev1.EnvVar{
+				Name: "TRIVY_USERNAME",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: secret.Name,
+						},
+						Key: fmt.Sprintf("%s.username", container.Name),
+					},
+				},
+			}, corev1.EnvVar{
+				Name: "TRIVY_PASSWORD",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{
+							Name: secret.Name,
+						},
+						Key: fmt.Sprintf("%s.password", container.Name),
+					},
+				},
+			})
+		}
+
+		env, err = p.appendTrivyInsecureEnv(config, container.Image, env)
+		if err != nil {
+			return corev1.PodSpec{}, nil, err
+		}
+
+		env, err = p.appendTrivyNonSSLEnv(config, container.Image, env)
+		if err != nil {
+			return corev1.PodSpec{}, nil, err
+		}
+
+		optionalMirroredImage, err := GetMirroredImage(container.Image, config.GetMirrors())
+		if err != nil {
+			return corev1.PodSpec{}, nil, err
+		}
+
+		containers = append(containers, corev1.Container{
+			Name:                     container.Name,
+			Image:                    trivyImageRef,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+			Env:                      env,
+			Command: []string{
+				"trivy",
+			},
+			Args: []string{
+				"--cache-dir",
+				sbomCacheDir,
+				"--quiet",
+				"image",
+				"--format",
+				formatArg,
+				optionalMirroredImage,
+			},
+			VolumeMounts: []corev1.VolumeMount{
+				{
+					Name:      tmpVolumeName,
+					MountPath: "/tmp",
+				},
+			},
+			Resources: requirements,
+		})
+	}
+
+	return corev1.PodSpec{
+		Affinity:                     starboard.LinuxNodeAffinity(),
+		RestartPolicy:                corev1.RestartPolicyNever,
+		ServiceAccountName:           ctx.GetServiceAccountName(),
+		AutomountServiceAccountToken: pointer.BoolPtr(false),
+		Containers:                   containers,
+		Volumes: []corev1.Volume{
+			{
+				Name: tmpVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{
+						Medium: corev1.StorageMediumDefault,
+					},
+				},
+			},
+		},
+	}, secrets, nil
+}
+
+// sbomDocument holds properties of CycloneDX and SPDX documents used to
+// validate and summarize them.
+type sbomDocument struct {
+	BOMFormat   string            `json:"bomFormat"`
+	Components  []json.RawMessage `json:"components"`
+	SPDXVersion string            `json:"spdxVersion"`
+	Packages    []json.RawMessage `json:"packages"`
+}
+
+func (p *sbomPlugin) ParseSBOMReportData(ctx starboard.PluginContext, imageRef string, format v1alpha1.SBOMFormat, logsReader io.ReadCloser) (v1alpha1.SBOMReportData, error) {
+	config, err := p.newConfigFrom(ctx)
+	if err != nil {
+		return v1alpha1.SBOMReportData{}, err
+	}
+	trivyImageRef, err := config.GetImageRef()
+	if err != nil {
+		return v1alpha1.SBOMReportData{}, err
+	}
+	version, err := starboard.GetVersionFromImageRef(trivyImageRef)
+	if err != nil {
+		return v1alpha1.SBOMReportData{}, err
+	}
+
+	raw, err := io.ReadAll(NewReader(logsReader))
+	if err != nil {
+		return v1alpha1.SBOMReportData{}, fmt.Errorf("reading SBOM document: %w", err)
+	}
+	var doc sbomDocument
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return v1alpha1.SBOMReportData{}, fmt.Errorf("decoding SBOM document: %w", err)
+	}
+
+	var componentsCount int
+	switch format {
+	case v1alpha1.SBOMFormatCycloneDX:
+		if doc.BOMFormat != "CycloneDX" {
+			return v1alpha1.SBOMReportData{}, fmt.Errorf("expected CycloneDX document, got bomFormat %q", doc.BOMFormat)
+		}
+		componentsCount = len(doc.Components)
+	case v1alpha1.SBOMFormatSPDX:
+		if doc.SPDXVersion == "" {
+			return v1alpha1.SBOMReportData{}, fmt.Errorf("expected SPDX document, spdxVersion is not set")
+		}
+		componentsCount = len(doc.Packages)
+	default:
+		return v1alpha1.SBOMReportData{}, fmt.Errorf("unsupported SBOM format: %s", format)
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, raw); err != nil {
+		return v1alpha1.SBOMReportData{}, fmt.Errorf("compacting SBOM document: %w", err)
+	}
+
+	registry, artifact, err := p.parseImageRef(imageRef)
+	if err != nil {
+		return v1alpha1.SBOMReportData{}, err
+	}
+
+	return v1alpha1.SBOMReportData{
+		UpdateTimestamp: metav1.NewTime(p.clock.Now()),
+		Scanner: v1alpha1.Scanner{
+			Name:    "Trivy",
+			Vendor:  "Aqua Security",
+			Version: version,
+		},
+		Registry: registry,
+		Artifact: artifact,
+		Format:   format,
+		Summary: v1alpha1.SBOMSummary{
+			ComponentsCount: componentsCount,
+		},
+		Document: compacted.String(),
+	}, nil
+}
@@ -0,0 +1,187 @@
+package trivy_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetSBOMFormatArg(t *testing.T) {
+	arg, err := trivy.GetSBOMFormatArg(v1alpha1.SBOMFormatCycloneDX)
+	require.NoError(t, err)
+	assert.Equal(t, "cyclonedx", arg)
+
+	arg, err = trivy.GetSBOMFormatArg(v1alpha1.SBOMFormatSPDX)
+	require.NoError(t, err)
+	assert.Equal(t, "spdx-json", arg)
+
+	_, err = trivy.GetSBOMFormatArg("xml")
+	require.EqualError(t, err, "unsupported SBOM format: xml")
+}
+
+func newSBOMPluginContext() starboard.PluginContext {
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "starboard-trivy-config",
+			Namespace: "starboard-ns",
+		},
+		Data: map[string]string{
+			"trivy.imageRef":     "docker.io/aquasec/trivy:0.31.3",
+			"trivy.mode":         string(trivy.Standalone),
+			"trivy.dbRepository": "ghcr.io/aquasecurity/trivy-db",
+		},
+	}
+	return starboard.NewPluginContext().
+		WithName("Trivy").
+		WithNamespace("starboard-ns").
+		WithServiceAccountName("starboard-sa").
+		WithClient(fake.NewClientBuilder().WithObjects(config).Build()).
+		Get()
+}
+
+func TestSBOMPlugin_GetScanJobSpec(t *testing.T) {
+	workload := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "ReplicaSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6799fc88d8",
+			Namespace: "prod-ns",
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "nginx", Image: "nginx:1.16"},
+						{Name: "sidecar", Image: "busybox:1.28"},
+					},
+				},
+			},
+		},
+	}
+
+	instance := trivy.NewSBOMPlugin(fixedClock)
+	spec, secrets, err := instance.GetScanJobSpec(newSBOMPluginContext(), workload, v1alpha1.SBOMFormatSPDX, nil)
+	require.NoError(t, err)
+	assert.Empty(t, secrets)
+	assert.Equal(t, "starboard-sa", spec.ServiceAccountName)
+	assert.Equal(t, corev1.RestartPolicyNever, spec.RestartPolicy)
+	require.Len(t, spec.Containers, 2)
+	assert.Equal(t, "nginx", spec.Containers[0].Name)
+	assert.Equal(t, "docker.io/aquasec/trivy:0.31.3", spec.Containers[0].Image)
+	assert.Equal(t, []string{"trivy"}, spec.Containers[0].Command)
+	assert.Equal(t, []string{
+		"--cache-dir", "/tmp/trivy/.cache", "--quiet", "image", "--format", "spdx-json", "nginx:1.16",
+	}, spec.Containers[0].Args)
+	assert.Equal(t, "sidecar", spec.Containers[1].Name)
+	assert.Equal(t, []string{
+		"--cache-dir", "/tmp/trivy/.cache", "--quiet", "image", "--format", "spdx-json", "busybox:1.28",
+	}, spec.Containers[1].Args)
+}
+
+func TestSBOMPlugin_ParseSBOMReportData(t *testing.T) {
+	testCases := []struct {
+		name           string
+		format         v1alpha1.SBOMFormat
+		input          string
+		expectedError  string
+		expectedReport v1alpha1.SBOMReportData
+	}{
+		{
+			name:   "Should parse CycloneDX document",
+			format: v1alpha1.SBOMFormatCycloneDX,
+			input: `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.4",
+  "components": [{"name": "musl"}, {"name": "zlib"}]
+}`,
+			expectedReport: v1alpha1.SBOMReportData{
+				UpdateTimestamp: metav1.NewTime(fixedTime),
+				Scanner: v1alpha1.Scanner{
+					Name:    "Trivy",
+					Vendor:  "Aqua Security",
+					Version: "0.31.3",
+				},
+				Registry: v1alpha1.Registry{
+					Server: "index.docker.io",
+				},
+				Artifact: v1alpha1.Artifact{
+					Repository: "library/alpine",
+					Tag:        "3.10.2",
+				},
+				Format: v1alpha1.SBOMFormatCycloneDX,
+				Summary: v1alpha1.SBOMSummary{
+					ComponentsCount: 2,
+				},
+				Document: `{"bomFormat":"CycloneDX","specVersion":"1.4","components":[{"name":"musl"},{"name":"zlib"}]}`,
+			},
+		},
+		{
+			name:   "Should parse SPDX document",
+			format: v1alpha1.SBOMFormatSPDX,
+			input:  `{"spdxVersion": "SPDX-2.2", "packages": [{"name": "musl"}]}`,
+			expectedReport: v1alpha1.SBOMReportData{
+				UpdateTimestamp: metav1.NewTime(fixedTime),
+				Scanner: v1alpha1.Scanner{
+					Name:    "Trivy",
+					Vendor:  "Aqua Security",
+					Version: "0.31.3",
+				},
+				Registry: v1alpha1.Registry{
+					Server: "index.docker.io",
+				},
+				Artifact: v1alpha1.Artifact{
+					Repository: "library/alpine",
+					Tag:        "3.10.2",
+				},
+				Format: v1alpha1.SBOMFormatSPDX,
+				Summary: v1alpha1.SBOMSummary{
+					ComponentsCount: 1,
+				},
+				Document: `{"spdxVersion":"SPDX-2.2","packages":[{"name":"musl"}]}`,
+			},
+		},
+		{
+			name:          "Should return error when document is not in CycloneDX format",
+			format:        v1alpha1.SBOMFormatCycloneDX,
+			input:         `{"spdxVersion": "SPDX-2.2"}`,
+			expectedError: `expected CycloneDX document, got bomFormat ""`,
+		},
+		{
+			name:          "Should return error when document is not in SPDX format",
+			format:        v1alpha1.SBOMFormatSPDX,
+			input:         `{"bomFormat": "CycloneDX"}`,
+			expectedError: "expected SPDX document, spdxVersion is not set",
+		},
+		{
+			name:          "Should return error when document is not JSON",
+			format:        v1alpha1.SBOMFormatCycloneDX,
+			input:         `FATAL image scan error`,
+			expectedError: "decoding SBOM document: unexpected end of JSON input",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := trivy.NewSBOMPlugin(fixedClock)
+			report, err := instance.ParseSBOMReportData(newSBOMPluginContext(), "alpine:3.10.2", tc.format, io.NopCloser(strings.NewReader(tc.input)))
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedReport, report)
+		})
+	}
+}
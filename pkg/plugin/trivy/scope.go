@@ -0,0 +1,233 @@
+package trivy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	keyTrivyK8SNamespaces   = "trivy.k8s.namespaces"
+	keyTrivyK8SIncludeKinds = "trivy.k8s.includeKinds"
+	keyTrivyK8SExcludeKinds = "trivy.k8s.excludeKinds"
+	keyTrivyK8SScanners     = "trivy.k8s.scanners"
+	keyTrivyK8SReport       = "trivy.k8s.report"
+)
+
+const (
+	scanReportPath = reportVolumeMountPath + "/trivy.json"
+	scanReadyPath  = reportVolumeMountPath + "/done.txt"
+)
+
+// Scanner is a kind of security check performed by the trivy k8s command.
+type Scanner string
+
+const (
+	ScannerVuln      Scanner = "vuln"
+	ScannerMisconfig Scanner = "misconfig"
+	ScannerSecret    Scanner = "secret"
+	ScannerRBAC      Scanner = "rbac"
+)
+
+// securityCheck returns the value of the --security-checks flag that enables
+// this Scanner.
+func (s Scanner) securityCheck() string {
+	if s == ScannerMisconfig {
+		return "config"
+	}
+	return string(s)
+}
+
+// ReportFormat of the trivy k8s command.
+type ReportFormat string
+
+const (
+	ReportAll     ReportFormat = "all"
+	ReportSummary ReportFormat = "summary"
+)
+
+var (
+	allowedScanners   = []Scanner{ScannerVuln, ScannerMisconfig, ScannerSecret, ScannerRBAC}
+	allowedSeverities = []string{"UNKNOWN", "LOW", "MEDIUM", "HIGH", "CRITICAL"}
+	kindRegexp        = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+)
+
+// ScanScope defines what the trivy k8s command scans and reports.
+type ScanScope struct {
+	// Namespaces to scan. All namespaces are scanned when empty.
+	Namespaces []string
+	// IncludeKinds restricts the scan to resources of the given kinds.
+	IncludeKinds []string
+	// ExcludeKinds skips resources of the given kinds.
+	ExcludeKinds []string
+	// Scanners to run. The trivy defaults are used when empty.
+	Scanners []Scanner
+	// Severities to report. All severities are reported when empty.
+	Severities []string
+	// Report format. The trivy default is used when empty.
+	Report ReportFormat
+	// CacheDir is the trivy cache directory. The trivy default is used when
+	// empty.
+	CacheDir string
+	// SkipUpdate skips the download of the vulnerability DB, which must be
+	// present in CacheDir.
+	SkipUpdate bool
+}
+
+// GetScanScope returns the ScanScope configured for the trivy k8s command.
+func (c Config) GetScanScope() (ScanScope, error) {
+	scope := ScanScope{
+		Namespaces:   c.getList(keyTrivyK8SNamespaces),
+		IncludeKinds: c.getList(keyTrivyK8SIncludeKinds),
+		ExcludeKinds: c.getList(keyTrivyK8SExcludeKinds),
+		Severities:   c.getList(keyTrivySeverity),
+		Report:       ReportFormat(strings.TrimSpace(c.Data[keyTrivyK8SReport])),
+	}
+	for _, scanner := range c.getList(keyTrivyK8SScanners) {
+		scope.Scanners = append(scope.Scanners, Scanner(scanner))
+	}
+	if _, ok, err := c.GetDBCache(); err != nil {
+		return ScanScope{}, err
+	} else if ok {
+		scope.CacheDir = dbCacheDir
+		scope.SkipUpdate = true
+	}
+	if err := scope.Validate(); err != nil {
+		return ScanScope{}, err
+	}
+	return scope, nil
+}
+
+func (c Config) getList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(c.Data[key], ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// Validate checks that the ScanScope can be turned into a trivy k8s command.
+func (s ScanScope) Validate() error {
+	for _, namespace := range s.Namespaces {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("invalid namespace %q in %s: %s", namespace, keyTrivyK8SNamespaces, strings.Join(errs, "; "))
+		}
+	}
+	for _, kind := range append(append([]string{}, s.IncludeKinds...), s.ExcludeKinds...) {
+		if !kindRegexp.MatchString(kind) {
+			return fmt.Errorf("invalid resource kind %q", kind)
+		}
+	}
+	for _, kind := range s.IncludeKinds {
+		if containsFold(s.ExcludeKinds, kind) {
+			return fmt.Errorf("resource kind %q is both included (%s) and excluded (%s)", kind, keyTrivyK8SIncludeKinds, keyTrivyK8SExcludeKinds)
+		}
+	}
+	for _, scanner := range s.Scanners {
+		if !containsScanner(allowedScanners, scanner) {
+			return fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s, %s, %s)",
+				scanner, keyTrivyK8SScanners, ScannerVuln, ScannerMisconfig, ScannerSecret, ScannerRBAC)
+		}
+	}
+	for _, severity := range s.Severities {
+		if !contains(allowedSeverities, severity) {
+			return fmt.Errorf("invalid value (%s) of %s; allowed values (%s)",
+				severity, keyTrivySeverity, strings.Join(allowedSeverities, ", "))
+		}
+	}
+	switch s.Report {
+	case "", ReportAll, ReportSummary:
+	default:
+		return fmt.Errorf("invalid value (%s) of %s; allowed values (%s, %s)",
+			s.Report, keyTrivyK8SReport, ReportAll, ReportSummary)
+	}
+	return nil
+}
+
+// Matches returns true if a resource of the given kind in the given namespace
+// is in the ScanScope. Trivy cannot filter by kind nor scan several
+// namespaces at once, therefore results have to be filtered with Matches.
+func (s ScanScope) Matches(kind, namespace string) bool {
+	if len(s.Namespaces) > 0 && !contains(s.Namespaces, namespace) {
+		return false
+	}
+	if len(s.IncludeKinds) > 0 && !containsFold(s.IncludeKinds, kind) {
+		return false
+	}
+	return !containsFold(s.ExcludeKinds, kind)
+}
+
+// Args returns the arguments of the trivy command that scans the ScanScope
+// and writes the JSON report to the given path.
+func (s ScanScope) Args(outputPath string) []string {
+	args := []string{"--quiet"}
+	if s.CacheDir != "" {
+		args = append(args, "--cache-dir="+s.CacheDir)
+	}
+	args = append(args,
+		"k8s",
+		"--format=json",
+		"--output="+outputPath,
+		"--no-progress",
+		"--include-non-failures",
+	)
+	if s.SkipUpdate {
+		args = append(args, "--skip-update")
+	}
+	if len(s.Scanners) > 0 {
+		var checks []string
+		for _, scanner := range s.Scanners {
+			checks = append(checks, scanner.securityCheck())
+		}
+		args = append(args, "--security-checks="+strings.Join(checks, ","))
+	}
+	if len(s.Severities) > 0 {
+		args = append(args, "--severity="+strings.Join(s.Severities, ","))
+	}
+	if s.Report != "" {
+		args = append(args, "--report="+string(s.Report))
+	}
+	if len(s.Namespaces) == 1 {
+		return append(args, "--namespace="+s.Namespaces[0], "all")
+	}
+	return append(args, "cluster")
+}
+
+// Script returns the startup script of the scan pod. It runs the trivy
+// command, signals the result collector that the report is complete and
+// waits until the report has been collected.
+func (s ScanScope) Script() string {
+	return fmt.Sprintf("trivy %s && touch %s && until [ ! -f %s ]; do sleep 5; done",
+		strings.Join(s.Args(scanReportPath), " "), scanReadyPath, scanReportPath)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsScanner(values []Scanner, value Scanner) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package trivy_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/plugin/trivy"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_GetScanScope(t *testing.T) {
+	testCases := []struct {
+		name          string
+		configData    map[string]string
+		expectedError string
+		expectedScope trivy.ScanScope
+	}{
+		{
+			name:          "Should return empty scope by default",
+			configData:    map[string]string{},
+			expectedScope: trivy.ScanScope{},
+		},
+		{
+			name: "Should return configured scope",
+			configData: map[string]string{
+				"trivy.k8s.namespaces":   "default, kube-system",
+				"trivy.k8s.includeKinds": "Deployment,StatefulSet",
+				"trivy.k8s.excludeKinds": "",
+				"trivy.k8s.scanners":     "vuln,misconfig",
+				"trivy.severity":         "HIGH,CRITICAL",
+				"trivy.k8s.report":       "summary",
+			},
+			expectedScope: trivy.ScanScope{
+				Namespaces:   []string{"default", "kube-system"},
+				IncludeKinds: []string{"Deployment", "StatefulSet"},
+				Scanners:     []trivy.Scanner{trivy.ScannerVuln, trivy.ScannerMisconfig},
+				Severities:   []string{"HIGH", "CRITICAL"},
+				Report:       trivy.ReportSummary,
+			},
+		},
+		{
+			name: "Should return error when namespace is invalid",
+			configData: map[string]string{
+				"trivy.k8s.namespaces": "default;rm -rf /",
+			},
+			expectedError: `invalid namespace "default;rm -rf /" in trivy.k8s.namespaces: a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`,
+		},
+		{
+			name: "Should return error when kind is both included and excluded",
+			configData: map[string]string{
+				"trivy.k8s.includeKinds": "Deployment",
+				"trivy.k8s.excludeKinds": "deployment",
+			},
+			expectedError: `resource kind "Deployment" is both included (trivy.k8s.includeKinds) and excluded (trivy.k8s.excludeKinds)`,
+		},
+		{
+			name: "Should return error when scanner is not allowed",
+			configData: map[string]string{
+				"trivy.k8s.scanners": "vuln,license",
+			},
+			expectedError: "invalid value (license) of trivy.k8s.scanners; allowed values (vuln, misconfig, secret, rbac)",
+		},
+		{
+			name: "Should return error when severity is not allowed",
+			configData: map[string]string{
+				"trivy.severity": "HIGH,SEVERE",
+			},
+			expectedError: "invalid value (SEVERE) of trivy.severity; allowed values (UNKNOWN, LOW, MEDIUM, HIGH, CRITICAL)",
+		},
+		{
+			name: "Should return error when report format is not allowed",
+			configData: map[string]string{
+				"trivy.k8s.report": "table",
+			},
+			expectedError: "invalid value (table) of trivy.k8s.report; allowed values (all, summary)",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: tc.configData}}
+			scope, err := config.GetScanScope()
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedScope, scope)
+		})
+	}
+}
+
+func TestScanScope_Matches(t *testing.T) {
+	scope := trivy.ScanScope{
+		Namespaces:   []string{"default", "prod"},
+		ExcludeKinds: []string{"pod"},
+	}
+	assert.True(t, scope.Matches("Deployment", "default"))
+	assert.True(t, scope.Matches("StatefulSet", "prod"))
+	assert.False(t, scope.Matches("Deployment", "kube-system"))
+	assert.False(t, scope.Matches("Pod", "default"))
+
+	scope = trivy.ScanScope{
+		IncludeKinds: []string{"Deployment"},
+	}
+	assert.True(t, scope.Matches("Deployment", "kube-system"))
+	assert.False(t, scope.Matches("DaemonSet", "kube-system"))
+}
+
+func TestConfig_GetStartupScript(t *testing.T) {
+	testCases := []struct {
+		name           string
+		configData     map[string]string
+		expectedScript string
+	}{
+		{
+			name: "Should return custom script",
+			configData: map[string]string{
+				"trivy.shScript":     "trivy k8s cluster",
+				"trivy.k8s.scanners": "vuln",
+			},
+			expectedScript: "trivy k8s cluster",
+		},
+		{
+			name:           "Should scan cluster by default",
+			configData:     map[string]string{},
+			expectedScript: "trivy --quiet k8s --format=json --output=/var/report/trivy.json --no-progress --include-non-failures cluster && touch /var/report/done.txt && until [ ! -f /var/report/trivy.json ]; do sleep 5; done",
+		},
+		{
+			name: "Should scan single namespace",
+			configData: map[string]string{
+				"trivy.k8s.namespaces": "default",
+				"trivy.k8s.scanners":   "vuln,misconfig,secret,rbac",
+				"trivy.severity":       "CRITICAL",
+				"trivy.k8s.report":     "all",
+			},
+			expectedScript: "trivy --quiet k8s --format=json --output=/var/report/trivy.json --no-progress --include-non-failures --security-checks=vuln,config,secret,rbac --severity=CRITICAL --report=all --namespace=default all && touch /var/report/done.txt && until [ ! -f /var/report/trivy.json ]; do sleep 5; done",
+		},
+		{
+			name: "Should skip DB update when DB cache is configured",
+			configData: map[string]string{
+				"trivy.dbCache.claimName": "trivy-db-cache",
+			},
+			expectedScript: "trivy --quiet --cache-dir=/tmp/trivy/.cache k8s --format=json --output=/var/report/trivy.json --no-progress --include-non-failures --skip-update cluster && touch /var/report/done.txt && until [ ! -f /var/report/trivy.json ]; do sleep 5; done",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := trivy.Config{PluginConfig: starboard.PluginConfig{Data: tc.configData}}
+			script, err := config.GetStartupScript()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedScript, script)
+		})
+	}
+}
@@ -1,11 +1,13 @@
 package trivymisconfig
 
+import "github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
+
 type ScanResult struct {
-	Target            string             `json:"Target"`
-	Class             string             `json:"Class"`
-	Type              string             `json:"Type"`
-	MisconfSummary    MisconfSummary     `json:"MisconfSummary"`
-	Misconfigurations []Misconfiguration `json:"Misconfigurations"`
+	Target            string                          `json:"Target"`
+	Class             string                          `json:"Class"`
+	Type              string                          `json:"Type"`
+	MisconfSummary    MisconfSummary                  `json:"MisconfSummary"`
+	Misconfigurations []aquasecurity.Misconfiguration `json:"Misconfigurations"`
 }
 
 type ScanReport struct {
@@ -15,12 +17,6 @@ type ScanReport struct {
 	Results   []ScanResult `json:"Results"`
 }
 
-type Misconfiguration struct {
-	ID     string `json:"ID"`
-	Status string `json:"Status"`
-	// Other fields are ommitted to avoid redundancy
-}
-
 type MisconfSummary struct {
 	Successes  int64 `json:"Successes"`
 	Failures   int64 `json:"Failures"`
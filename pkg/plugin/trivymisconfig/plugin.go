@@ -170,7 +170,7 @@ func (p *plugin) getPodSpecForStandaloneMode(ctx starboard.PluginContext, config
 	}
 
 	containers = append(containers, corev1.Container{
-		Name:                     "trivymisconfigscancontainer",
+		Name:                     trivymisconfig.ScanContainerName,
 		Image:                    trivyImageRef,
 		ImagePullPolicy:          corev1.PullIfNotPresent,
 		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
@@ -215,10 +215,7 @@ func (p *plugin) ParseMisconfigurationReportData(ctx starboard.PluginContext, lo
 
 	for _, report := range reports.Results {
 		for _, sr := range report.Misconfigurations {
-			misconfigurations = append(misconfigurations, v1alpha1.Misconfiguration{
-				ID:     sr.ID,
-				Status: sr.Status,
-			})
+			misconfigurations = append(misconfigurations, trivymisconfig.ToMisconfiguration(sr))
 		}
 	}
 
@@ -228,6 +225,7 @@ func (p *plugin) ParseMisconfigurationReportData(ctx starboard.PluginContext, lo
 			Name:   "Trivy",
 			Vendor: "Aqua Security",
 		},
+		Summary:           v1alpha1.MisconfigurationSummaryFrom(misconfigurations),
 		Misconfigurations: misconfigurations,
 	}, nil
 }
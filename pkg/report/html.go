@@ -187,6 +187,9 @@ func (r *namespaceReporter) topNVulnerabilitiesByScore(reports []v1alpha1.Vulner
 	for _, report := range reports {
 		vulnMap := make(map[string]bool)
 		for _, vulnerability := range report.Report.Vulnerabilities {
+			if vulnerability.Suppressed {
+				continue
+			}
 			vulnId := vulnerability.VulnerabilityID
 			if vulnMap[vulnId] {
 				continue
@@ -198,16 +201,19 @@ func (r *namespaceReporter) topNVulnerabilitiesByScore(reports []v1alpha1.Vulner
 				tempVuln.AffectedWorkloads++
 				vulnerabilityMap[vulnId] = tempVuln
 			} else {
-				if vulnerability.Score == nil {
+				if vulnerability.Score == nil && !vulnerability.KnownExploited {
 					continue
 				}
 
 				vulnerabilityMap[vulnId] = templates.VulnerabilityWithCount{
 					Vulnerability: v1alpha1.Vulnerability{
-						VulnerabilityID: vulnerability.VulnerabilityID,
-						PrimaryLink:     vulnerability.PrimaryLink,
-						Severity:        vulnerability.Severity,
-						Score:           vulnerability.Score,
+						VulnerabilityID:         vulnerability.VulnerabilityID,
+						PrimaryLink:             vulnerability.PrimaryLink,
+						Severity:                vulnerability.Severity,
+						Score:                   vulnerability.Score,
+						KnownExploited:          vulnerability.KnownExploited,
+						KnownExploitedDateAdded: vulnerability.KnownExploitedDateAdded,
+						ExploitProbability:      vulnerability.ExploitProbability,
 					},
 					AffectedWorkloads: 1,
 				}
@@ -222,8 +228,16 @@ func (r *namespaceReporter) topNVulnerabilitiesByScore(reports []v1alpha1.Vulner
 		i++
 	}
 
+	// Known exploited vulnerabilities come first regardless of their score.
 	sort.SliceStable(vulnerabilities, func(i, j int) bool {
-		return *vulnerabilities[i].Score > *vulnerabilities[j].Score
+		vi, vj := vulnerabilities[i], vulnerabilities[j]
+		if vi.KnownExploited != vj.KnownExploited {
+			return vi.KnownExploited
+		}
+		if vi.Score == nil || vj.Score == nil {
+			return vi.Score != nil
+		}
+		return *vi.Score > *vj.Score
 	})
 
 	return vulnerabilities[:ext.MinInt(N, len(vulnerabilities))]
@@ -206,6 +206,66 @@ func Test_topNVulnerabilitiesByScore(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Should return known exploited vulnerabilities first",
+			reports: []v1alpha1.VulnerabilityReport{
+				{
+					Report: v1alpha1.VulnerabilityReportData{
+						Vulnerabilities: []v1alpha1.Vulnerability{
+							{
+								VulnerabilityID: "CVE-2019-1548",
+								Severity:        v1alpha1.SeverityCritical,
+								Score:           pointer.Float64Ptr(9.1),
+							},
+							{
+								VulnerabilityID:         "CVE-2021-44228",
+								Severity:                v1alpha1.SeverityCritical,
+								KnownExploited:          true,
+								KnownExploitedDateAdded: "2021-12-10",
+								ExploitProbability:      pointer.Float64Ptr(0.97565),
+							},
+							{
+								VulnerabilityID:         "CVE-2022-22965",
+								Severity:                v1alpha1.SeverityHigh,
+								Score:                   pointer.Float64Ptr(5.3),
+								KnownExploited:          true,
+								KnownExploitedDateAdded: "2022-04-04",
+							},
+						},
+					},
+				},
+			},
+			expectedOutput: []templates.VulnerabilityWithCount{
+				{
+					Vulnerability: v1alpha1.Vulnerability{
+						VulnerabilityID:         "CVE-2022-22965",
+						Severity:                v1alpha1.SeverityHigh,
+						Score:                   pointer.Float64Ptr(5.3),
+						KnownExploited:          true,
+						KnownExploitedDateAdded: "2022-04-04",
+					},
+					AffectedWorkloads: 1,
+				},
+				{
+					Vulnerability: v1alpha1.Vulnerability{
+						VulnerabilityID:         "CVE-2021-44228",
+						Severity:                v1alpha1.SeverityCritical,
+						KnownExploited:          true,
+						KnownExploitedDateAdded: "2021-12-10",
+						ExploitProbability:      pointer.Float64Ptr(0.97565),
+					},
+					AffectedWorkloads: 1,
+				},
+				{
+					Vulnerability: v1alpha1.Vulnerability{
+						VulnerabilityID: "CVE-2019-1548",
+						Severity:        v1alpha1.SeverityCritical,
+						Score:           pointer.Float64Ptr(9.1),
+					},
+					AffectedWorkloads: 1,
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
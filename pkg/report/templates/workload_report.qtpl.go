@@ -78,13 +78,14 @@ func (p *WorkloadReport) GetMergedVulnsSummary() v1alpha1.VulnerabilitySummary {
 		merged.MediumCount += report.Summary.MediumCount
 		merged.LowCount += report.Summary.LowCount
 		merged.UnknownCount += report.Summary.UnknownCount
+		merged.ExploitedCount += report.Summary.ExploitedCount
 	}
 	return merged
 }
 
-//line pkg/report/templates/workload_report.qtpl:22
+//line pkg/report/templates/workload_report.qtpl:23
 func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
-//line pkg/report/templates/workload_report.qtpl:22
+//line pkg/report/templates/workload_report.qtpl:23
 	qw422016.N().S(`
   <style>
   a {
@@ -100,9 +101,9 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
     <div class="col mt-5">
       <div class="row text-center">
         `)
-//line pkg/report/templates/workload_report.qtpl:36
+//line pkg/report/templates/workload_report.qtpl:37
 	streamimgAquaLogo(qw422016)
-//line pkg/report/templates/workload_report.qtpl:36
+//line pkg/report/templates/workload_report.qtpl:37
 	qw422016.N().S(`
       </div>
       <div class="row mt-4 text-center">
@@ -110,27 +111,27 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
       </div>
       <div class="row text-center">
         <h3 class="text-muted mx-auto">Workload: `)
-//line pkg/report/templates/workload_report.qtpl:42
+//line pkg/report/templates/workload_report.qtpl:43
 	qw422016.E().V(p.Workload.Kind)
-//line pkg/report/templates/workload_report.qtpl:42
+//line pkg/report/templates/workload_report.qtpl:43
 	qw422016.N().S(`/`)
-//line pkg/report/templates/workload_report.qtpl:42
+//line pkg/report/templates/workload_report.qtpl:43
 	qw422016.E().S(p.Workload.Name)
-//line pkg/report/templates/workload_report.qtpl:42
+//line pkg/report/templates/workload_report.qtpl:43
 	qw422016.N().S(`</h3>
       </div>
       <div class="row text-center">
         <h3 class="text-muted mx-auto">Namespace: `)
-//line pkg/report/templates/workload_report.qtpl:45
+//line pkg/report/templates/workload_report.qtpl:46
 	qw422016.E().S(p.Workload.Namespace)
-//line pkg/report/templates/workload_report.qtpl:45
+//line pkg/report/templates/workload_report.qtpl:46
 	qw422016.N().S(`</h3>
       </div>
       <div class="row text-center">
         <h3 class="text-muted mx-auto">Generated on `)
-//line pkg/report/templates/workload_report.qtpl:48
+//line pkg/report/templates/workload_report.qtpl:49
 	qw422016.E().S(p.GeneratedAt.Format("2 Jan 2006 15:04:01"))
-//line pkg/report/templates/workload_report.qtpl:48
+//line pkg/report/templates/workload_report.qtpl:49
 	qw422016.N().S(`</h3>
       </div>
 
@@ -141,82 +142,82 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                 <div class="row">
                     <ul>
                         `)
-//line pkg/report/templates/workload_report.qtpl:57
+//line pkg/report/templates/workload_report.qtpl:58
 	if len(p.VulnsReports) > 0 {
-//line pkg/report/templates/workload_report.qtpl:57
+//line pkg/report/templates/workload_report.qtpl:58
 		qw422016.N().S(`
                         <li>
                             <a href="#vuln_header">Vulnerabilities</a></li>
                             <ul>
                               `)
-//line pkg/report/templates/workload_report.qtpl:61
+//line pkg/report/templates/workload_report.qtpl:62
 		for container, _ := range p.VulnsReports {
-//line pkg/report/templates/workload_report.qtpl:61
+//line pkg/report/templates/workload_report.qtpl:62
 			qw422016.N().S(`
                                 <li><a href="#vulns_container_`)
-//line pkg/report/templates/workload_report.qtpl:62
+//line pkg/report/templates/workload_report.qtpl:63
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:62
+//line pkg/report/templates/workload_report.qtpl:63
 			qw422016.N().S(`">`)
-//line pkg/report/templates/workload_report.qtpl:62
+//line pkg/report/templates/workload_report.qtpl:63
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:62
+//line pkg/report/templates/workload_report.qtpl:63
 			qw422016.N().S(`</a></li>
                               `)
-//line pkg/report/templates/workload_report.qtpl:63
+//line pkg/report/templates/workload_report.qtpl:64
 		}
-//line pkg/report/templates/workload_report.qtpl:63
+//line pkg/report/templates/workload_report.qtpl:64
 		qw422016.N().S(`
                             </ul>
                         </li>
                         `)
-//line pkg/report/templates/workload_report.qtpl:66
+//line pkg/report/templates/workload_report.qtpl:67
 	}
-//line pkg/report/templates/workload_report.qtpl:66
+//line pkg/report/templates/workload_report.qtpl:67
 	qw422016.N().S(`
                         `)
-//line pkg/report/templates/workload_report.qtpl:67
+//line pkg/report/templates/workload_report.qtpl:68
 	if p.ConfigAuditReport != nil && len(p.ConfigAuditReport.Report.PodChecks) > 0 {
-//line pkg/report/templates/workload_report.qtpl:67
+//line pkg/report/templates/workload_report.qtpl:68
 		qw422016.N().S(`
                         <li>
                             <a href="#ca_header">Configuration Audit</a>
                             <ul>
                               <li><a href="#ca_pod_checks">Pod Checks</a></li>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:72
+//line pkg/report/templates/workload_report.qtpl:73
 		for container, _ := range p.ConfigAuditReport.Report.ContainerChecks {
-//line pkg/report/templates/workload_report.qtpl:72
+//line pkg/report/templates/workload_report.qtpl:73
 			qw422016.N().S(`
                                   <li><a href="#ca_container_`)
-//line pkg/report/templates/workload_report.qtpl:73
+//line pkg/report/templates/workload_report.qtpl:74
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:73
+//line pkg/report/templates/workload_report.qtpl:74
 			qw422016.N().S(`">`)
-//line pkg/report/templates/workload_report.qtpl:73
+//line pkg/report/templates/workload_report.qtpl:74
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:73
+//line pkg/report/templates/workload_report.qtpl:74
 			qw422016.N().S(`</a></li>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:74
+//line pkg/report/templates/workload_report.qtpl:75
 		}
-//line pkg/report/templates/workload_report.qtpl:74
+//line pkg/report/templates/workload_report.qtpl:75
 		qw422016.N().S(`
                             </ul>
                         </li>
                         `)
-//line pkg/report/templates/workload_report.qtpl:77
+//line pkg/report/templates/workload_report.qtpl:78
 	}
-//line pkg/report/templates/workload_report.qtpl:77
+//line pkg/report/templates/workload_report.qtpl:78
 	qw422016.N().S(`
                     </ul>
                 </div>
 
 
                 `)
-//line pkg/report/templates/workload_report.qtpl:82
+//line pkg/report/templates/workload_report.qtpl:83
 	if len(p.VulnsReports) > 0 {
-//line pkg/report/templates/workload_report.qtpl:82
+//line pkg/report/templates/workload_report.qtpl:83
 		qw422016.N().S(`
                 <!-- Vulnerabilities -->
                 <div class="row text-center border-bottom mt-4">
@@ -235,7 +236,7 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                              <div class="row">
                                 <div class="col">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:100
+//line pkg/report/templates/workload_report.qtpl:101
 		var scanner_name, scanner_vendor, scanner_version, creation_timestamp string
 		for _, report := range p.VulnsReports {
 			scanner_name = report.Scanner.Name
@@ -245,22 +246,22 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
 			break
 		}
 
-//line pkg/report/templates/workload_report.qtpl:108
+//line pkg/report/templates/workload_report.qtpl:109
 		qw422016.N().S(`
                                     <p class="my-0">Name:  `)
-//line pkg/report/templates/workload_report.qtpl:109
+//line pkg/report/templates/workload_report.qtpl:110
 		qw422016.E().S(scanner_name)
-//line pkg/report/templates/workload_report.qtpl:109
+//line pkg/report/templates/workload_report.qtpl:110
 		qw422016.N().S(`</p>
                                     <p class="my-0">Vendor:  `)
-//line pkg/report/templates/workload_report.qtpl:110
+//line pkg/report/templates/workload_report.qtpl:111
 		qw422016.E().S(scanner_vendor)
-//line pkg/report/templates/workload_report.qtpl:110
+//line pkg/report/templates/workload_report.qtpl:111
 		qw422016.N().S(`</p>
                                     <p class="my-0">Version:  `)
-//line pkg/report/templates/workload_report.qtpl:111
+//line pkg/report/templates/workload_report.qtpl:112
 		qw422016.E().S(scanner_version)
-//line pkg/report/templates/workload_report.qtpl:111
+//line pkg/report/templates/workload_report.qtpl:112
 		qw422016.N().S(`</p>
                                 </div>
                              </div>
@@ -274,96 +275,96 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                             </div>
                             <div class="row">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:124
+//line pkg/report/templates/workload_report.qtpl:125
 		summary := p.GetMergedVulnsSummary()
 
-//line pkg/report/templates/workload_report.qtpl:125
+//line pkg/report/templates/workload_report.qtpl:126
 		qw422016.N().S(`
                                 `)
-//line pkg/report/templates/workload_report.qtpl:126
+//line pkg/report/templates/workload_report.qtpl:127
 		if summary.CriticalCount > 0 {
-//line pkg/report/templates/workload_report.qtpl:126
+//line pkg/report/templates/workload_report.qtpl:127
 			qw422016.N().S(`
                                 <div class="col text-center p-0 text-danger font-weight-bold">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:128
+//line pkg/report/templates/workload_report.qtpl:129
 		} else {
-//line pkg/report/templates/workload_report.qtpl:128
+//line pkg/report/templates/workload_report.qtpl:129
 			qw422016.N().S(`
                                 <div class="col text-center p-0">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:130
+//line pkg/report/templates/workload_report.qtpl:131
 		}
-//line pkg/report/templates/workload_report.qtpl:130
+//line pkg/report/templates/workload_report.qtpl:131
 		qw422016.N().S(`
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:131
+//line pkg/report/templates/workload_report.qtpl:132
 		qw422016.N().D(summary.CriticalCount)
-//line pkg/report/templates/workload_report.qtpl:131
+//line pkg/report/templates/workload_report.qtpl:132
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">CRITICAL</p>
                                 </div>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:134
+//line pkg/report/templates/workload_report.qtpl:135
 		if summary.HighCount > 0 {
-//line pkg/report/templates/workload_report.qtpl:134
+//line pkg/report/templates/workload_report.qtpl:135
 			qw422016.N().S(`
                                 <div class="col text-center p-0 text-danger font-weight-bold">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:136
+//line pkg/report/templates/workload_report.qtpl:137
 		} else {
-//line pkg/report/templates/workload_report.qtpl:136
+//line pkg/report/templates/workload_report.qtpl:137
 			qw422016.N().S(`
                                 <div class="col text-center p-0">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:138
+//line pkg/report/templates/workload_report.qtpl:139
 		}
-//line pkg/report/templates/workload_report.qtpl:138
+//line pkg/report/templates/workload_report.qtpl:139
 		qw422016.N().S(`
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:139
+//line pkg/report/templates/workload_report.qtpl:140
 		qw422016.N().D(summary.HighCount)
-//line pkg/report/templates/workload_report.qtpl:139
+//line pkg/report/templates/workload_report.qtpl:140
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">HIGH</p>
                                 </div>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:142
+//line pkg/report/templates/workload_report.qtpl:143
 		if summary.MediumCount > 0 {
-//line pkg/report/templates/workload_report.qtpl:142
+//line pkg/report/templates/workload_report.qtpl:143
 			qw422016.N().S(`
                                 <div class="col text-center p-0 text-warning font-weight-bold">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:144
+//line pkg/report/templates/workload_report.qtpl:145
 		} else {
-//line pkg/report/templates/workload_report.qtpl:144
+//line pkg/report/templates/workload_report.qtpl:145
 			qw422016.N().S(`
                                 <div class="col text-center p-0">
                                 `)
-//line pkg/report/templates/workload_report.qtpl:146
+//line pkg/report/templates/workload_report.qtpl:147
 		}
-//line pkg/report/templates/workload_report.qtpl:146
+//line pkg/report/templates/workload_report.qtpl:147
 		qw422016.N().S(`
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:147
+//line pkg/report/templates/workload_report.qtpl:148
 		qw422016.N().D(summary.MediumCount)
-//line pkg/report/templates/workload_report.qtpl:147
+//line pkg/report/templates/workload_report.qtpl:148
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">MEDIUM</p>
                                 </div>
                                 <div class="col text-center p-0">
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:151
+//line pkg/report/templates/workload_report.qtpl:152
 		qw422016.N().D(summary.LowCount)
-//line pkg/report/templates/workload_report.qtpl:151
+//line pkg/report/templates/workload_report.qtpl:152
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">LOW</p>
                                 </div>
                                 <div class="col text-center p-0">
                                     <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:155
+//line pkg/report/templates/workload_report.qtpl:156
 		qw422016.N().D(summary.UnknownCount)
-//line pkg/report/templates/workload_report.qtpl:155
+//line pkg/report/templates/workload_report.qtpl:156
 		qw422016.N().S(`</p>
                                     <p class="mx-auto ">UNKNOWN</p>
                                 </div>
@@ -380,9 +381,9 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                                 <div class="col">
                                     <p class="my-0">
                                         Generated at:  `)
-//line pkg/report/templates/workload_report.qtpl:170
+//line pkg/report/templates/workload_report.qtpl:171
 		qw422016.E().S(creation_timestamp)
-//line pkg/report/templates/workload_report.qtpl:170
+//line pkg/report/templates/workload_report.qtpl:171
 		qw422016.N().S(`
                                     </p>
                                 </div>
@@ -392,51 +393,51 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                     </div>      
                 </div>
                 `)
-//line pkg/report/templates/workload_report.qtpl:178
+//line pkg/report/templates/workload_report.qtpl:179
 	}
-//line pkg/report/templates/workload_report.qtpl:178
+//line pkg/report/templates/workload_report.qtpl:179
 	qw422016.N().S(`
                 
                 `)
-//line pkg/report/templates/workload_report.qtpl:180
+//line pkg/report/templates/workload_report.qtpl:181
 	for container, report := range p.VulnsReports {
-//line pkg/report/templates/workload_report.qtpl:180
+//line pkg/report/templates/workload_report.qtpl:181
 		qw422016.N().S(`
                 
                   <div class="row"><h5 class="text-info" id="vulns_container_`)
-//line pkg/report/templates/workload_report.qtpl:182
+//line pkg/report/templates/workload_report.qtpl:183
 		qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:182
+//line pkg/report/templates/workload_report.qtpl:183
 		qw422016.N().S(`">Container `)
-//line pkg/report/templates/workload_report.qtpl:182
+//line pkg/report/templates/workload_report.qtpl:183
 		qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:182
+//line pkg/report/templates/workload_report.qtpl:183
 		qw422016.N().S(`</h5></div>
                   <div class="row"><p>`)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:184
 		qw422016.E().S(report.Registry.Server)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:184
 		qw422016.N().S(`/`)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:184
 		qw422016.E().S(report.Artifact.Repository)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:184
 		qw422016.N().S(`:`)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:184
 		qw422016.E().S(report.Artifact.Tag)
-//line pkg/report/templates/workload_report.qtpl:183
+//line pkg/report/templates/workload_report.qtpl:184
 		qw422016.N().S(`</p></div>
                   `)
-//line pkg/report/templates/workload_report.qtpl:184
+//line pkg/report/templates/workload_report.qtpl:185
 		if len(report.Vulnerabilities) == 0 {
-//line pkg/report/templates/workload_report.qtpl:184
+//line pkg/report/templates/workload_report.qtpl:185
 			qw422016.N().S(`
                     <div class="row">
                       <p class="alert alert-success py-0 m-0" style="font-size: small;">No Vulnerabilities</p>
                     </div>                  
                   `)
-//line pkg/report/templates/workload_report.qtpl:188
+//line pkg/report/templates/workload_report.qtpl:189
 		} else {
-//line pkg/report/templates/workload_report.qtpl:188
+//line pkg/report/templates/workload_report.qtpl:189
 			qw422016.N().S(`
 
                   <div class="row">
@@ -452,67 +453,67 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                       </thead>
                       <tbody>
                         `)
-//line pkg/report/templates/workload_report.qtpl:202
+//line pkg/report/templates/workload_report.qtpl:203
 			for _, v := range report.Vulnerabilities {
-//line pkg/report/templates/workload_report.qtpl:202
+//line pkg/report/templates/workload_report.qtpl:203
 				qw422016.N().S(`
                         <tr>
                           <td>
                             <a target="_blank" href="`)
-//line pkg/report/templates/workload_report.qtpl:205
+//line pkg/report/templates/workload_report.qtpl:206
 				qw422016.E().S(v.PrimaryLink)
-//line pkg/report/templates/workload_report.qtpl:205
+//line pkg/report/templates/workload_report.qtpl:206
 				qw422016.N().S(`">`)
-//line pkg/report/templates/workload_report.qtpl:205
+//line pkg/report/templates/workload_report.qtpl:206
 				qw422016.E().S(v.VulnerabilityID)
-//line pkg/report/templates/workload_report.qtpl:205
+//line pkg/report/templates/workload_report.qtpl:206
 				qw422016.N().S(`</a>
                           </td>
                           <td>`)
-//line pkg/report/templates/workload_report.qtpl:207
-				qw422016.E().V(v.Severity)
-//line pkg/report/templates/workload_report.qtpl:207
-				qw422016.N().S(`</td>
-                          <td>`)
 //line pkg/report/templates/workload_report.qtpl:208
-				qw422016.E().S(v.Resource)
+				qw422016.E().V(v.Severity)
 //line pkg/report/templates/workload_report.qtpl:208
 				qw422016.N().S(`</td>
                           <td>`)
 //line pkg/report/templates/workload_report.qtpl:209
-				qw422016.E().S(v.InstalledVersion)
+				qw422016.E().S(v.Resource)
 //line pkg/report/templates/workload_report.qtpl:209
 				qw422016.N().S(`</td>
                           <td>`)
 //line pkg/report/templates/workload_report.qtpl:210
-				qw422016.E().S(v.FixedVersion)
+				qw422016.E().S(v.InstalledVersion)
 //line pkg/report/templates/workload_report.qtpl:210
 				qw422016.N().S(`</td>
+                          <td>`)
+//line pkg/report/templates/workload_report.qtpl:211
+				qw422016.E().S(v.FixedVersion)
+//line pkg/report/templates/workload_report.qtpl:211
+				qw422016.N().S(`</td>
                         </tr>
                         `)
-//line pkg/report/templates/workload_report.qtpl:212
+//line pkg/report/templates/workload_report.qtpl:213
 			}
-//line pkg/report/templates/workload_report.qtpl:212
+//line pkg/report/templates/workload_report.qtpl:213
 			qw422016.N().S(`
                       </tbody>
                     </table>
                   </div>
                 `)
-//line pkg/report/templates/workload_report.qtpl:216
+//line pkg/report/templates/workload_report.qtpl:217
 		}
-//line pkg/report/templates/workload_report.qtpl:216
+//line pkg/report/templates/workload_report.qtpl:217
 		qw422016.N().S(`
                 `)
-//line pkg/report/templates/workload_report.qtpl:217
+//line pkg/report/templates/workload_report.qtpl:218
 	}
-//line pkg/report/templates/workload_report.qtpl:217
+//line pkg/report/templates/workload_report.qtpl:218
 	qw422016.N().S(`
 
                 <!-- Config Audits -->
                 `)
-//line pkg/report/templates/workload_report.qtpl:220
+//line pkg/report/templates/workload_report.qtpl:221
 	if p.ConfigAuditReport != nil && len(p.ConfigAuditReport.Report.PodChecks) > 0 {
-//line pkg/report/templates/workload_report.qtpl:220
+//line pkg/report/templates/workload_report.qtpl:221
 		qw422016.N().S(`
                   <div class="row pt-3 text-center border-bottom my-4">
                       <h3 class="mx-auto" id="ca_header" style="color: rgb(0, 160, 170);">Configuration Audit</h3>
@@ -530,19 +531,19 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                              <div class="row">
                                 <div class="col">
                                     <p class="my-0">Name:  `)
-//line pkg/report/templates/workload_report.qtpl:236
+//line pkg/report/templates/workload_report.qtpl:237
 		qw422016.E().S(p.ConfigAuditReport.Report.Scanner.Name)
-//line pkg/report/templates/workload_report.qtpl:236
+//line pkg/report/templates/workload_report.qtpl:237
 		qw422016.N().S(`</p>
                                     <p class="my-0">Vendor:  `)
-//line pkg/report/templates/workload_report.qtpl:237
+//line pkg/report/templates/workload_report.qtpl:238
 		qw422016.E().S(p.ConfigAuditReport.Report.Scanner.Vendor)
-//line pkg/report/templates/workload_report.qtpl:237
+//line pkg/report/templates/workload_report.qtpl:238
 		qw422016.N().S(`</p>
                                     <p class="my-0">Version:  `)
-//line pkg/report/templates/workload_report.qtpl:238
+//line pkg/report/templates/workload_report.qtpl:239
 		qw422016.E().S(p.ConfigAuditReport.Report.Scanner.Version)
-//line pkg/report/templates/workload_report.qtpl:238
+//line pkg/report/templates/workload_report.qtpl:239
 		qw422016.N().S(`</p>
                                 </div>
                              </div>
@@ -556,47 +557,47 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                             </div>
                             <div class="row">
                               `)
-//line pkg/report/templates/workload_report.qtpl:251
+//line pkg/report/templates/workload_report.qtpl:252
 		sumCritical := p.ConfigAuditReport.Report.Summary.CriticalCount
 		sumHigh := p.ConfigAuditReport.Report.Summary.HighCount
 		sumMedium := p.ConfigAuditReport.Report.Summary.MediumCount
 		sumLow := p.ConfigAuditReport.Report.Summary.LowCount
 
-//line pkg/report/templates/workload_report.qtpl:255
+//line pkg/report/templates/workload_report.qtpl:256
 		qw422016.N().S(`
 
                               <div class="col text-center p-0 text-danger font-weight-bold">
                                 <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:258
+//line pkg/report/templates/workload_report.qtpl:259
 		qw422016.N().D(sumCritical)
-//line pkg/report/templates/workload_report.qtpl:258
+//line pkg/report/templates/workload_report.qtpl:259
 		qw422016.N().S(`</p>
                                 <p class="mx-auto">CRITICAL</p>
                               </div>
 
                               <div class="col text-center p-0 text-danger font-weight-bold">
                                 <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:263
+//line pkg/report/templates/workload_report.qtpl:264
 		qw422016.N().D(sumHigh)
-//line pkg/report/templates/workload_report.qtpl:263
+//line pkg/report/templates/workload_report.qtpl:264
 		qw422016.N().S(`</p>
                                 <p class="mx-auto">HIGH</p>
                               </div>
 
                               <div class="col text-center p-0 text-warning font-weight-bold">
                                 <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:268
+//line pkg/report/templates/workload_report.qtpl:269
 		qw422016.N().D(sumMedium)
-//line pkg/report/templates/workload_report.qtpl:268
+//line pkg/report/templates/workload_report.qtpl:269
 		qw422016.N().S(`</p>
                                 <p class="mx-auto">MEDIUM</p>
                               </div>
 
                               <div class="col text-center p-0">
                                 <p class="mx-auto mb-1">`)
-//line pkg/report/templates/workload_report.qtpl:273
+//line pkg/report/templates/workload_report.qtpl:274
 		qw422016.N().D(sumLow)
-//line pkg/report/templates/workload_report.qtpl:273
+//line pkg/report/templates/workload_report.qtpl:274
 		qw422016.N().S(`</p>
                                 <p class="mx-auto">LOW</p>
                               </div>
@@ -613,9 +614,9 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                                 <div class="col">
                                     <p class="my-0">
                                         Generated at:  `)
-//line pkg/report/templates/workload_report.qtpl:288
+//line pkg/report/templates/workload_report.qtpl:289
 		qw422016.E().S(p.ConfigAuditReport.Report.UpdateTimestamp.Format("2 Jan 2006 15:04:01"))
-//line pkg/report/templates/workload_report.qtpl:288
+//line pkg/report/templates/workload_report.qtpl:289
 		qw422016.N().S(`
                                     </p>
                                 </div>
@@ -636,53 +637,53 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                             </thead>
                             <tbody>
                               `)
-//line pkg/report/templates/workload_report.qtpl:307
+//line pkg/report/templates/workload_report.qtpl:308
 		for _, check := range p.ConfigAuditReport.Report.PodChecks {
-//line pkg/report/templates/workload_report.qtpl:307
+//line pkg/report/templates/workload_report.qtpl:308
 			qw422016.N().S(`
                                 <tr>
                                   <td>`)
-//line pkg/report/templates/workload_report.qtpl:309
-			qw422016.E().V(check.Success)
-//line pkg/report/templates/workload_report.qtpl:309
-			qw422016.N().S(`</td>
-                                  <td>`)
 //line pkg/report/templates/workload_report.qtpl:310
-			qw422016.E().S(check.ID)
+			qw422016.E().V(check.Success)
 //line pkg/report/templates/workload_report.qtpl:310
 			qw422016.N().S(`</td>
                                   <td>`)
 //line pkg/report/templates/workload_report.qtpl:311
-			qw422016.E().V(check.Severity)
+			qw422016.E().S(check.ID)
 //line pkg/report/templates/workload_report.qtpl:311
 			qw422016.N().S(`</td>
                                   <td>`)
 //line pkg/report/templates/workload_report.qtpl:312
-			qw422016.E().S(check.Category)
+			qw422016.E().V(check.Severity)
 //line pkg/report/templates/workload_report.qtpl:312
+			qw422016.N().S(`</td>
+                                  <td>`)
+//line pkg/report/templates/workload_report.qtpl:313
+			qw422016.E().S(check.Category)
+//line pkg/report/templates/workload_report.qtpl:313
 			qw422016.N().S(`</td>
                                 </tr>
                               `)
-//line pkg/report/templates/workload_report.qtpl:314
+//line pkg/report/templates/workload_report.qtpl:315
 		}
-//line pkg/report/templates/workload_report.qtpl:314
+//line pkg/report/templates/workload_report.qtpl:315
 		qw422016.N().S(`
                             </tbody>
                       </table>
                   </div>
                   `)
-//line pkg/report/templates/workload_report.qtpl:318
+//line pkg/report/templates/workload_report.qtpl:319
 		for container, checks := range p.ConfigAuditReport.Report.ContainerChecks {
-//line pkg/report/templates/workload_report.qtpl:318
+//line pkg/report/templates/workload_report.qtpl:319
 			qw422016.N().S(`
                     <div class="row"><h5 class="text-info" id="ca_container_`)
-//line pkg/report/templates/workload_report.qtpl:319
+//line pkg/report/templates/workload_report.qtpl:320
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:319
+//line pkg/report/templates/workload_report.qtpl:320
 			qw422016.N().S(`">Container `)
-//line pkg/report/templates/workload_report.qtpl:319
+//line pkg/report/templates/workload_report.qtpl:320
 			qw422016.E().S(container)
-//line pkg/report/templates/workload_report.qtpl:319
+//line pkg/report/templates/workload_report.qtpl:320
 			qw422016.N().S(`</h5></div>
                     <div class="row">
                         <table class="table table-sm table-bordered">
@@ -696,78 +697,78 @@ func (p *WorkloadReport) StreamBody(qw422016 *qt422016.Writer) {
                               </thead>
                               <tbody>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:331
+//line pkg/report/templates/workload_report.qtpl:332
 			for _, check := range checks {
-//line pkg/report/templates/workload_report.qtpl:331
+//line pkg/report/templates/workload_report.qtpl:332
 				qw422016.N().S(`
                                   <tr>
                                     <td>`)
-//line pkg/report/templates/workload_report.qtpl:333
-				qw422016.E().V(check.Success)
-//line pkg/report/templates/workload_report.qtpl:333
-				qw422016.N().S(`</td>
-                                    <td>`)
 //line pkg/report/templates/workload_report.qtpl:334
-				qw422016.E().S(check.ID)
+				qw422016.E().V(check.Success)
 //line pkg/report/templates/workload_report.qtpl:334
 				qw422016.N().S(`</td>
                                     <td>`)
 //line pkg/report/templates/workload_report.qtpl:335
-				qw422016.E().V(check.Severity)
+				qw422016.E().S(check.ID)
 //line pkg/report/templates/workload_report.qtpl:335
 				qw422016.N().S(`</td>
                                     <td>`)
 //line pkg/report/templates/workload_report.qtpl:336
-				qw422016.E().S(check.Category)
+				qw422016.E().V(check.Severity)
 //line pkg/report/templates/workload_report.qtpl:336
+				qw422016.N().S(`</td>
+                                    <td>`)
+//line pkg/report/templates/workload_report.qtpl:337
+				qw422016.E().S(check.Category)
+//line pkg/report/templates/workload_report.qtpl:337
 				qw422016.N().S(`</td>
                                   </tr>
                                 `)
-//line pkg/report/templates/workload_report.qtpl:338
+//line pkg/report/templates/workload_report.qtpl:339
 			}
-//line pkg/report/templates/workload_report.qtpl:338
+//line pkg/report/templates/workload_report.qtpl:339
 			qw422016.N().S(`
                               </tbody>
                         </table>
                     </div>
                   `)
-//line pkg/report/templates/workload_report.qtpl:342
+//line pkg/report/templates/workload_report.qtpl:343
 		}
-//line pkg/report/templates/workload_report.qtpl:342
+//line pkg/report/templates/workload_report.qtpl:343
 		qw422016.N().S(`
                   `)
-//line pkg/report/templates/workload_report.qtpl:343
+//line pkg/report/templates/workload_report.qtpl:344
 	}
-//line pkg/report/templates/workload_report.qtpl:343
+//line pkg/report/templates/workload_report.qtpl:344
 	qw422016.N().S(`
             </div>
         </div>
 `)
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:347
 }
 
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:347
 func (p *WorkloadReport) WriteBody(qq422016 qtio422016.Writer) {
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:347
 	qw422016 := qt422016.AcquireWriter(qq422016)
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:347
 	p.StreamBody(qw422016)
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:347
 	qt422016.ReleaseWriter(qw422016)
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:347
 }
 
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:347
 func (p *WorkloadReport) Body() string {
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:347
 	qb422016 := qt422016.AcquireByteBuffer()
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:347
 	p.WriteBody(qb422016)
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:347
 	qs422016 := string(qb422016.B)
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:347
 	qt422016.ReleaseByteBuffer(qb422016)
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:347
 	return qs422016
-//line pkg/report/templates/workload_report.qtpl:346
+//line pkg/report/templates/workload_report.qtpl:347
 }
@@ -0,0 +1,250 @@
+package sbomreport
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type ScanJobBuilder struct {
+	plugin            Plugin
+	pluginContext     starboard.PluginContext
+	timeout           time.Duration
+	object            client.Object
+	format            v1alpha1.SBOMFormat
+	credentials       map[string]docker.Auth
+	tolerations       []corev1.Toleration
+	annotations       map[string]string
+	podTemplateLabels labels.Set
+}
+
+func NewScanJobBuilder() *ScanJobBuilder {
+	return &ScanJobBuilder{}
+}
+
+func (s *ScanJobBuilder) WithPlugin(plugin Plugin) *ScanJobBuilder {
+	s.plugin = plugin
+	return s
+}
+
+func (s *ScanJobBuilder) WithPluginContext(pluginContext starboard.PluginContext) *ScanJobBuilder {
+	s.pluginContext = pluginContext
+	return s
+}
+
+func (s *ScanJobBuilder) WithTimeout(timeout time.Duration) *ScanJobBuilder {
+	s.timeout = timeout
+	return s
+}
+
+func (s *ScanJobBuilder) WithObject(object client.Object) *ScanJobBuilder {
+	s.object = object
+	return s
+}
+
+func (s *ScanJobBuilder) WithFormat(format v1alpha1.SBOMFormat) *ScanJobBuilder {
+	s.format = format
+	return s
+}
+
+func (s *ScanJobBuilder) WithCredentials(credentials map[string]docker.Auth) *ScanJobBuilder {
+	s.credentials = credentials
+	return s
+}
+
+func (s *ScanJobBuilder) WithTolerations(tolerations []corev1.Toleration) *ScanJobBuilder {
+	s.tolerations = tolerations
+	return s
+}
+
+func (s *ScanJobBuilder) WithAnnotations(annotations map[string]string) *ScanJobBuilder {
+	s.annotations = annotations
+	return s
+}
+
+func (s *ScanJobBuilder) WithPodTemplateLabels(podTemplateLabels labels.Set) *ScanJobBuilder {
+	s.podTemplateLabels = podTemplateLabels
+	return s
+}
+
+func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
+	spec, err := kube.GetPodSpec(s.object)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	templateSpec, secrets, err := s.plugin.GetScanJobSpec(s.pluginContext, s.object, s.format, s.credentials)
+	if err != nil {
+		return nil, nil, err
+	}
+	templateSpec.Tolerations = append(templateSpec.Tolerations, s.tolerations...)
+
+	containerImagesAsJSON, err := kube.GetContainerImagesFromPodSpec(spec).AsJSON()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	labelsSet := labels.Set{
+		starboard.LabelResourceSpecHash:  kube.ComputeHash(spec),
+		starboard.LabelSBOMReportScanner: s.pluginContext.GetName(),
+		starboard.LabelK8SAppManagedBy:   starboard.AppStarboard,
+	}
+
+	podTemplateLabelsSet := make(labels.Set)
+	for index, element := range labelsSet {
+		podTemplateLabelsSet[index] = element
+	}
+	for index, element := range s.podTemplateLabels {
+		podTemplateLabelsSet[index] = element
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetScanJobName(s.object),
+			Namespace: s.pluginContext.GetNamespace(),
+			Labels:    labelsSet,
+			Annotations: map[string]string{
+				starboard.AnnotationContainerImages: containerImagesAsJSON,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          pointer.Int32Ptr(0),
+			Completions:           pointer.Int32Ptr(1),
+			ActiveDeadlineSeconds: kube.GetActiveDeadlineSeconds(s.timeout),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      podTemplateLabelsSet,
+					Annotations: s.annotations,
+				},
+				Spec: templateSpec,
+			},
+		},
+	}
+
+	err = kube.ObjectToObjectMeta(s.object, &job.ObjectMeta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = kube.ObjectToObjectMeta(s.object, &job.Spec.Template.ObjectMeta)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, secret := range secrets {
+		secret.Namespace = s.pluginContext.GetNamespace()
+	}
+
+	return job, secrets, nil
+}
+
+func GetScanJobName(obj client.Object) string {
+	return fmt.Sprintf("scan-sbomreport-%s", kube.ComputeHash(kube.ObjectRef{
+		Kind:      kube.Kind(obj.GetObjectKind().GroupVersionKind().Kind),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}))
+}
+
+func RegistryCredentialsSecretName(obj client.Object) string {
+	return fmt.Sprintf("%s-regcred", GetScanJobName(obj))
+}
+
+type ReportBuilder struct {
+	scheme     *runtime.Scheme
+	controller client.Object
+	container  string
+	hash       string
+	data       v1alpha1.SBOMReportData
+}
+
+func NewReportBuilder(scheme *runtime.Scheme) *ReportBuilder {
+	return &ReportBuilder{
+		scheme: scheme,
+	}
+}
+
+func (b *ReportBuilder) Controller(controller client.Object) *ReportBuilder {
+	b.controller = controller
+	return b
+}
+
+func (b *ReportBuilder) Container(name string) *ReportBuilder {
+	b.container = name
+	return b
+}
+
+func (b *ReportBuilder) PodSpecHash(hash string) *ReportBuilder {
+	b.hash = hash
+	return b
+}
+
+func (b *ReportBuilder) Data(data v1alpha1.SBOMReportData) *ReportBuilder {
+	b.data = data
+	return b
+}
+
+func (b *ReportBuilder) reportName() string {
+	kind := b.controller.GetObjectKind().GroupVersionKind().Kind
+	name := b.controller.GetName()
+	reportName := fmt.Sprintf("%s-%s-%s", strings.ToLower(kind), name, b.container)
+	if len(validation.IsValidLabelValue(reportName)) == 0 {
+		return reportName
+	}
+
+	return fmt.Sprintf("%s-%s", strings.ToLower(kind), kube.ComputeHash(name+"-"+b.container))
+}
+
+// Get returns the v1alpha1.SBOMReport with the document compressed if it's
+// larger than CompressThreshold.
+func (b *ReportBuilder) Get() (v1alpha1.SBOMReport, error) {
+	labels := map[string]string{
+		starboard.LabelContainerName: b.container,
+	}
+
+	if b.hash != "" {
+		labels[starboard.LabelResourceSpecHash] = b.hash
+	}
+
+	data, err := EncodeDocument(b.data)
+	if err != nil {
+		return v1alpha1.SBOMReport{}, err
+	}
+
+	report := v1alpha1.SBOMReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      b.reportName(),
+			Namespace: b.controller.GetNamespace(),
+			Labels:    labels,
+		},
+		Report: data,
+	}
+
+	err = kube.ObjectToObjectMeta(b.controller, &report.ObjectMeta)
+	if err != nil {
+		return v1alpha1.SBOMReport{}, err
+	}
+	err = controllerutil.SetControllerReference(b.controller, &report, b.scheme)
+	if err != nil {
+		return v1alpha1.SBOMReport{}, fmt.Errorf("setting controller reference: %w", err)
+	}
+	// Do not require RBAC permissions to update finalizers of the owner when
+	// the OwnerReferencesPermissionsEnforcement admission controller is
+	// enabled. See vulnerabilityreport.ReportBuilder for details.
+	report.OwnerReferences[0].BlockOwnerDeletion = pointer.BoolPtr(false)
+	return report, nil
+}
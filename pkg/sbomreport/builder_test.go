@@ -0,0 +1,80 @@
+package sbomreport_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestReportBuilder(t *testing.T) {
+	replicaSet := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "ReplicaSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-owner",
+			Namespace: "qa",
+		},
+	}
+
+	t.Run("Should build report owned by workload", func(t *testing.T) {
+		report, err := sbomreport.NewReportBuilder(starboard.NewScheme()).
+			Controller(replicaSet).
+			Container("my-container").
+			PodSpecHash("xyz").
+			Data(v1alpha1.SBOMReportData{
+				Format:   v1alpha1.SBOMFormatCycloneDX,
+				Document: "{}",
+			}).
+			Get()
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.SBOMReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-some-owner-my-container",
+				Namespace: "qa",
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion:         "apps/v1",
+						Kind:               "ReplicaSet",
+						Name:               "some-owner",
+						Controller:         pointer.BoolPtr(true),
+						BlockOwnerDeletion: pointer.BoolPtr(false),
+					},
+				},
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "ReplicaSet",
+					starboard.LabelResourceName:      "some-owner",
+					starboard.LabelResourceNamespace: "qa",
+					starboard.LabelContainerName:     "my-container",
+					starboard.LabelResourceSpecHash:  "xyz",
+				},
+			},
+			Report: v1alpha1.SBOMReportData{
+				Format:   v1alpha1.SBOMFormatCycloneDX,
+				Document: "{}",
+			},
+		}, report)
+	})
+
+	t.Run("Should compress large document", func(t *testing.T) {
+		report, err := sbomreport.NewReportBuilder(starboard.NewScheme()).
+			Controller(replicaSet).
+			Container("my-container").
+			Data(v1alpha1.SBOMReportData{
+				Format:   v1alpha1.SBOMFormatSPDX,
+				Document: strings.Repeat("A", sbomreport.CompressThreshold+1),
+			}).
+			Get()
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.SBOMEncodingGzipBase64, report.Report.Encoding)
+	})
+}
@@ -0,0 +1,83 @@
+package sbomreport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+const (
+	// CompressThreshold is the size in bytes above which SBOM documents are
+	// gzip compressed before they are stored.
+	CompressThreshold = 32 * 1024
+
+	// MaxInlineSize is the maximum size in bytes of an encoded document kept
+	// in the v1alpha1.SBOMReport itself. Larger documents are stored in a
+	// ConfigMap referred by the report.
+	MaxInlineSize = 256 * 1024
+
+	// MaxExternalSize is the maximum size in bytes of an encoded document
+	// stored in a ConfigMap, which is limited to 1 MiB including metadata.
+	MaxExternalSize = 1000 * 1024
+)
+
+// ParseFormat returns the v1alpha1.SBOMFormat with the given name.
+func ParseFormat(value string) (v1alpha1.SBOMFormat, error) {
+	switch format := v1alpha1.SBOMFormat(strings.ToLower(value)); format {
+	case v1alpha1.SBOMFormatCycloneDX, v1alpha1.SBOMFormatSPDX:
+		return format, nil
+	}
+	return "", fmt.Errorf("invalid SBOM format %q, allowed formats are: cyclonedx,spdx", value)
+}
+
+// EncodeDocument compresses the document of the given v1alpha1.SBOMReportData
+// if it's larger than CompressThreshold. Already encoded documents are
+// returned as is.
+func EncodeDocument(data v1alpha1.SBOMReportData) (v1alpha1.SBOMReportData, error) {
+	if data.Encoding != v1alpha1.SBOMEncodingNone || len(data.Document) <= CompressThreshold {
+		return data, nil
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := io.WriteString(w, data.Document); err != nil {
+		return v1alpha1.SBOMReportData{}, fmt.Errorf("compressing SBOM document: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return v1alpha1.SBOMReportData{}, fmt.Errorf("compressing SBOM document: %w", err)
+	}
+	data.Encoding = v1alpha1.SBOMEncodingGzipBase64
+	data.Document = base64.StdEncoding.EncodeToString(buf.Bytes())
+	return data, nil
+}
+
+// DecodeDocument returns the SBOM document stored with the given encoding.
+func DecodeDocument(encoding v1alpha1.SBOMEncoding, document string) ([]byte, error) {
+	switch encoding {
+	case v1alpha1.SBOMEncodingNone:
+		return []byte(document), nil
+	case v1alpha1.SBOMEncodingGzipBase64:
+		compressed, err := base64.StdEncoding.DecodeString(document)
+		if err != nil {
+			return nil, fmt.Errorf("decoding SBOM document: %w", err)
+		}
+		r, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, fmt.Errorf("decompressing SBOM document: %w", err)
+		}
+		defer func() {
+			_ = r.Close()
+		}()
+		decompressed, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("decompressing SBOM document: %w", err)
+		}
+		return decompressed, nil
+	default:
+		return nil, fmt.Errorf("unsupported SBOM document encoding: %s", encoding)
+	}
+}
@@ -0,0 +1,84 @@
+package sbomreport_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFormat(t *testing.T) {
+	testCases := []struct {
+		value          string
+		expectedFormat v1alpha1.SBOMFormat
+		expectedError  string
+	}{
+		{value: "cyclonedx", expectedFormat: v1alpha1.SBOMFormatCycloneDX},
+		{value: "CycloneDX", expectedFormat: v1alpha1.SBOMFormatCycloneDX},
+		{value: "spdx", expectedFormat: v1alpha1.SBOMFormatSPDX},
+		{value: "spdx-json", expectedError: `invalid SBOM format "spdx-json", allowed formats are: cyclonedx,spdx`},
+		{value: "", expectedError: `invalid SBOM format "", allowed formats are: cyclonedx,spdx`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			format, err := sbomreport.ParseFormat(tc.value)
+			if tc.expectedError != "" {
+				require.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedFormat, format)
+		})
+	}
+}
+
+func TestEncodeDocument(t *testing.T) {
+	t.Run("Should keep small document as is", func(t *testing.T) {
+		data := v1alpha1.SBOMReportData{
+			Format:   v1alpha1.SBOMFormatCycloneDX,
+			Document: `{"bomFormat":"CycloneDX"}`,
+		}
+		encoded, err := sbomreport.EncodeDocument(data)
+		require.NoError(t, err)
+		assert.Equal(t, data, encoded)
+	})
+
+	t.Run("Should compress large document", func(t *testing.T) {
+		document := `{"components":[` + strings.Repeat(`{"name":"pkg"},`, sbomreport.CompressThreshold/10) + `{}]}`
+		encoded, err := sbomreport.EncodeDocument(v1alpha1.SBOMReportData{
+			Document: document,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.SBOMEncodingGzipBase64, encoded.Encoding)
+		assert.Less(t, len(encoded.Document), len(document))
+
+		decoded, err := sbomreport.DecodeDocument(encoded.Encoding, encoded.Document)
+		require.NoError(t, err)
+		assert.Equal(t, document, string(decoded))
+	})
+
+	t.Run("Should not encode document twice", func(t *testing.T) {
+		data := v1alpha1.SBOMReportData{
+			Encoding: v1alpha1.SBOMEncodingGzipBase64,
+			Document: strings.Repeat("A", sbomreport.CompressThreshold+1),
+		}
+		encoded, err := sbomreport.EncodeDocument(data)
+		require.NoError(t, err)
+		assert.Equal(t, data, encoded)
+	})
+}
+
+func TestDecodeDocument(t *testing.T) {
+	t.Run("Should return error for unsupported encoding", func(t *testing.T) {
+		_, err := sbomreport.DecodeDocument("zstd", "")
+		require.EqualError(t, err, "unsupported SBOM document encoding: zstd")
+	})
+
+	t.Run("Should return error for invalid base64 data", func(t *testing.T) {
+		_, err := sbomreport.DecodeDocument(v1alpha1.SBOMEncodingGzipBase64, "!!!")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,219 @@
+package sbomreport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	documentRefKindConfigMap = "ConfigMap"
+	documentKey              = "document"
+)
+
+// Writer is the interface that wraps the basic Write method.
+//
+// Write creates or updates the given slice of v1alpha1.SBOMReport instances.
+// Documents larger than MaxInlineSize are moved to ConfigMaps controlled by
+// the reports.
+type Writer interface {
+	Write(context.Context, []v1alpha1.SBOMReport) error
+}
+
+// Reader is the interface that wraps methods for finding v1alpha1.SBOMReport
+// objects and reading their documents.
+//
+// FindByOwner returns the slice of v1alpha1.SBOMReport instances owned by the
+// given kube.ObjectRef or an empty slice if the reports are not found.
+//
+// FindByOwnerInHierarchy is similar to FindByOwner except it tries to lookup
+// v1alpha1.SBOMReport objects owned by related Kubernetes objects. For
+// example, if the given owner is a Deployment, but reports are owned by the
+// active ReplicaSet (current revision) this method will return the reports.
+//
+// GetDocument returns the decoded SBOM document of the given report, reading
+// it from the referred ConfigMap if it's stored externally.
+type Reader interface {
+	FindByOwner(context.Context, kube.ObjectRef) ([]v1alpha1.SBOMReport, error)
+	FindByOwnerInHierarchy(ctx context.Context, object kube.ObjectRef) ([]v1alpha1.SBOMReport, error)
+	GetDocument(ctx context.Context, report v1alpha1.SBOMReport) ([]byte, error)
+}
+
+type ReadWriter interface {
+	Reader
+	Writer
+}
+
+type readWriter struct {
+	*kube.ObjectResolver
+}
+
+// NewReadWriter constructs a new ReadWriter which is using the client package
+// provided by the controller-runtime libraries for interacting with the
+// Kubernetes API server.
+func NewReadWriter(resolver *kube.ObjectResolver) ReadWriter {
+	return &readWriter{
+		ObjectResolver: resolver,
+	}
+}
+
+// GetDocumentConfigMapName returns the name of the ConfigMap that stores the
+// document of the v1alpha1.SBOMReport with the given name.
+func GetDocumentConfigMapName(reportName string) string {
+	return fmt.Sprintf("sbomreport-%s", reportName)
+}
+
+func (r *readWriter) Write(ctx context.Context, reports []v1alpha1.SBOMReport) error {
+	for _, report := range reports {
+		err := r.createOrUpdate(ctx, report)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *readWriter) createOrUpdate(ctx context.Context, report v1alpha1.SBOMReport) error {
+	var document string
+	if report.Report.DocumentRef == nil && len(report.Report.Document) > MaxInlineSize {
+		if len(report.Report.Document) > MaxExternalSize {
+			return fmt.Errorf("SBOM document of %s/%s is too large: %d bytes",
+				report.Namespace, report.Name, len(report.Report.Document))
+		}
+		document = report.Report.Document
+		report.Report.Document = ""
+		report.Report.DocumentRef = &v1alpha1.SBOMDocumentRef{
+			Kind: documentRefKindConfigMap,
+			Name: GetDocumentConfigMapName(report.Name),
+			Key:  documentKey,
+		}
+	}
+
+	var existing v1alpha1.SBOMReport
+	err := r.Get(ctx, types.NamespacedName{
+		Name:      report.Name,
+		Namespace: report.Namespace,
+	}, &existing)
+
+	switch {
+	case err == nil:
+		copied := existing.DeepCopy()
+		copied.Labels = report.Labels
+		copied.Report = report.Report
+		err = r.Update(ctx, copied)
+		report = *copied
+	case errors.IsNotFound(err):
+		err = r.Create(ctx, &report)
+	}
+	if err != nil {
+		return err
+	}
+
+	if document == "" {
+		return nil
+	}
+	return r.writeDocument(ctx, report, document)
+}
+
+// writeDocument stores the document of the given report in the ConfigMap
+// controlled by the report, so that it's deleted along with the report.
+func (r *readWriter) writeDocument(ctx context.Context, report v1alpha1.SBOMReport, document string) error {
+	ref := report.Report.DocumentRef
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ref.Name,
+			Namespace: report.Namespace,
+			Labels: map[string]string{
+				starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+			},
+		},
+		Data: map[string]string{
+			ref.Key: document,
+		},
+	}
+	err := controllerutil.SetControllerReference(&report, cm, r.Scheme())
+	if err != nil {
+		return fmt.Errorf("setting controller reference: %w", err)
+	}
+
+	var existing corev1.ConfigMap
+	err = r.Get(ctx, client.ObjectKeyFromObject(cm), &existing)
+	if err == nil {
+		copied := existing.DeepCopy()
+		copied.Labels = cm.Labels
+		copied.OwnerReferences = cm.OwnerReferences
+		copied.Data = cm.Data
+		return r.Update(ctx, copied)
+	}
+	if errors.IsNotFound(err) {
+		return r.Create(ctx, cm)
+	}
+	return err
+}
+
+func (r *readWriter) FindByOwner(ctx context.Context, owner kube.ObjectRef) ([]v1alpha1.SBOMReport, error) {
+	var list v1alpha1.SBOMReportList
+
+	labels := client.MatchingLabels(kube.ObjectRefToLabels(owner))
+
+	err := r.List(ctx, &list, labels, client.InNamespace(owner.Namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	return list.DeepCopy().Items, nil
+}
+
+func (r *readWriter) FindByOwnerInHierarchy(ctx context.Context, owner kube.ObjectRef) ([]v1alpha1.SBOMReport, error) {
+	reports, err := r.FindByOwner(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	// no reports found for provided owner, look for reports in related replicaset
+	if len(reports) == 0 && (owner.Kind == kube.KindDeployment || owner.Kind == kube.KindPod) {
+		rsName, err := r.RelatedReplicaSetName(ctx, owner)
+		if err != nil {
+			return nil, fmt.Errorf("getting replicaset related to %s/%s: %w", owner.Kind, owner.Name, err)
+		}
+		reports, err = r.FindByOwner(ctx, kube.ObjectRef{
+			Kind:      kube.KindReplicaSet,
+			Name:      rsName,
+			Namespace: owner.Namespace,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return reports, nil
+}
+
+func (r *readWriter) GetDocument(ctx context.Context, report v1alpha1.SBOMReport) ([]byte, error) {
+	ref := report.Report.DocumentRef
+	if ref == nil {
+		return DecodeDocument(report.Report.Encoding, report.Report.Document)
+	}
+	if ref.Kind != documentRefKindConfigMap {
+		return nil, fmt.Errorf("unsupported SBOM document reference kind: %s", ref.Kind)
+	}
+	var cm corev1.ConfigMap
+	err := r.Get(ctx, types.NamespacedName{Namespace: report.Namespace, Name: ref.Name}, &cm)
+	if err != nil {
+		return nil, fmt.Errorf("getting SBOM document from ConfigMap %s/%s: %w", report.Namespace, ref.Name, err)
+	}
+	document, ok := cm.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("SBOM document not found in ConfigMap %s/%s under key %s", report.Namespace, ref.Name, ref.Key)
+	}
+	return DecodeDocument(report.Report.Encoding, document)
+}
@@ -0,0 +1,135 @@
+package sbomreport_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/sbomreport"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReadWriter(t *testing.T) {
+
+	kubernetesScheme := starboard.NewScheme()
+
+	newReport := func(container string, document string) v1alpha1.SBOMReport {
+		return v1alpha1.SBOMReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "deployment-app1-" + container,
+				Namespace: "qa",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "Deployment",
+					starboard.LabelResourceName:      "app1",
+					starboard.LabelResourceNamespace: "qa",
+					starboard.LabelContainerName:     container,
+					starboard.LabelResourceSpecHash:  "h1",
+				},
+			},
+			Report: v1alpha1.SBOMReportData{
+				Format:   v1alpha1.SBOMFormatCycloneDX,
+				Document: document,
+			},
+		}
+	}
+
+	t.Run("Should create SBOMReports with inline documents", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+		readWriter := sbomreport.NewReadWriter(&resolver)
+
+		err := readWriter.Write(context.TODO(), []v1alpha1.SBOMReport{
+			newReport("container1", `{"bomFormat":"CycloneDX"}`),
+		})
+		require.NoError(t, err)
+
+		var report v1alpha1.SBOMReport
+		err = testClient.Get(context.TODO(), types.NamespacedName{Namespace: "qa", Name: "deployment-app1-container1"}, &report)
+		require.NoError(t, err)
+		assert.Nil(t, report.Report.DocumentRef)
+
+		document, err := readWriter.GetDocument(context.TODO(), report)
+		require.NoError(t, err)
+		assert.Equal(t, `{"bomFormat":"CycloneDX"}`, string(document))
+	})
+
+	t.Run("Should store large documents in ConfigMaps", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+		readWriter := sbomreport.NewReadWriter(&resolver)
+
+		largeDocument := strings.Repeat("A", sbomreport.MaxInlineSize+1)
+		err := readWriter.Write(context.TODO(), []v1alpha1.SBOMReport{
+			newReport("container1", largeDocument),
+		})
+		require.NoError(t, err)
+
+		var report v1alpha1.SBOMReport
+		err = testClient.Get(context.TODO(), types.NamespacedName{Namespace: "qa", Name: "deployment-app1-container1"}, &report)
+		require.NoError(t, err)
+		assert.Empty(t, report.Report.Document)
+		assert.Equal(t, &v1alpha1.SBOMDocumentRef{
+			Kind: "ConfigMap",
+			Name: "sbomreport-deployment-app1-container1",
+			Key:  "document",
+		}, report.Report.DocumentRef)
+
+		var cm corev1.ConfigMap
+		err = testClient.Get(context.TODO(), types.NamespacedName{Namespace: "qa", Name: "sbomreport-deployment-app1-container1"}, &cm)
+		require.NoError(t, err)
+		require.Len(t, cm.OwnerReferences, 1)
+		assert.Equal(t, "SBOMReport", cm.OwnerReferences[0].Kind)
+		assert.Equal(t, "deployment-app1-container1", cm.OwnerReferences[0].Name)
+
+		document, err := readWriter.GetDocument(context.TODO(), report)
+		require.NoError(t, err)
+		assert.Equal(t, largeDocument, string(document))
+	})
+
+	t.Run("Should return error for too large documents", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+		readWriter := sbomreport.NewReadWriter(&resolver)
+
+		err := readWriter.Write(context.TODO(), []v1alpha1.SBOMReport{
+			newReport("container1", strings.Repeat("A", sbomreport.MaxExternalSize+1)),
+		})
+		require.EqualError(t, err, "SBOM document of qa/deployment-app1-container1 is too large: 1024001 bytes")
+	})
+
+	t.Run("Should find SBOMReports by owner", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+		readWriter := sbomreport.NewReadWriter(&resolver)
+
+		err := readWriter.Write(context.TODO(), []v1alpha1.SBOMReport{
+			newReport("container1", "{}"),
+			newReport("container2", "{}"),
+		})
+		require.NoError(t, err)
+
+		reports, err := readWriter.FindByOwner(context.TODO(), kube.ObjectRef{
+			Kind:      kube.KindDeployment,
+			Name:      "app1",
+			Namespace: "qa",
+		})
+		require.NoError(t, err)
+		assert.Len(t, reports, 2)
+
+		reports, err = readWriter.FindByOwner(context.TODO(), kube.ObjectRef{
+			Kind:      kube.KindDeployment,
+			Name:      "app2",
+			Namespace: "qa",
+		})
+		require.NoError(t, err)
+		assert.Empty(t, reports)
+	})
+}
@@ -0,0 +1,37 @@
+package sbomreport
+
+import (
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Plugin defines the interface between Starboard and tools that generate
+// software bills of materials for container images.
+type Plugin interface {
+
+	// Init is a callback to initialize this plugin, e.g. ensure the default
+	// configuration.
+	Init(ctx starboard.PluginContext) error
+
+	// GetScanJobSpec describes the pod that will be created by Starboard when
+	// it schedules a Kubernetes job to generate SBOM documents in the given
+	// format for container images of the specified workload.
+	// The pod is expected to run one container for each container of the
+	// workload, with the same name, which prints the document.
+	// The last argument maps container names to Docker registry credentials,
+	// which can be passed to the generator as environment variables with
+	// values set from returned secrets.
+	GetScanJobSpec(ctx starboard.PluginContext, workload client.Object, format v1alpha1.SBOMFormat,
+		credentials map[string]docker.Auth) (corev1.PodSpec, []*corev1.Secret, error)
+
+	// ParseSBOMReportData is a callback to parse and convert logs of the
+	// container in a pod controlled by the scan job to v1alpha1.SBOMReportData.
+	// The returned data holds the document as is, i.e. without encoding.
+	ParseSBOMReportData(ctx starboard.PluginContext, imageRef string, format v1alpha1.SBOMFormat,
+		logsReader io.ReadCloser) (v1alpha1.SBOMReportData, error)
+}
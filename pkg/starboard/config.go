@@ -64,6 +64,8 @@ const (
 	keyScanJobAnnotations                = "scanJob.annotations"
 	keyScanJobPodTemplateLabels          = "scanJob.podTemplateLabels"
 	keyComplianceFailEntriesLimit        = "compliance.failEntriesLimit"
+	keyComplianceHistoryLimit            = "compliance.historyLimit"
+	keyComplianceDetailShardSize         = "compliance.detailShardSize"
 )
 
 // ConfigData holds Starboard configuration settings as a set of key-value
@@ -226,6 +228,38 @@ func (c ConfigData) ComplianceFailEntriesLimit() int {
 	return intVal
 }
 
+// ComplianceHistoryLimit returns the number of snapshots kept in the history
+// of a cluster compliance report.
+func (c ConfigData) ComplianceHistoryLimit() int {
+	const defaultValue = 10
+	value, ok := c[keyComplianceHistoryLimit]
+	if !ok {
+		return defaultValue
+	}
+	intVal, err := strconv.Atoi(value)
+	if err != nil || intVal < 1 {
+		return defaultValue
+	}
+	return intVal
+}
+
+// ComplianceDetailShardSize returns the maximum number of fail entries in a
+// single cluster compliance detail report. Larger detail reports are split
+// into several shards, which are also capped at 1 MiB of encoded entries to
+// stay under the etcd request size limit.
+func (c ConfigData) ComplianceDetailShardSize() int {
+	const defaultValue = 1000
+	value, ok := c[keyComplianceDetailShardSize]
+	if !ok {
+		return defaultValue
+	}
+	intVal, err := strconv.Atoi(value)
+	if err != nil || intVal < 1 {
+		return defaultValue
+	}
+	return intVal
+}
+
 // NewConfigManager constructs a new ConfigManager that is using kubernetes.Interface
 // to manage ConfigData backed by the ConfigMap stored in the specified namespace.
 func NewConfigManager(client kubernetes.Interface, namespace string) ConfigManager {
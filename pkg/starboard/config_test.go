@@ -296,6 +296,74 @@ func TestConfigData_GetComplianceFailEntriesLimit(t *testing.T) {
 	}
 }
 
+func TestConfigData_GetComplianceHistoryLimit(t *testing.T) {
+	testCases := []struct {
+		name       string
+		configData starboard.ConfigData
+		want       int
+	}{
+		{
+			name:       "Should return compliance history limit default value",
+			configData: starboard.ConfigData{},
+			want:       10,
+		},
+		{
+			name: "Should return compliance history limit from config data",
+			configData: starboard.ConfigData{
+				"compliance.historyLimit": "3",
+			},
+			want: 3,
+		},
+		{
+			name: "Should return compliance history limit default value when value is not positive",
+			configData: starboard.ConfigData{
+				"compliance.historyLimit": "0",
+			},
+			want: 10,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotLimit := tc.configData.ComplianceHistoryLimit()
+			assert.Equal(t, tc.want, gotLimit)
+		})
+	}
+}
+
+func TestConfigData_GetComplianceDetailShardSize(t *testing.T) {
+	testCases := []struct {
+		name       string
+		configData starboard.ConfigData
+		want       int
+	}{
+		{
+			name:       "Should return compliance detail shard size default value",
+			configData: starboard.ConfigData{},
+			want:       1000,
+		},
+		{
+			name: "Should return compliance detail shard size from config data",
+			configData: starboard.ConfigData{
+				"compliance.detailShardSize": "200",
+			},
+			want: 200,
+		},
+		{
+			name: "Should return compliance detail shard size default value when value is invalid",
+			configData: starboard.ConfigData{
+				"compliance.detailShardSize": "many",
+			},
+			want: 1000,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gotSize := tc.configData.ComplianceDetailShardSize()
+			assert.Equal(t, tc.want, gotSize)
+		})
+	}
+}
+
 func TestConfigData_GetKubeBenchImageRef(t *testing.T) {
 	testCases := []struct {
 		name             string
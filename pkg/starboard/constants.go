@@ -26,6 +26,10 @@ const (
 	// PoliciesConfigMapName the name of the ConfigMap used to store OPA Rego
 	// policies.
 	PoliciesConfigMapName = "starboard-policies-config"
+
+	// ExploitCatalogConfigMapName the name of the ConfigMap used to store the
+	// catalog of known exploited vulnerabilities and exploit probabilities.
+	ExploitCatalogConfigMapName = "starboard-exploit-catalog"
 )
 
 const (
@@ -39,12 +43,36 @@ const (
 
 	LabelConfigAuditReportScanner   = "configAuditReport.scanner"
 	LabelVulnerabilityReportScanner = "vulnerabilityReport.scanner"
+	LabelSBOMReportScanner          = "sbomReport.scanner"
 	LabelKubeBenchReportScanner     = "kubeBenchReport.scanner"
 
+	// LabelVEXDocument marks ConfigMaps that store OpenVEX documents.
+	LabelVEXDocument = "starboard.vex-document"
+
+	// LabelComplianceSpec marks ConfigMaps that store compliance specs.
+	LabelComplianceSpec = "starboard.compliance-spec"
+
+	// LabelComplianceReport is the name of the ClusterComplianceReport of a
+	// ClusterComplianceDetailReport shard.
+	LabelComplianceReport = "starboard.compliance-report"
+	// LabelComplianceShard is the index of a ClusterComplianceDetailReport
+	// shard.
+	LabelComplianceShard = "starboard.compliance-shard"
+
+	// LabelImageDigestPrefix is the prefix of labels that mark scan jobs
+	// scanning the container image with the hashed digest in the label key.
+	LabelImageDigestPrefix = "starboard.image-digest."
+
 	LabelK8SAppManagedBy = "app.kubernetes.io/managed-by"
 	AppStarboard         = "starboard"
 )
 
 const (
-	AnnotationContainerImages = "starboard.container-images"
+	AnnotationContainerImages       = "starboard.container-images"
+	AnnotationContainerImageDigests = "starboard.container-image-digests"
+
+	// AnnotationComplianceShardContinues marks a ClusterComplianceDetailReport
+	// shard whose first control check continues the last control check of the
+	// previous shard.
+	AnnotationComplianceShardContinues = "starboard.compliance-shard.continues"
 )
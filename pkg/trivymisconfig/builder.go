@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 type ScanJobBuilder struct {
@@ -174,7 +175,6 @@ func RegistryCredentialsSecretName(obj client.Object) string {
 type ReportBuilder struct {
 	scheme     *runtime.Scheme
 	controller client.Object
-	container  string
 	hash       string
 	data       v1alpha1.MisconfigurationReportData
 	reportTTL  *time.Duration
@@ -191,11 +191,6 @@ func (b *ReportBuilder) Controller(controller client.Object) *ReportBuilder {
 	return b
 }
 
-func (b *ReportBuilder) Container(name string) *ReportBuilder {
-	b.container = name
-	return b
-}
-
 func (b *ReportBuilder) PodSpecHash(hash string) *ReportBuilder {
 	b.hash = hash
 	return b
@@ -214,18 +209,15 @@ func (b *ReportBuilder) ReportTTL(ttl *time.Duration) *ReportBuilder {
 func (b *ReportBuilder) reportName() string {
 	kind := b.controller.GetObjectKind().GroupVersionKind().Kind
 	name := b.controller.GetName()
-	reportName := fmt.Sprintf("%s-%s-%s", strings.ToLower(kind), name, b.container)
+	reportName := fmt.Sprintf("%s-%s", strings.ToLower(kind), name)
 	if len(validation.IsValidLabelValue(reportName)) == 0 {
 		return reportName
 	}
-
-	return fmt.Sprintf("%s-%s", strings.ToLower(kind), kube.ComputeHash(name+"-"+b.container))
+	return fmt.Sprintf("%s-%s", strings.ToLower(kind), kube.ComputeHash(name))
 }
 
 func (b *ReportBuilder) Get() (v1alpha1.MisconfigurationReport, error) {
-	labels := map[string]string{
-		starboard.LabelContainerName: b.container,
-	}
+	labels := map[string]string{}
 
 	if b.hash != "" {
 		labels[starboard.LabelResourceSpecHash] = b.hash
@@ -240,27 +232,27 @@ func (b *ReportBuilder) Get() (v1alpha1.MisconfigurationReport, error) {
 		Report: b.data,
 	}
 
-	// if b.reportTTL != nil {
-	// 	report.Annotations = map[string]string{
-	// 		v1alpha1.TTLReportAnnotation: b.reportTTL.String(),
-	// 	}
-	// }
-	// err := kube.ObjectToObjectMeta(b.controller, &report.ObjectMeta)
-	// if err != nil {
-	// 	return v1alpha1.MisconfigurationReport{}, err
-	// }
-	// err = controllerutil.SetControllerReference(b.controller, &report, b.scheme)
-	// if err != nil {
-	// 	return v1alpha1.MisconfigurationReport{}, fmt.Errorf("setting controller reference: %w", err)
-	// }
-	// // The OwnerReferencesPermissionsEnforcement admission controller protects the
-	// // access to metadata.ownerReferences[x].blockOwnerDeletion of an object, so
-	// // that only users with "update" permission to the finalizers subresource of the
-	// // referenced owner can change it.
-	// // We set metadata.ownerReferences[x].blockOwnerDeletion to false so that
-	// // additional RBAC permissions are not required when the OwnerReferencesPermissionsEnforcement
-	// // is enabled.
-	// // See https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#ownerreferencespermissionenforcement
-	// report.OwnerReferences[0].BlockOwnerDeletion = pointer.BoolPtr(false)
+	if b.reportTTL != nil {
+		report.Annotations = map[string]string{
+			v1alpha1.TTLReportAnnotation: b.reportTTL.String(),
+		}
+	}
+	err := kube.ObjectToObjectMeta(b.controller, &report.ObjectMeta)
+	if err != nil {
+		return v1alpha1.MisconfigurationReport{}, err
+	}
+	err = controllerutil.SetControllerReference(b.controller, &report, b.scheme)
+	if err != nil {
+		return v1alpha1.MisconfigurationReport{}, fmt.Errorf("setting controller reference: %w", err)
+	}
+	// The OwnerReferencesPermissionsEnforcement admission controller protects the
+	// access to metadata.ownerReferences[x].blockOwnerDeletion of an object, so
+	// that only users with "update" permission to the finalizers subresource of the
+	// referenced owner can change it.
+	// We set metadata.ownerReferences[x].blockOwnerDeletion to false so that
+	// additional RBAC permissions are not required when the OwnerReferencesPermissionsEnforcement
+	// is enabled.
+	// See https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers/#ownerreferencespermissionenforcement
+	report.OwnerReferences[0].BlockOwnerDeletion = pointer.BoolPtr(false)
 	return report, nil
 }
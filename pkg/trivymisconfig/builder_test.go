@@ -0,0 +1,84 @@
+package trivymisconfig_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/trivymisconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestReportBuilder(t *testing.T) {
+	replicaSet := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "ReplicaSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-owner",
+			Namespace: "qa",
+		},
+	}
+
+	t.Run("Should build report owned by workload", func(t *testing.T) {
+		report, err := trivymisconfig.NewReportBuilder(starboard.NewScheme()).
+			Controller(replicaSet).
+			PodSpecHash("xyz").
+			ReportTTL(pointer.Duration(24 * time.Hour)).
+			Data(v1alpha1.MisconfigurationReportData{
+				Misconfigurations: []v1alpha1.Misconfiguration{{ID: "KSV001", Status: "FAIL"}},
+			}).
+			Get()
+		require.NoError(t, err)
+		assert.Equal(t, v1alpha1.MisconfigurationReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-some-owner",
+				Namespace: "qa",
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion:         "apps/v1",
+						Kind:               "ReplicaSet",
+						Name:               "some-owner",
+						Controller:         pointer.BoolPtr(true),
+						BlockOwnerDeletion: pointer.BoolPtr(false),
+					},
+				},
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "ReplicaSet",
+					starboard.LabelResourceName:      "some-owner",
+					starboard.LabelResourceNamespace: "qa",
+					starboard.LabelResourceSpecHash:  "xyz",
+				},
+				Annotations: map[string]string{
+					v1alpha1.TTLReportAnnotation: "24h0m0s",
+				},
+			},
+			Report: v1alpha1.MisconfigurationReportData{
+				Misconfigurations: []v1alpha1.Misconfiguration{{ID: "KSV001", Status: "FAIL"}},
+			},
+		}, report)
+	})
+
+	t.Run("Should hash name of report owned by workload with long name", func(t *testing.T) {
+		report, err := trivymisconfig.NewReportBuilder(starboard.NewScheme()).
+			Controller(&appsv1.ReplicaSet{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "apps/v1",
+					Kind:       "ReplicaSet",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "some-very-very-very-very-very-very-very-very-very-long-owner",
+					Namespace: "qa",
+				},
+			}).
+			Get()
+		require.NoError(t, err)
+		assert.Equal(t, "replicaset-75c449c47f", report.Name)
+	})
+}
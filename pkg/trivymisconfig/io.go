@@ -13,24 +13,24 @@ import (
 
 // Writer is the interface that wraps the basic Write method.
 //
-// Write creates or updates the given slice of v1alpha1.VulnerabilityReport
+// Write creates or updates the given slice of v1alpha1.MisconfigurationReport
 // instances.
 type Writer interface {
-	Write(context.Context, []v1alpha1.VulnerabilityReport) error
+	Write(context.Context, []v1alpha1.MisconfigurationReport) error
 }
 
-// Reader is the interface that wraps methods for finding v1alpha1.VulnerabilityReport objects.
+// Reader is the interface that wraps methods for finding v1alpha1.MisconfigurationReport objects.
 //
-// FindByOwner returns the slice of v1alpha1.VulnerabilityReport instances
+// FindByOwner returns the slice of v1alpha1.MisconfigurationReport instances
 // owned by the given kube.ObjectRef or an empty slice if the reports are not found.
 //
 // FindByOwnerInHierarchy is similar to FindByOwner except it tries to lookup
-// v1alpha1.VulnerabilityReport objects owned by related Kubernetes objects.
+// v1alpha1.MisconfigurationReport objects owned by related Kubernetes objects.
 // For example, if the given owner is a Deployment, but reports are owned by the
 // active ReplicaSet (current revision) this method will return the reports.
 type Reader interface {
-	FindByOwner(context.Context, kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error)
-	FindByOwnerInHierarchy(ctx context.Context, object kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error)
+	FindByOwner(context.Context, kube.ObjectRef) ([]v1alpha1.MisconfigurationReport, error)
+	FindByOwnerInHierarchy(ctx context.Context, object kube.ObjectRef) ([]v1alpha1.MisconfigurationReport, error)
 }
 
 type ReadWriter interface {
@@ -45,13 +45,13 @@ type readWriter struct {
 // NewReadWriter constructs a new ReadWriter which is using the client package
 // provided by the controller-runtime libraries for interacting with the
 // Kubernetes API server.
-func NewReadWriter(client client.Client) ReadWriter {
+func NewReadWriter(resolver *kube.ObjectResolver) ReadWriter {
 	return &readWriter{
-		ObjectResolver: &kube.ObjectResolver{Client: client},
+		ObjectResolver: resolver,
 	}
 }
 
-func (r *readWriter) Write(ctx context.Context, reports []v1alpha1.VulnerabilityReport) error {
+func (r *readWriter) Write(ctx context.Context, reports []v1alpha1.MisconfigurationReport) error {
 	for _, report := range reports {
 		err := r.createOrUpdate(ctx, report)
 		if err != nil {
@@ -61,8 +61,8 @@ func (r *readWriter) Write(ctx context.Context, reports []v1alpha1.Vulnerability
 	return nil
 }
 
-func (r *readWriter) createOrUpdate(ctx context.Context, report v1alpha1.VulnerabilityReport) error {
-	var existing v1alpha1.VulnerabilityReport
+func (r *readWriter) createOrUpdate(ctx context.Context, report v1alpha1.MisconfigurationReport) error {
+	var existing v1alpha1.MisconfigurationReport
 	err := r.Get(ctx, types.NamespacedName{
 		Name:      report.Name,
 		Namespace: report.Namespace,
@@ -83,8 +83,8 @@ func (r *readWriter) createOrUpdate(ctx context.Context, report v1alpha1.Vulnera
 	return err
 }
 
-func (r *readWriter) FindByOwner(ctx context.Context, owner kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error) {
-	var list v1alpha1.VulnerabilityReportList
+func (r *readWriter) FindByOwner(ctx context.Context, owner kube.ObjectRef) ([]v1alpha1.MisconfigurationReport, error) {
+	var list v1alpha1.MisconfigurationReportList
 
 	labels := client.MatchingLabels(kube.ObjectRefToLabels(owner))
 
@@ -96,7 +96,7 @@ func (r *readWriter) FindByOwner(ctx context.Context, owner kube.ObjectRef) ([]v
 	return list.DeepCopy().Items, nil
 }
 
-func (r *readWriter) FindByOwnerInHierarchy(ctx context.Context, owner kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error) {
+func (r *readWriter) FindByOwnerInHierarchy(ctx context.Context, owner kube.ObjectRef) ([]v1alpha1.MisconfigurationReport, error) {
 	reports, err := r.FindByOwner(ctx, owner)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,114 @@
+package trivymisconfig_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/trivymisconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReadWriter(t *testing.T) {
+
+	kubernetesScheme := starboard.NewScheme()
+
+	newReport := func(kind, name string, status v1alpha1.MisconfigurationStatus) v1alpha1.MisconfigurationReport {
+		return v1alpha1.MisconfigurationReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-" + name,
+				Namespace: "qa",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      kind,
+					starboard.LabelResourceName:      name,
+					starboard.LabelResourceNamespace: "qa",
+				},
+			},
+			Report: v1alpha1.MisconfigurationReportData{
+				Misconfigurations: []v1alpha1.Misconfiguration{{ID: "KSV001", Status: status}},
+			},
+		}
+	}
+
+	t.Run("Should create and update MisconfigurationReport", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).Build()
+		resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+		readWriter := trivymisconfig.NewReadWriter(&resolver)
+
+		err := readWriter.Write(context.TODO(), []v1alpha1.MisconfigurationReport{
+			newReport("ReplicaSet", "app-6d4cf56db6", "FAIL"),
+		})
+		require.NoError(t, err)
+		err = readWriter.Write(context.TODO(), []v1alpha1.MisconfigurationReport{
+			newReport("ReplicaSet", "app-6d4cf56db6", "PASS"),
+		})
+		require.NoError(t, err)
+
+		var found v1alpha1.MisconfigurationReport
+		err = testClient.Get(context.TODO(), types.NamespacedName{Namespace: "qa", Name: "replicaset-app-6d4cf56db6"}, &found)
+		require.NoError(t, err)
+		assert.Equal(t, "2", found.ResourceVersion)
+		assert.Equal(t, []v1alpha1.Misconfiguration{{ID: "KSV001", Status: "PASS"}}, found.Report.Misconfigurations)
+	})
+
+	t.Run("Should find MisconfigurationReport by owner in hierarchy", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "app",
+					Namespace: "qa",
+					Annotations: map[string]string{
+						"deployment.kubernetes.io/revision": "2",
+					},
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "app"},
+					},
+				},
+			},
+			&appsv1.ReplicaSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "app-6d4cf56db6",
+					Namespace: "qa",
+					Labels:    map[string]string{"app": "app"},
+					Annotations: map[string]string{
+						"deployment.kubernetes.io/revision": "2",
+					},
+				},
+			},
+		).Build()
+		resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+		readWriter := trivymisconfig.NewReadWriter(&resolver)
+
+		err := readWriter.Write(context.TODO(), []v1alpha1.MisconfigurationReport{
+			newReport("ReplicaSet", "app-6d4cf56db6", "FAIL"),
+			newReport("ReplicaSet", "other-7c4f8d8b9f", "FAIL"),
+		})
+		require.NoError(t, err)
+
+		reports, err := readWriter.FindByOwner(context.TODO(), kube.ObjectRef{
+			Kind:      kube.KindDeployment,
+			Name:      "app",
+			Namespace: "qa",
+		})
+		require.NoError(t, err)
+		assert.Empty(t, reports)
+
+		reports, err = readWriter.FindByOwnerInHierarchy(context.TODO(), kube.ObjectRef{
+			Kind:      kube.KindDeployment,
+			Name:      "app",
+			Namespace: "qa",
+		})
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, "replicaset-app-6d4cf56db6", reports[0].Name)
+	})
+}
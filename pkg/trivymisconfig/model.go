@@ -0,0 +1,36 @@
+package trivymisconfig
+
+import (
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+)
+
+// ToMisconfiguration converts a misconfiguration found by trivy to
+// v1alpha1.Misconfiguration. Unrecognized severities are mapped to
+// v1alpha1.SeverityUnknown.
+func ToMisconfiguration(m aquasecurity.Misconfiguration) v1alpha1.Misconfiguration {
+	severity, err := v1alpha1.StringToSeverity(m.Severity)
+	if err != nil {
+		severity = v1alpha1.SeverityUnknown
+	}
+	misconfiguration := v1alpha1.Misconfiguration{
+		ID:          m.ID,
+		AVDID:       m.AVDID,
+		Title:       m.Title,
+		Description: m.Description,
+		Severity:    severity,
+		Status:      v1alpha1.MisconfigurationStatus(m.Status),
+		Message:     m.Message,
+		Resolution:  m.Resolution,
+		PrimaryLink: m.PrimaryURL,
+		References:  m.References,
+	}
+	if m.CauseMetadata != nil {
+		misconfiguration.CauseMetadata = &v1alpha1.MisconfigurationCause{
+			Resource:  m.CauseMetadata.Resource,
+			StartLine: m.CauseMetadata.StartLine,
+			EndLine:   m.CauseMetadata.EndLine,
+		}
+	}
+	return misconfiguration
+}
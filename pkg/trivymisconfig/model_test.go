@@ -0,0 +1,62 @@
+package trivymisconfig_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/trivymisconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToMisconfiguration(t *testing.T) {
+	t.Run("Should convert misconfiguration with cause metadata", func(t *testing.T) {
+		misconfiguration := trivymisconfig.ToMisconfiguration(aquasecurity.Misconfiguration{
+			ID:          "KSV001",
+			AVDID:       "AVD-KSV-0001",
+			Title:       "Process can elevate its own privileges",
+			Description: "A program inside the container can elevate its own privileges.",
+			Message:     "Container 'nginx' should set 'securityContext.allowPrivilegeEscalation' to false",
+			Resolution:  "Set 'containers[].securityContext.allowPrivilegeEscalation' to 'false'.",
+			Severity:    "MEDIUM",
+			PrimaryURL:  "https://avd.aquasec.com/misconfig/ksv001",
+			References:  []string{"https://avd.aquasec.com/misconfig/ksv001"},
+			Status:      "FAIL",
+			CauseMetadata: &aquasecurity.CauseMetadata{
+				Resource:  "Deployment/nginx",
+				Provider:  "Kubernetes",
+				StartLine: 132,
+				EndLine:   143,
+			},
+		})
+		assert.Equal(t, v1alpha1.Misconfiguration{
+			ID:          "KSV001",
+			AVDID:       "AVD-KSV-0001",
+			Title:       "Process can elevate its own privileges",
+			Description: "A program inside the container can elevate its own privileges.",
+			Message:     "Container 'nginx' should set 'securityContext.allowPrivilegeEscalation' to false",
+			Resolution:  "Set 'containers[].securityContext.allowPrivilegeEscalation' to 'false'.",
+			Severity:    v1alpha1.SeverityMedium,
+			PrimaryLink: "https://avd.aquasec.com/misconfig/ksv001",
+			References:  []string{"https://avd.aquasec.com/misconfig/ksv001"},
+			Status:      v1alpha1.MisconfigurationStatusFail,
+			CauseMetadata: &v1alpha1.MisconfigurationCause{
+				Resource:  "Deployment/nginx",
+				StartLine: 132,
+				EndLine:   143,
+			},
+		}, misconfiguration)
+	})
+
+	t.Run("Should map unrecognized severity to unknown", func(t *testing.T) {
+		misconfiguration := trivymisconfig.ToMisconfiguration(aquasecurity.Misconfiguration{
+			ID:     "KSV002",
+			Status: "PASS",
+		})
+		assert.Equal(t, v1alpha1.Misconfiguration{
+			ID:       "KSV002",
+			Severity: v1alpha1.SeverityUnknown,
+			Status:   v1alpha1.MisconfigurationStatusPass,
+		}, misconfiguration)
+	})
+}
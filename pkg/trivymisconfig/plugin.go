@@ -9,6 +9,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ScanContainerName is the name of the container that runs the misconfiguration
+// scanner in the pod controlled by the scan job.
+const ScanContainerName = "trivymisconfigscancontainer"
+
 // Plugin defines the interface between Starboard and static vulnerability
 // scanners.
 type Plugin interface {
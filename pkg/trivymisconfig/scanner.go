@@ -3,6 +3,7 @@ package trivymisconfig
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
@@ -30,25 +31,27 @@ type Scanner struct {
 	secretsReader  kube.SecretsReader
 }
 
-// NewScanner constructs a new static vulnerability Scanner with the specified
+// NewScanner constructs a new misconfiguration Scanner with the specified
 // Plugin that knows how to perform the actual scanning,
 // which is performed by running a Kubernetes job, and knows how to convert logs
-// to instances of v1alpha1.VulnerabilityReport.
+// to instances of v1alpha1.MisconfigurationReport.
 func NewScanner(
 	clientset kubernetes.Interface,
 	client client.Client,
+	cm kube.CompatibleMgr,
 	plugin Plugin,
 	pluginContext starboard.PluginContext,
 	config starboard.ConfigData,
 	opts kube.ScannerOpts,
 ) *Scanner {
+	or := kube.NewObjectResolver(client, cm)
 	return &Scanner{
 		scheme:         client.Scheme(),
 		clientset:      clientset,
 		opts:           opts,
 		plugin:         plugin,
 		pluginContext:  pluginContext,
-		objectResolver: &kube.ObjectResolver{Client: client},
+		objectResolver: &or,
 		logsReader:     kube.NewLogsReader(clientset),
 		config:         config,
 		secretsReader:  kube.NewSecretsReader(client),
@@ -59,8 +62,8 @@ func NewScanner(
 // by the scan job has template contributed by the Plugin.
 // It is a blocking method that watches the status of the job until it succeeds
 // or fails. When succeeded it parses container logs and coverts the output
-// to instances of v1alpha1.VulnerabilityReport by delegating such transformation
-// logic also to the Plugin.
+// to instances of v1alpha1.MisconfigurationReport by delegating such
+// transformation logic also to the Plugin.
 func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1.MisconfigurationReport, error) {
 	klog.V(3).Infof("Getting Pod template for workload: %v", workload)
 
@@ -69,10 +72,10 @@ func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1
 		return nil, fmt.Errorf("resolving object: %w", err)
 	}
 
-	// owner, err := s.objectResolver.ReportOwner(ctx, workloadObj)
-	// if err != nil {
-	// 	return nil, err
-	// }
+	owner, err := s.objectResolver.ReportOwner(ctx, workloadObj)
+	if err != nil {
+		return nil, fmt.Errorf("resolving report owner: %w", err)
+	}
 
 	scanJobTolerations, err := s.config.GetScanJobTolerations()
 	if err != nil {
@@ -100,7 +103,7 @@ func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1
 		WithPlugin(s.plugin).
 		WithPluginContext(s.pluginContext).
 		WithTimeout(s.opts.ScanJobTimeout).
-		WithObject(workloadObj).
+		WithObject(owner).
 		WithTolerations(scanJobTolerations).
 		WithAnnotations(scanJobAnnotations).
 		WithPodTemplateLabels(scanJobPodTemplateLabels).
@@ -132,43 +135,50 @@ func (s *Scanner) Scan(ctx context.Context, workload kube.ObjectRef) ([]v1alpha1
 	return s.getMisconfigurationReportsByScanJob(ctx, job)
 }
 
-// TODO To make this method look the same as the one used by the operator we
-// should resolve the owner based on labels set on the given job instead of
-// passing owner directly. The goal is for CLI and operator to create jobs
-// with the same struct and set of labels to reuse code responsible for parsing
-// v1alpha1.VulnerabilityReport instances.
+// getMisconfigurationReportsByScanJob parses logs of the given scan job and
+// returns the report owned by the object encoded in the job's labels.
 func (s *Scanner) getMisconfigurationReportsByScanJob(ctx context.Context, job *batchv1.Job) ([]v1alpha1.MisconfigurationReport, error) {
-	var reports []v1alpha1.MisconfigurationReport
-
-	// containerImages, err := kube.GetContainerImagesFromJob(job)
-	// if err != nil {
-	// 	return nil, fmt.Errorf("getting container images: %w", err)
-	// }
+	ownerRef, err := kube.ObjectRefFromObjectMeta(job.ObjectMeta)
+	if err != nil {
+		return nil, fmt.Errorf("getting owner ref from scan job metadata: %w", err)
+	}
+	owner, err := s.objectResolver.ObjectFromObjectRef(ctx, ownerRef)
+	if err != nil {
+		return nil, fmt.Errorf("getting owner object from ref: %w", err)
+	}
 
-	// for containerName, containerImage := range containerImages {
-	containerName := "trivymisconfigscancontainer" // TODO: Sync with plugin.go
-	klog.V(3).Infof("Getting logs for %s container in job: %s/%s", containerName, job.Namespace, job.Name)
-	logsStream, err := s.logsReader.GetLogsByJobAndContainerName(ctx, job, containerName)
+	klog.V(3).Infof("Getting logs for %s container in job: %s/%s", ScanContainerName, job.Namespace, job.Name)
+	logsStream, err := s.logsReader.GetLogsByJobAndContainerName(ctx, job, ScanContainerName)
 	if err != nil {
 		return nil, err
 	}
-	result, err := s.plugin.ParseMisconfigurationReportData(s.pluginContext, logsStream)
+	var stream io.ReadCloser = logsStream
+	if s.opts.RawReportWriter != nil {
+		stream = io.NopCloser(io.TeeReader(logsStream, s.opts.RawReportWriter))
+	}
+	result, err := s.plugin.ParseMisconfigurationReportData(s.pluginContext, stream)
 	if err != nil {
+		_ = logsStream.Close()
 		return nil, err
 	}
+	if s.opts.RawReportWriter != nil {
+		_, err = io.Copy(io.Discard, stream)
+		if err != nil {
+			_ = logsStream.Close()
+			return nil, fmt.Errorf("copying raw report: %w", err)
+		}
+	}
 
 	_ = logsStream.Close()
 
-	report := v1alpha1.MisconfigurationReport{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "report-123",
-			Namespace: "cloudcasa-io",
-		},
-		Report: result,
+	report, err := NewReportBuilder(s.scheme).
+		Controller(owner).
+		PodSpecHash(job.Labels[starboard.LabelResourceSpecHash]).
+		Data(result).
+		Get()
+	if err != nil {
+		return nil, err
 	}
 
-	reports = append(reports, report)
-
-	// }
-	return reports, nil
+	return []v1alpha1.MisconfigurationReport{report}, nil
 }
@@ -0,0 +1,366 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/google/go-containerregistry/pkg/name"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// AdmissionWebhookPath is the path at which the AdmissionHandler is served.
+const AdmissionWebhookPath = "/validate-vulnerabilities"
+
+// AdmissionAction is the action taken by the AdmissionHandler when a
+// container image violates the AdmissionPolicy or has not been scanned yet.
+type AdmissionAction string
+
+const (
+	AdmissionActionAllow AdmissionAction = "allow"
+	AdmissionActionWarn  AdmissionAction = "warn"
+	AdmissionActionDeny  AdmissionAction = "deny"
+)
+
+const (
+	keyAdmissionAction          = "admission.action"
+	keyAdmissionMaxSeverity     = "admission.maxSeverity"
+	keyAdmissionMaxCounts       = "admission.maxCounts"
+	keyAdmissionFixableOnly     = "admission.fixableOnly"
+	keyAdmissionExceptionAware  = "admission.exceptionAware"
+	keyAdmissionUnscannedImages = "admission.unscannedImages"
+)
+
+// AdmissionPolicy defines which vulnerabilities of container images are
+// acceptable for admission.
+type AdmissionPolicy struct {
+	// Action is taken when an image violates the policy, either
+	// AdmissionActionDeny or AdmissionActionWarn.
+	Action AdmissionAction
+	// MaxSeverity is the highest acceptable severity of vulnerabilities. Empty
+	// means any severity is acceptable.
+	MaxSeverity v1alpha1.Severity
+	// MaxCounts is the acceptable number of vulnerabilities by severity.
+	MaxCounts map[v1alpha1.Severity]int
+	// FixableOnly restricts the policy to vulnerabilities with a fixed version.
+	FixableOnly bool
+	// ExceptionAware excludes vulnerabilities suppressed by active
+	// v1alpha1.VulnerabilityException instances from the policy.
+	ExceptionAware bool
+	// UnscannedImages is the action taken for images without reports, either
+	// AdmissionActionAllow or AdmissionActionWarn. Images are scanned once a
+	// workload which runs them exists, so denying unscanned images would deny
+	// new images forever.
+	UnscannedImages AdmissionAction
+}
+
+// GetAdmissionPolicy returns the AdmissionPolicy configured by the admission.*
+// properties of the given starboard.ConfigData.
+func GetAdmissionPolicy(config starboard.ConfigData) (AdmissionPolicy, error) {
+	policy := AdmissionPolicy{
+		Action:          AdmissionActionDeny,
+		MaxCounts:       map[v1alpha1.Severity]int{},
+		ExceptionAware:  true,
+		UnscannedImages: AdmissionActionAllow,
+	}
+	if value, ok := config[keyAdmissionAction]; ok {
+		policy.Action = AdmissionAction(value)
+		if policy.Action != AdmissionActionDeny && policy.Action != AdmissionActionWarn {
+			return AdmissionPolicy{}, fmt.Errorf("property %s must be either %q or %q, got %q",
+				keyAdmissionAction, AdmissionActionDeny, AdmissionActionWarn, value)
+		}
+	}
+	if value, ok := config[keyAdmissionMaxSeverity]; ok && value != "" {
+		severity, err := v1alpha1.StringToSeverity(value)
+		if err != nil {
+			return AdmissionPolicy{}, fmt.Errorf("property %s: %w", keyAdmissionMaxSeverity, err)
+		}
+		if _, ok := severityOrder[severity]; !ok {
+			return AdmissionPolicy{}, fmt.Errorf("property %s: unsupported severity %s", keyAdmissionMaxSeverity, severity)
+		}
+		policy.MaxSeverity = severity
+	}
+	if value, ok := config[keyAdmissionMaxCounts]; ok && strings.TrimSpace(value) != "" {
+		for _, entry := range strings.Split(value, ",") {
+			sepByEqual := strings.Split(entry, "=")
+			if len(sepByEqual) != 2 {
+				return AdmissionPolicy{}, fmt.Errorf("failed parsing incorrectly formatted %s: %s", keyAdmissionMaxCounts, value)
+			}
+			severity, err := v1alpha1.StringToSeverity(strings.TrimSpace(sepByEqual[0]))
+			if err != nil {
+				return AdmissionPolicy{}, fmt.Errorf("property %s: %w", keyAdmissionMaxCounts, err)
+			}
+			count, err := strconv.Atoi(strings.TrimSpace(sepByEqual[1]))
+			if err != nil || count < 0 {
+				return AdmissionPolicy{}, fmt.Errorf("property %s: invalid count %q", keyAdmissionMaxCounts, sepByEqual[1])
+			}
+			policy.MaxCounts[severity] = count
+		}
+	}
+	for key, target := range map[string]*bool{
+		keyAdmissionFixableOnly:    &policy.FixableOnly,
+		keyAdmissionExceptionAware: &policy.ExceptionAware,
+	} {
+		if value, ok := config[key]; ok {
+			if value != "false" && value != "true" {
+				return AdmissionPolicy{}, fmt.Errorf("property %s must be either \"false\" or \"true\", got %q", key, value)
+			}
+			*target = value == "true"
+		}
+	}
+	if value, ok := config[keyAdmissionUnscannedImages]; ok {
+		policy.UnscannedImages = AdmissionAction(value)
+		if policy.UnscannedImages != AdmissionActionAllow && policy.UnscannedImages != AdmissionActionWarn {
+			return AdmissionPolicy{}, fmt.Errorf("property %s must be either %q or %q, got %q",
+				keyAdmissionUnscannedImages, AdmissionActionAllow, AdmissionActionWarn, value)
+		}
+	}
+	return policy, nil
+}
+
+// Evaluate returns violations of the AdmissionPolicy by vulnerabilities of
+// the given v1alpha1.VulnerabilityReportData, or an empty slice if there are
+// none. Vulnerabilities that do not affect the image according to a VEX
+// document never violate the policy.
+func (p AdmissionPolicy) Evaluate(data v1alpha1.VulnerabilityReportData) []string {
+	violations := make([]string, 0)
+	counts := map[v1alpha1.Severity]int{}
+	var exceeding []string
+	for _, vulnerability := range data.Vulnerabilities {
+		if vulnerability.VEXStatus == v1alpha1.VEXStatusNotAffected {
+			continue
+		}
+		if p.ExceptionAware && vulnerability.Suppressed {
+			continue
+		}
+		if p.FixableOnly && vulnerability.FixedVersion == "" {
+			continue
+		}
+		counts[vulnerability.Severity]++
+		if order, ok := severityOrder[vulnerability.Severity]; ok && p.MaxSeverity != "" && order < severityOrder[p.MaxSeverity] {
+			exceeding = append(exceeding, fmt.Sprintf("%s (%s)", vulnerability.VulnerabilityID, vulnerability.Severity))
+		}
+	}
+	if len(exceeding) > 0 {
+		violations = append(violations, fmt.Sprintf("vulnerabilities exceed maximum severity %s: %s",
+			p.MaxSeverity, strings.Join(exceeding, ", ")))
+	}
+	for _, severity := range []v1alpha1.Severity{
+		v1alpha1.SeverityCritical,
+		v1alpha1.SeverityHigh,
+		v1alpha1.SeverityMedium,
+		v1alpha1.SeverityLow,
+		v1alpha1.SeverityUnknown,
+	} {
+		if max, ok := p.MaxCounts[severity]; ok && counts[severity] > max {
+			violations = append(violations, fmt.Sprintf("%d %s vulnerabilities exceed maximum count %d",
+				counts[severity], severity, max))
+		}
+	}
+	return violations
+}
+
+// AdmissionHandler is a validating admission webhook handler, which denies or
+// warns about Pods and workloads whose container images have vulnerabilities
+// that violate the AdmissionPolicy.
+//
+// Vulnerabilities of an image are read from v1alpha1.VulnerabilityReport
+// instances in the namespace of the admitted object or from the
+// v1alpha1.ClusterVulnerabilityReport of the image, which are looked up by
+// the image digest, or by the image registry, repository and tag if the image
+// is referenced by tag.
+//
+// If the APIReader is set, vulnerabilities are annotated with VEXDocuments
+// read from the operator namespace.
+type AdmissionHandler struct {
+	logr.Logger
+	client.Client
+	Reader
+	ext.Clock
+	Policy    AdmissionPolicy
+	Namespace string
+	APIReader client.Reader
+
+	decoder *admission.Decoder
+}
+
+// InjectDecoder implements admission.DecoderInjector.
+func (h *AdmissionHandler) InjectDecoder(decoder *admission.Decoder) error {
+	h.decoder = decoder
+	return nil
+}
+
+// Handle implements admission.Handler.
+func (h *AdmissionHandler) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+	obj, err := h.Client.Scheme().New(schema.GroupVersionKind(req.Kind))
+	if err != nil {
+		return admission.Allowed("")
+	}
+	workload, ok := obj.(client.Object)
+	if !ok {
+		return admission.Allowed("")
+	}
+	err = h.decoder.Decode(req, workload)
+	if err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if workload.GetNamespace() == "" {
+		workload.SetNamespace(req.Namespace)
+	}
+	podSpec, err := kube.GetPodSpec(workload)
+	if err != nil {
+		return admission.Allowed("")
+	}
+
+	denials, warnings, err := h.validate(ctx, workload, kube.GetContainerImagesFromPodSpec(podSpec))
+	if err != nil {
+		h.Logger.Error(err, "Validating container images", "kind", req.Kind.Kind,
+			"name", req.Namespace+"/"+req.Name)
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(denials) > 0 {
+		return admission.Denied(strings.Join(denials, "; ")).WithWarnings(warnings...)
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
+
+// validate returns messages of container images that are denied and of
+// container images that are admitted with warnings.
+func (h *AdmissionHandler) validate(ctx context.Context, workload client.Object, images kube.ContainerImages) ([]string, []string, error) {
+	var vex VEXDocuments
+	var err error
+	if h.APIReader != nil {
+		var invalid []InvalidVEXDocument
+		vex, invalid, err = LoadVEXDocuments(ctx, h.APIReader, h.Namespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, document := range invalid {
+			h.Logger.Error(document.Err, "Skipping invalid VEX document", "configMap", client.ObjectKeyFromObject(&document.ConfigMap))
+		}
+	}
+	var exceptions []v1alpha1.VulnerabilityException
+	if h.Policy.ExceptionAware {
+		exceptions, err = ListActiveExceptions(ctx, h.Client, workload.GetNamespace(), h.Clock.Now())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	containerNames := make([]string, 0, len(images))
+	for containerName := range images {
+		containerNames = append(containerNames, containerName)
+	}
+	sort.Strings(containerNames)
+
+	var denials, warnings []string
+	for _, containerName := range containerNames {
+		image := images[containerName]
+		data, found, err := h.findReportData(ctx, workload.GetNamespace(), image)
+		if err != nil {
+			return nil, nil, err
+		}
+		var action AdmissionAction
+		var message string
+		if !found {
+			action = h.Policy.UnscannedImages
+			message = fmt.Sprintf("container %s: image %s has not been scanned for vulnerabilities", containerName, image)
+		} else {
+			vex.AnnotateReportData(&data)
+			if exceptions != nil {
+				ApplyExceptions(&data, workload, exceptions)
+			}
+			violations := h.Policy.Evaluate(data)
+			if len(violations) == 0 {
+				continue
+			}
+			action = h.Policy.Action
+			message = fmt.Sprintf("container %s: image %s: %s", containerName, image, strings.Join(violations, "; "))
+		}
+		switch action {
+		case AdmissionActionDeny:
+			denials = append(denials, message)
+		case AdmissionActionWarn:
+			warnings = append(warnings, message)
+		}
+	}
+	return denials, warnings, nil
+}
+
+// findReportData returns the scan results of the given image, which are read
+// from a v1alpha1.VulnerabilityReport in the given namespace or from a
+// v1alpha1.ClusterVulnerabilityReport. Images referenced by digest are looked
+// up by the digest. Images referenced by tag are looked up by the registry,
+// repository and tag, and the most recently updated report is used, since the
+// tag might have been moved to another image.
+func (h *AdmissionHandler) findReportData(ctx context.Context, namespace, image string) (v1alpha1.VulnerabilityReportData, bool, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, false, nil
+	}
+	if tag, ok := ref.(name.Tag); ok {
+		return h.findReportDataByTag(ctx, namespace, tag)
+	}
+	digest := ref.Identifier()
+	reports, err := h.Reader.FindByImageDigest(ctx, namespace, digest)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, false, fmt.Errorf("finding vulnerability reports by image digest: %w", err)
+	}
+	if len(reports) > 0 {
+		return reports[0].Report, true, nil
+	}
+	report, err := h.Reader.FindClusterReportByImageDigest(ctx, digest)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, false, fmt.Errorf("finding cluster vulnerability report by image digest: %w", err)
+	}
+	if report == nil {
+		return v1alpha1.VulnerabilityReportData{}, false, nil
+	}
+	return report.Report, true, nil
+}
+
+// findReportDataByTag returns the most recently updated scan results of the
+// image with the given tag.
+func (h *AdmissionHandler) findReportDataByTag(ctx context.Context, namespace string, tag name.Tag) (v1alpha1.VulnerabilityReportData, bool, error) {
+	reports, err := h.Reader.FindByImageTag(ctx, namespace, tag)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, false, fmt.Errorf("finding vulnerability reports by image tag: %w", err)
+	}
+	candidates := make([]v1alpha1.VulnerabilityReportData, 0, len(reports))
+	for _, report := range reports {
+		candidates = append(candidates, report.Report)
+	}
+	if len(candidates) == 0 {
+		clusterReports, err := h.Reader.FindClusterReportsByImageTag(ctx, tag)
+		if err != nil {
+			return v1alpha1.VulnerabilityReportData{}, false, fmt.Errorf("finding cluster vulnerability reports by image tag: %w", err)
+		}
+		for _, report := range clusterReports {
+			candidates = append(candidates, report.Report)
+		}
+	}
+	if len(candidates) == 0 {
+		return v1alpha1.VulnerabilityReportData{}, false, nil
+	}
+	latest := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if candidate.UpdateTimestamp.After(latest.UpdateTimestamp.Time) {
+			latest = candidate
+		}
+	}
+	return latest, true, nil
+}
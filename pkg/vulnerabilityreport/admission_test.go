@@ -0,0 +1,301 @@
+package vulnerabilityreport_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestGetAdmissionPolicy(t *testing.T) {
+	t.Run("Should return default policy", func(t *testing.T) {
+		policy, err := vulnerabilityreport.GetAdmissionPolicy(starboard.ConfigData{})
+		require.NoError(t, err)
+		assert.Equal(t, vulnerabilityreport.AdmissionPolicy{
+			Action:          vulnerabilityreport.AdmissionActionDeny,
+			MaxCounts:       map[v1alpha1.Severity]int{},
+			ExceptionAware:  true,
+			UnscannedImages: vulnerabilityreport.AdmissionActionAllow,
+		}, policy)
+	})
+
+	t.Run("Should return configured policy", func(t *testing.T) {
+		policy, err := vulnerabilityreport.GetAdmissionPolicy(starboard.ConfigData{
+			"admission.action":          "warn",
+			"admission.maxSeverity":     "high",
+			"admission.maxCounts":       "CRITICAL=0, HIGH=10",
+			"admission.fixableOnly":     "true",
+			"admission.exceptionAware":  "false",
+			"admission.unscannedImages": "warn",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, vulnerabilityreport.AdmissionPolicy{
+			Action:      vulnerabilityreport.AdmissionActionWarn,
+			MaxSeverity: v1alpha1.SeverityHigh,
+			MaxCounts: map[v1alpha1.Severity]int{
+				v1alpha1.SeverityCritical: 0,
+				v1alpha1.SeverityHigh:     10,
+			},
+			FixableOnly:     true,
+			UnscannedImages: vulnerabilityreport.AdmissionActionWarn,
+		}, policy)
+	})
+
+	testCases := []struct {
+		name          string
+		config        starboard.ConfigData
+		expectedError string
+	}{
+		{
+			name:          "Should return error when action is invalid",
+			config:        starboard.ConfigData{"admission.action": "allow"},
+			expectedError: `property admission.action must be either "deny" or "warn", got "allow"`,
+		},
+		{
+			name:          "Should return error when max severity is invalid",
+			config:        starboard.ConfigData{"admission.maxSeverity": "SEVERE"},
+			expectedError: "property admission.maxSeverity: unrecognized name literal: SEVERE",
+		},
+		{
+			name:          "Should return error when max counts are incorrectly formatted",
+			config:        starboard.ConfigData{"admission.maxCounts": "CRITICAL"},
+			expectedError: "failed parsing incorrectly formatted admission.maxCounts: CRITICAL",
+		},
+		{
+			name:          "Should return error when max count is negative",
+			config:        starboard.ConfigData{"admission.maxCounts": "HIGH=-1"},
+			expectedError: `property admission.maxCounts: invalid count "-1"`,
+		},
+		{
+			name:          "Should return error when unscanned images action is invalid",
+			config:        starboard.ConfigData{"admission.unscannedImages": "block"},
+			expectedError: `property admission.unscannedImages must be either "allow" or "warn", got "block"`,
+		},
+		{
+			name:          "Should return error when unscanned images are denied",
+			config:        starboard.ConfigData{"admission.unscannedImages": "deny"},
+			expectedError: `property admission.unscannedImages must be either "allow" or "warn", got "deny"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := vulnerabilityreport.GetAdmissionPolicy(tc.config)
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestAdmissionPolicy_Evaluate(t *testing.T) {
+	data := v1alpha1.VulnerabilityReportData{
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2022-1292", Severity: v1alpha1.SeverityCritical, FixedVersion: "1.1.1n-0+deb11u2"},
+			{VulnerabilityID: "CVE-2022-2068", Severity: v1alpha1.SeverityCritical},
+			{VulnerabilityID: "CVE-2022-2097", Severity: v1alpha1.SeverityHigh, Suppressed: true},
+			{VulnerabilityID: "CVE-2021-3711", Severity: v1alpha1.SeverityCritical, VEXStatus: v1alpha1.VEXStatusNotAffected},
+			{VulnerabilityID: "CVE-2021-3712", Severity: v1alpha1.SeverityLow},
+		},
+	}
+	testCases := []struct {
+		name               string
+		policy             vulnerabilityreport.AdmissionPolicy
+		expectedViolations []string
+	}{
+		{
+			name:   "Should report vulnerabilities exceeding max severity",
+			policy: vulnerabilityreport.AdmissionPolicy{MaxSeverity: v1alpha1.SeverityHigh, ExceptionAware: true},
+			expectedViolations: []string{
+				"vulnerabilities exceed maximum severity HIGH: CVE-2022-1292 (CRITICAL), CVE-2022-2068 (CRITICAL)",
+			},
+		},
+		{
+			name:   "Should report counts exceeding max counts",
+			policy: vulnerabilityreport.AdmissionPolicy{MaxCounts: map[v1alpha1.Severity]int{v1alpha1.SeverityCritical: 1, v1alpha1.SeverityHigh: 0}},
+			expectedViolations: []string{
+				"2 CRITICAL vulnerabilities exceed maximum count 1",
+				"1 HIGH vulnerabilities exceed maximum count 0",
+			},
+		},
+		{
+			name:               "Should ignore suppressed vulnerabilities",
+			policy:             vulnerabilityreport.AdmissionPolicy{MaxCounts: map[v1alpha1.Severity]int{v1alpha1.SeverityHigh: 0}, ExceptionAware: true},
+			expectedViolations: []string{},
+		},
+		{
+			name:   "Should only take fixable vulnerabilities into account",
+			policy: vulnerabilityreport.AdmissionPolicy{MaxSeverity: v1alpha1.SeverityMedium, FixableOnly: true},
+			expectedViolations: []string{
+				"vulnerabilities exceed maximum severity MEDIUM: CVE-2022-1292 (CRITICAL)",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedViolations, tc.policy.Evaluate(data))
+		})
+	}
+}
+
+func TestAdmissionHandler_Handle(t *testing.T) {
+	const digest = "sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767"
+	now := time.Date(2022, time.September, 1, 10, 0, 0, 0, time.UTC)
+	scheme := starboard.NewScheme()
+	decoder, err := admission.NewDecoder(scheme)
+	require.NoError(t, err)
+
+	clusterReport := &v1alpha1.ClusterVulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{Name: vulnerabilityreport.GetClusterReportName(digest)},
+		Report: v1alpha1.VulnerabilityReportData{
+			Artifact: v1alpha1.Artifact{Repository: "library/nginx", Digest: digest},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2022-1292", Resource: "openssl", Severity: v1alpha1.SeverityCritical},
+			},
+		},
+	}
+	// the tag nginx:1.16 was moved to a patched image, which is scanned later
+	newTagReport := func(name string, updated time.Time, vulnerabilities ...v1alpha1.Vulnerability) *v1alpha1.VulnerabilityReport {
+		return &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Report: v1alpha1.VulnerabilityReportData{
+				UpdateTimestamp: metav1.NewTime(updated),
+				Registry:        v1alpha1.Registry{Server: "index.docker.io"},
+				Artifact:        v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+				Vulnerabilities: vulnerabilities,
+			},
+		}
+	}
+	oldTagReport := newTagReport("replicaset-nginx-6d4cf56db6-nginx", now.Add(-time.Hour),
+		v1alpha1.Vulnerability{VulnerabilityID: "CVE-2022-1292", Resource: "openssl", Severity: v1alpha1.SeverityCritical})
+	tagReport := newTagReport("replicaset-nginx-7d8b4c9f5d-nginx", now,
+		v1alpha1.Vulnerability{VulnerabilityID: "CVE-2022-2068", Resource: "openssl", Severity: v1alpha1.SeverityHigh})
+	exception := newException("accept-openssl", v1alpha1.VulnerabilityExceptionSpec{
+		Resource:  "openssl",
+		ExpiresAt: metav1.NewTime(now.Add(time.Hour)),
+	})
+
+	newRequest := func(t *testing.T, namespace string, obj runtime.Object, kind string) admission.Request {
+		raw, err := json.Marshal(obj)
+		require.NoError(t, err)
+		group := "apps"
+		if kind == "Pod" {
+			group = ""
+		}
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Namespace: namespace,
+			Kind:      metav1.GroupVersionKind{Group: group, Version: "v1", Kind: kind},
+			Object:    runtime.RawExtension{Raw: raw},
+		}}
+	}
+	newDeployment := func(image string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "nginx", Image: image}},
+					},
+				},
+			},
+		}
+	}
+	newHandler := func(policy vulnerabilityreport.AdmissionPolicy) *vulnerabilityreport.AdmissionHandler {
+		testClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(clusterReport, oldTagReport, tagReport, &exception).Build()
+		resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+		handler := &vulnerabilityreport.AdmissionHandler{
+			Client: testClient,
+			Reader: vulnerabilityreport.NewReadWriter(&resolver),
+			Clock:  ext.NewFixedClock(now),
+			Policy: policy,
+		}
+		require.NoError(t, handler.InjectDecoder(decoder))
+		return handler
+	}
+
+	t.Run("Should deny image with vulnerabilities exceeding max severity", func(t *testing.T) {
+		handler := newHandler(vulnerabilityreport.AdmissionPolicy{
+			Action:      vulnerabilityreport.AdmissionActionDeny,
+			MaxSeverity: v1alpha1.SeverityHigh,
+		})
+		response := handler.Handle(context.TODO(), newRequest(t, "default", newDeployment("nginx@"+digest), "Deployment"))
+		assert.False(t, response.Allowed)
+		assert.Equal(t, "container nginx: image nginx@"+digest+": vulnerabilities exceed maximum severity HIGH: CVE-2022-1292 (CRITICAL)",
+			string(response.Result.Reason))
+	})
+
+	t.Run("Should allow image with suppressed vulnerabilities", func(t *testing.T) {
+		handler := newHandler(vulnerabilityreport.AdmissionPolicy{
+			Action:         vulnerabilityreport.AdmissionActionDeny,
+			MaxSeverity:    v1alpha1.SeverityHigh,
+			ExceptionAware: true,
+		})
+		response := handler.Handle(context.TODO(), newRequest(t, "default", newDeployment("nginx@"+digest), "Deployment"))
+		assert.True(t, response.Allowed)
+		assert.Empty(t, response.Warnings)
+	})
+
+	t.Run("Should warn about image with vulnerabilities", func(t *testing.T) {
+		handler := newHandler(vulnerabilityreport.AdmissionPolicy{
+			Action:    vulnerabilityreport.AdmissionActionWarn,
+			MaxCounts: map[v1alpha1.Severity]int{v1alpha1.SeverityCritical: 0},
+		})
+		response := handler.Handle(context.TODO(), newRequest(t, "default", newDeployment("nginx@"+digest), "Deployment"))
+		assert.True(t, response.Allowed)
+		assert.Equal(t, []string{
+			"container nginx: image nginx@" + digest + ": 1 CRITICAL vulnerabilities exceed maximum count 0",
+		}, response.Warnings)
+	})
+
+	t.Run("Should deny image referenced by tag with vulnerabilities exceeding max severity", func(t *testing.T) {
+		handler := newHandler(vulnerabilityreport.AdmissionPolicy{
+			Action:      vulnerabilityreport.AdmissionActionDeny,
+			MaxSeverity: v1alpha1.SeverityMedium,
+		})
+		response := handler.Handle(context.TODO(), newRequest(t, "default", newDeployment("nginx:1.16"), "Deployment"))
+		assert.False(t, response.Allowed)
+		assert.Equal(t, "container nginx: image nginx:1.16: vulnerabilities exceed maximum severity MEDIUM: CVE-2022-2068 (HIGH)",
+			string(response.Result.Reason))
+	})
+
+	t.Run("Should warn about unscanned image", func(t *testing.T) {
+		handler := newHandler(vulnerabilityreport.AdmissionPolicy{
+			Action:          vulnerabilityreport.AdmissionActionDeny,
+			UnscannedImages: vulnerabilityreport.AdmissionActionWarn,
+		})
+		pod := &corev1.Pod{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{Name: "redis"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "redis", Image: "redis:6"}},
+			},
+		}
+		response := handler.Handle(context.TODO(), newRequest(t, "default", pod, "Pod"))
+		assert.True(t, response.Allowed)
+		assert.Equal(t, []string{"container redis: image redis:6 has not been scanned for vulnerabilities"}, response.Warnings)
+	})
+
+	t.Run("Should allow unscanned image", func(t *testing.T) {
+		handler := newHandler(vulnerabilityreport.AdmissionPolicy{
+			Action:          vulnerabilityreport.AdmissionActionDeny,
+			MaxSeverity:     v1alpha1.SeverityLow,
+			UnscannedImages: vulnerabilityreport.AdmissionActionAllow,
+		})
+		response := handler.Handle(context.TODO(), newRequest(t, "default", newDeployment("redis:6"), "Deployment"))
+		assert.True(t, response.Allowed)
+	})
+}
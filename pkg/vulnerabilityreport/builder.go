@@ -26,6 +26,7 @@ type ScanJobBuilder struct {
 	timeout           time.Duration
 	object            client.Object
 	credentials       map[string]docker.Auth
+	imageDigests      kube.ContainerImages
 	tolerations       []corev1.Toleration
 	annotations       map[string]string
 	podTemplateLabels labels.Set
@@ -75,6 +76,13 @@ func (s *ScanJobBuilder) WithCredentials(credentials map[string]docker.Auth) *Sc
 	return s
 }
 
+// WithImageDigests sets digests of container images scanned by the job, which
+// are used to cache scan results when the job completes.
+func (s *ScanJobBuilder) WithImageDigests(imageDigests kube.ContainerImages) *ScanJobBuilder {
+	s.imageDigests = imageDigests
+	return s
+}
+
 func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 	// spec, err := kube.GetPodSpec(s.object)
 	// if err != nil {
@@ -129,6 +137,16 @@ func (s *ScanJobBuilder) Get() (*batchv1.Job, []*corev1.Secret, error) {
 			},
 		},
 	}
+	if len(s.imageDigests) > 0 {
+		imageDigestsAsJSON, err := s.imageDigests.AsJSON()
+		if err != nil {
+			return nil, nil, err
+		}
+		job.Annotations[starboard.AnnotationContainerImageDigests] = imageDigestsAsJSON
+		for _, digest := range s.imageDigests {
+			job.Labels[ImageDigestLabel(digest)] = "true"
+		}
+	}
 	// secrets will be created with scan jobs in same namespace where scan job will run
 	for i := range secrets {
 		secrets[i].Namespace = s.pluginContext.GetNamespace()
@@ -173,6 +191,13 @@ func GetScanJobName(obj client.Object) string {
 	}))
 }
 
+// ImageDigestLabel returns the key of the label that marks scan jobs scanning
+// the container image with the given digest. Digests are hashed because they
+// do not fit label keys.
+func ImageDigestLabel(digest string) string {
+	return starboard.LabelImageDigestPrefix + kube.ComputeHash(digest)
+}
+
 func RegistryCredentialsSecretName(obj client.Object) string {
 	return fmt.Sprintf("%s-regcred", GetScanJobName(obj))
 }
@@ -184,6 +209,7 @@ type ReportBuilder struct {
 	hash       string
 	data       v1alpha1.VulnerabilityReportData
 	reportTTL  *time.Duration
+	exceptions []v1alpha1.VulnerabilityException
 }
 
 func NewReportBuilder(scheme *runtime.Scheme) *ReportBuilder {
@@ -217,6 +243,14 @@ func (b *ReportBuilder) ReportTTL(ttl *time.Duration) *ReportBuilder {
 	return b
 }
 
+// Exceptions marks vulnerabilities matching any of the given active
+// exceptions as suppressed, see ApplyExceptions. The report data is left
+// intact if exceptions are nil.
+func (b *ReportBuilder) Exceptions(exceptions []v1alpha1.VulnerabilityException) *ReportBuilder {
+	b.exceptions = exceptions
+	return b
+}
+
 func (b *ReportBuilder) reportName() string {
 	kind := b.controller.GetObjectKind().GroupVersionKind().Kind
 	name := b.controller.GetName()
@@ -243,7 +277,10 @@ func (b *ReportBuilder) Get() (v1alpha1.VulnerabilityReport, error) {
 			Namespace: b.controller.GetNamespace(),
 			Labels:    labels,
 		},
-		Report: b.data,
+		Report: *b.data.DeepCopy(),
+	}
+	if b.exceptions != nil {
+		ApplyExceptions(&report.Report, b.controller, b.exceptions)
 	}
 
 	if b.reportTTL != nil {
@@ -0,0 +1,128 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ImageCache stores scan results of container images as cluster scoped
+// v1alpha1.ClusterVulnerabilityReport instances named after image digests, so
+// that each unique image is scanned once and its results are shared by all
+// workloads running it.
+//
+// Get returns the cached v1alpha1.VulnerabilityReportData of the image with
+// the given digest. The second return value is false if there are no cached
+// results or they are older than the TTL of the cache.
+//
+// Put caches the given v1alpha1.VulnerabilityReportData of the image with the
+// given digest.
+//
+// Invalidate deletes results cached before the given time, e.g. when the
+// vulnerability database has been updated.
+type ImageCache interface {
+	Get(ctx context.Context, digest string) (v1alpha1.VulnerabilityReportData, bool, error)
+	Put(ctx context.Context, digest string, data v1alpha1.VulnerabilityReportData) error
+	Invalidate(ctx context.Context, before time.Time) error
+}
+
+type imageCache struct {
+	client client.Client
+	clock  ext.Clock
+	ttl    time.Duration
+}
+
+// NewImageCache constructs a new ImageCache with the given TTL. Cached results
+// never expire if the TTL is not positive.
+func NewImageCache(c client.Client, clock ext.Clock, ttl time.Duration) ImageCache {
+	return &imageCache{
+		client: c,
+		clock:  clock,
+		ttl:    ttl,
+	}
+}
+
+// GetClusterReportName returns the name of the v1alpha1.ClusterVulnerabilityReport
+// that caches scan results of the image with the given digest, e.g.
+// sha256-9dd...4f1 for sha256:9dd...4f1.
+func GetClusterReportName(digest string) string {
+	name := strings.ReplaceAll(digest, ":", "-")
+	if len(validation.IsDNS1123Subdomain(name)) == 0 {
+		return name
+	}
+	return fmt.Sprintf("image-%s", kube.ComputeHash(digest))
+}
+
+func (c *imageCache) Get(ctx context.Context, digest string) (v1alpha1.VulnerabilityReportData, bool, error) {
+	var report v1alpha1.ClusterVulnerabilityReport
+	err := c.client.Get(ctx, client.ObjectKey{Name: GetClusterReportName(digest)}, &report)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return v1alpha1.VulnerabilityReportData{}, false, nil
+		}
+		return v1alpha1.VulnerabilityReportData{}, false, fmt.Errorf("getting cluster vulnerability report: %w", err)
+	}
+	if c.ttl > 0 && c.clock.Now().Sub(report.Report.UpdateTimestamp.Time) > c.ttl {
+		return v1alpha1.VulnerabilityReportData{}, false, nil
+	}
+	return report.Report, true, nil
+}
+
+func (c *imageCache) Put(ctx context.Context, digest string, data v1alpha1.VulnerabilityReportData) error {
+	data.Artifact.Digest = digest
+	report := v1alpha1.ClusterVulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: GetClusterReportName(digest),
+			Labels: map[string]string{
+				starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+			},
+		},
+		Report: data,
+	}
+
+	var existing v1alpha1.ClusterVulnerabilityReport
+	err := c.client.Get(ctx, client.ObjectKeyFromObject(&report), &existing)
+	switch {
+	case err == nil:
+		copied := existing.DeepCopy()
+		copied.Labels = report.Labels
+		copied.Report = report.Report
+		err = c.client.Update(ctx, copied)
+	case errors.IsNotFound(err):
+		err = c.client.Create(ctx, &report)
+	}
+	if err != nil {
+		return fmt.Errorf("caching scan results of image %s: %w", digest, err)
+	}
+	return nil
+}
+
+func (c *imageCache) Invalidate(ctx context.Context, before time.Time) error {
+	var list v1alpha1.ClusterVulnerabilityReportList
+	err := c.client.List(ctx, &list, client.MatchingLabels{
+		starboard.LabelK8SAppManagedBy: starboard.AppStarboard,
+	})
+	if err != nil {
+		return fmt.Errorf("listing cluster vulnerability reports: %w", err)
+	}
+	for i := range list.Items {
+		if !list.Items[i].Report.UpdateTimestamp.Time.Before(before) {
+			continue
+		}
+		err = c.client.Delete(ctx, &list.Items[i])
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("deleting cluster vulnerability report: %w", err)
+		}
+	}
+	return nil
+}
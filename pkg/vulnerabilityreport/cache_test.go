@@ -0,0 +1,119 @@
+package vulnerabilityreport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testDigest = "sha256:d20aa6d1cae56fd17cd458f4807e0de462caf2336f0b70b5eeb69fcaaf30dd9c"
+
+func TestGetClusterReportName(t *testing.T) {
+	assert.Equal(t, "sha256-d20aa6d1cae56fd17cd458f4807e0de462caf2336f0b70b5eeb69fcaaf30dd9c",
+		vulnerabilityreport.GetClusterReportName(testDigest))
+	assert.Regexp(t, "^image-[a-z0-9]+$", vulnerabilityreport.GetClusterReportName("SHA256:ABC"))
+}
+
+func TestImageCache(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	clock := ext.NewFixedClock(now)
+
+	newData := func(updated time.Time) v1alpha1.VulnerabilityReportData {
+		return v1alpha1.VulnerabilityReportData{
+			UpdateTimestamp: metav1.NewTime(updated),
+			Artifact: v1alpha1.Artifact{
+				Repository: "library/nginx",
+				Tag:        "1.16",
+			},
+			Summary: v1alpha1.VulnerabilitySummary{
+				HighCount: 2,
+			},
+			Vulnerabilities: []v1alpha1.Vulnerability{},
+		}
+	}
+
+	t.Run("Should return cached scan results", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+		cache := vulnerabilityreport.NewImageCache(testClient, clock, time.Hour)
+
+		_, ok, err := cache.Get(context.TODO(), testDigest)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		err = cache.Put(context.TODO(), testDigest, newData(now.Add(-time.Minute)))
+		require.NoError(t, err)
+
+		data, ok, err := cache.Get(context.TODO(), testDigest)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, testDigest, data.Artifact.Digest)
+		assert.Equal(t, 2, data.Summary.HighCount)
+
+		var report v1alpha1.ClusterVulnerabilityReport
+		err = testClient.Get(context.TODO(), client.ObjectKey{
+			Name: "sha256-d20aa6d1cae56fd17cd458f4807e0de462caf2336f0b70b5eeb69fcaaf30dd9c",
+		}, &report)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{starboard.LabelK8SAppManagedBy: starboard.AppStarboard}, report.Labels)
+	})
+
+	t.Run("Should update cached scan results", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+		cache := vulnerabilityreport.NewImageCache(testClient, clock, time.Hour)
+
+		err := cache.Put(context.TODO(), testDigest, newData(now.Add(-time.Minute)))
+		require.NoError(t, err)
+		updated := newData(now)
+		updated.Summary.HighCount = 3
+		err = cache.Put(context.TODO(), testDigest, updated)
+		require.NoError(t, err)
+
+		data, ok, err := cache.Get(context.TODO(), testDigest)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, 3, data.Summary.HighCount)
+	})
+
+	t.Run("Should not return expired scan results", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+		cache := vulnerabilityreport.NewImageCache(testClient, clock, time.Hour)
+
+		err := cache.Put(context.TODO(), testDigest, newData(now.Add(-2*time.Hour)))
+		require.NoError(t, err)
+
+		_, ok, err := cache.Get(context.TODO(), testDigest)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("Should delete scan results cached before the given time", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+		cache := vulnerabilityreport.NewImageCache(testClient, clock, 0)
+
+		const otherDigest = "sha256:b5cfd4befc119a590ca1a81d6bb0fa1fb19f1fbebd0397f25fae164abe1e8a6a"
+		err := cache.Put(context.TODO(), testDigest, newData(now.Add(-2*time.Hour)))
+		require.NoError(t, err)
+		err = cache.Put(context.TODO(), otherDigest, newData(now))
+		require.NoError(t, err)
+
+		err = cache.Invalidate(context.TODO(), now.Add(-time.Hour))
+		require.NoError(t, err)
+
+		_, ok, err := cache.Get(context.TODO(), testDigest)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		_, ok, err = cache.Get(context.TODO(), otherDigest)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"reflect"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/operator/controller"
 	"github.com/aquasecurity/starboard/pkg/operator/etc"
@@ -19,6 +20,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,6 +32,22 @@ import (
 // WorkloadController watches Kubernetes workloads and generates
 // v1alpha1.VulnerabilityReport instances using vulnerability scanner that that
 // implements the Plugin interface.
+//
+// If the ImageCache is set, reports of workloads running images with known
+// digests are created from cached scan results without running scan jobs,
+// and results of completed scan jobs are cached. Workloads running images
+// that are being scanned by another workload's scan job are requeued until
+// the results are cached. Results of plugins that do not parse complete
+// scan results are never cached.
+//
+// If the ExploitCache is set, vulnerabilities are annotated with the
+// ExploitCatalog whenever reports are written. If the APIReader is set, they
+// are annotated with the VEXDocuments read from the operator namespace too.
+// This way updates of the catalog and VEX documents apply to cached scan
+// results as well.
+//
+// Vulnerabilities matching active v1alpha1.VulnerabilityException instances
+// in the namespace of a workload are marked as suppressed.
 type WorkloadController struct {
 	logr.Logger
 	etc.Config
@@ -42,6 +60,11 @@ type WorkloadController struct {
 	starboard.PluginContext
 	ReadWriter
 	starboard.ConfigData
+	ext.Clock
+	record.EventRecorder
+	ImageCache   ImageCache
+	ExploitCache *ExploitCatalogCache
+	APIReader    client.Reader
 }
 
 func (r *WorkloadController) SetupWithManager(mgr ctrl.Manager) error {
@@ -154,6 +177,24 @@ func (r *WorkloadController) reconcileWorkload(workloadKind kube.Kind) reconcile
 			return ctrl.Result{}, nil
 		}
 
+		imageDigests := kube.ContainerImages{}
+		if r.ImageCache != nil {
+			pods, err := r.PodsByWorkload(ctx, workloadObj)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("listing pods: %w", err)
+			}
+			imageDigests = kube.GetContainerImageDigests(podSpec, pods)
+
+			reports, err := r.reportsFromCache(ctx, workloadObj, hash, containerImages, imageDigests)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("getting cached scan results: %w", err)
+			}
+			if reports != nil {
+				log.V(1).Info("Creating VulnerabilityReports from cached scan results")
+				return ctrl.Result{}, r.ReadWriter.Write(ctx, reports)
+			}
+		}
+
 		_, job, err := r.hasActiveScanJob(ctx, workloadRef, hash)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("checking scan job: %w", err)
@@ -165,6 +206,17 @@ func (r *WorkloadController) reconcileWorkload(workloadKind kube.Kind) reconcile
 			return ctrl.Result{}, nil
 		}
 
+		job, err = r.findPendingScanJob(ctx, workloadObj, imageDigests)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("checking scan jobs of image digests: %w", err)
+		}
+
+		if job != nil {
+			log.V(1).Info("Waiting for scan job of the same image digest",
+				"job", fmt.Sprintf("%s/%s", job.Namespace, job.Name), "retryAfter", r.ScanJobRetryAfter)
+			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
+		}
+
 		limitExceeded, scanJobsCount, err := r.LimitChecker.Check(ctx)
 		if err != nil {
 			return ctrl.Result{}, err
@@ -176,8 +228,70 @@ func (r *WorkloadController) reconcileWorkload(workloadKind kube.Kind) reconcile
 			return ctrl.Result{RequeueAfter: r.Config.ScanJobRetryAfter}, nil
 		}
 
-		return ctrl.Result{}, r.submitScanJob(ctx, workloadObj)
+		return ctrl.Result{}, r.submitScanJob(ctx, workloadObj, imageDigests)
+	}
+}
+
+// reportsFromCache returns v1alpha1.VulnerabilityReport instances for all
+// containers of the given workload built from cached scan results. It returns
+// nil if the digest of any container image is unknown or its scan results are
+// not cached.
+func (r *WorkloadController) reportsFromCache(ctx context.Context, workload client.Object, hash string,
+	images kube.ContainerImages, digests kube.ContainerImages) ([]v1alpha1.VulnerabilityReport, error) {
+	exploits, err := r.loadExploitCatalog(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vex, err := r.loadVEXDocuments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exceptions, err := ListActiveExceptions(ctx, r.Client, workload.GetNamespace(), r.Clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	var reports []v1alpha1.VulnerabilityReport
+	for containerName, image := range images {
+		digest, ok := digests[containerName]
+		if !ok {
+			return nil, nil
+		}
+		data, ok, err := r.ImageCache.Get(ctx, digest)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, nil
+		}
+
+		// Cached results may have been produced for another reference of the
+		// same image, e.g. a different tag or registry mirror.
+		registry, artifact, err := ParseImageRef(image)
+		if err != nil {
+			return nil, err
+		}
+		artifact.Digest = digest
+		data.Registry = registry
+		data.Artifact = artifact
+		exploits.AnnotateReportData(&data)
+		vex.AnnotateReportData(&data)
+
+		reportBuilder := NewReportBuilder(r.Client.Scheme()).
+			Controller(workload).
+			Container(containerName).
+			Data(data).
+			Exceptions(exceptions).
+			PodSpecHash(hash)
+		if r.Config.VulnerabilityScannerReportTTL != nil {
+			reportBuilder.ReportTTL(r.Config.VulnerabilityScannerReportTTL)
+		}
+		report, err := reportBuilder.Get()
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
 	}
+	return reports, nil
 }
 
 func (r *WorkloadController) hasReports(ctx context.Context, owner kube.ObjectRef, hash string, images kube.ContainerImages) (bool, error) {
@@ -220,7 +334,31 @@ func (r *WorkloadController) hasActiveScanJob(ctx context.Context, owner kube.Ob
 	return false, nil, nil
 }
 
-func (r *WorkloadController) submitScanJob(ctx context.Context, owner client.Object) error {
+// findPendingScanJob returns a scan job of another workload that scans any of
+// the given image digests, or nil if there is none. Workloads running images
+// that are being scanned wait for the results to be cached instead of running
+// scan jobs of their own.
+func (r *WorkloadController) findPendingScanJob(ctx context.Context, workload client.Object, digests kube.ContainerImages) (*batchv1.Job, error) {
+	if r.ImageCache == nil || !CanCacheResults(r.Plugin) {
+		return nil, nil
+	}
+	ownJobName := GetScanJobName(workload)
+	for _, digest := range digests {
+		var jobs batchv1.JobList
+		err := r.List(ctx, &jobs, client.InNamespace(r.Config.Namespace), client.HasLabels{ImageDigestLabel(digest)})
+		if err != nil {
+			return nil, fmt.Errorf("listing jobs: %w", err)
+		}
+		for i := range jobs.Items {
+			if jobs.Items[i].Name != ownJobName {
+				return &jobs.Items[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (r *WorkloadController) submitScanJob(ctx context.Context, owner client.Object, imageDigests kube.ContainerImages) error {
 	log := r.Logger.WithValues("kind", owner.GetObjectKind().GroupVersionKind().Kind,
 		"name", owner.GetName(), "namespace", owner.GetNamespace())
 	credentials, err := r.CredentialsByWorkload(ctx, owner)
@@ -252,6 +390,7 @@ func (r *WorkloadController) submitScanJob(ctx context.Context, owner client.Obj
 		WithAnnotations(scanJobAnnotations).
 		WithPodTemplateLabels(scanJobPodTemplateLabels).
 		WithCredentials(credentials).
+		WithImageDigests(imageDigests).
 		Get()
 
 	if err != nil {
@@ -356,6 +495,11 @@ func (r *WorkloadController) processCompleteScanJob(ctx context.Context, job *ba
 		return fmt.Errorf("expected label %s not set", starboard.LabelResourceSpecHash)
 	}
 
+	imageDigests, err := kube.GetContainerImageDigestsFromJob(job)
+	if err != nil {
+		return fmt.Errorf("getting container image digests: %w", err)
+	}
+
 	hasReports, err := r.hasReports(ctx, ownerRef, podSpecHash, containerImages)
 	if err != nil {
 		return err
@@ -367,6 +511,19 @@ func (r *WorkloadController) processCompleteScanJob(ctx context.Context, job *ba
 		return r.deleteJob(ctx, job)
 	}
 
+	exploits, err := r.loadExploitCatalog(ctx)
+	if err != nil {
+		return err
+	}
+	vex, err := r.loadVEXDocuments(ctx)
+	if err != nil {
+		return err
+	}
+	exceptions, err := ListActiveExceptions(ctx, r.Client, owner.GetNamespace(), r.Clock.Now())
+	if err != nil {
+		return err
+	}
+
 	var vulnerabilityReports []v1alpha1.VulnerabilityReport
 
 	for containerName, containerImage := range containerImages {
@@ -387,11 +544,24 @@ func (r *WorkloadController) processCompleteScanJob(ctx context.Context, job *ba
 			return err
 		}
 		_ = logsStream.Close()
+		exploits.AnnotateReportData(&reportData)
+
+		if digest, ok := imageDigests[containerName]; ok {
+			reportData.Artifact.Digest = digest
+			if r.ImageCache != nil && CanCacheResults(r.Plugin) {
+				err = r.ImageCache.Put(ctx, digest, reportData)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		vex.AnnotateReportData(&reportData)
 
 		reportBuilder := NewReportBuilder(r.Client.Scheme()).
 			Controller(owner).
 			Container(containerName).
 			Data(reportData).
+			Exceptions(exceptions).
 			PodSpecHash(podSpecHash)
 
 		if r.Config.VulnerabilityScannerReportTTL != nil {
@@ -416,6 +586,43 @@ func (r *WorkloadController) processCompleteScanJob(ctx context.Context, job *ba
 	return r.deleteJob(ctx, job)
 }
 
+// loadExploitCatalog returns the current ExploitCatalog, or nil if the
+// ExploitCache is not set or the catalog does not exist. A malformed
+// catalog is ignored with a warning event raised for its ConfigMap, so that
+// reports are still written, just without exploit annotations.
+func (r *WorkloadController) loadExploitCatalog(ctx context.Context) (*ExploitCatalog, error) {
+	if r.ExploitCache == nil {
+		return nil, nil
+	}
+	catalog, err := r.ExploitCache.Load(ctx)
+	var invalid *InvalidExploitCatalogError
+	if errors.As(err, &invalid) {
+		r.Logger.Error(invalid.Err, "Ignoring invalid exploit catalog", "configMap", client.ObjectKeyFromObject(&invalid.ConfigMap))
+		if r.EventRecorder != nil {
+			r.EventRecorder.Eventf(&invalid.ConfigMap, corev1.EventTypeWarning, ReasonInvalidExploitCatalog,
+				"Exploit catalog ignored: %v", invalid.Err)
+		}
+		return nil, nil
+	}
+	return catalog, err
+}
+
+// loadVEXDocuments returns the current VEXDocuments, or nil if the APIReader
+// is not set. Invalid documents are skipped.
+func (r *WorkloadController) loadVEXDocuments(ctx context.Context) (VEXDocuments, error) {
+	if r.APIReader == nil {
+		return nil, nil
+	}
+	documents, invalid, err := LoadVEXDocuments(ctx, r.APIReader, r.Config.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, document := range invalid {
+		r.Logger.Error(document.Err, "Skipping invalid VEX document", "configMap", client.ObjectKeyFromObject(&document.ConfigMap))
+	}
+	return documents, nil
+}
+
 func (r *WorkloadController) processFailedScanJob(ctx context.Context, scanJob *batchv1.Job) error {
 	log := r.Logger.WithValues("job", fmt.Sprintf("%s/%s", scanJob.Namespace, scanJob.Name))
 
@@ -0,0 +1,174 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/docker"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type testPlugin struct {
+	Plugin
+	parsesVulnerabilities bool
+}
+
+func (p testPlugin) ParsesVulnerabilities() bool {
+	return p.parsesVulnerabilities
+}
+
+func (p testPlugin) GetScanJobSpec(_ starboard.PluginContext, _ client.Object, _ map[string]docker.Auth) (
+	corev1.PodSpec, []*corev1.Secret, error) {
+	return corev1.PodSpec{}, nil, nil
+}
+
+func TestScanJobBuilder_ImageDigestLabels(t *testing.T) {
+	digests := kube.ContainerImages{
+		"nginx":   "sha256:d20aa6d1cae56fd17cd458f4807e0de462caf2336f0b70b5eeb69fcaaf30dd9c",
+		"sidecar": "sha256:9dd2d6cb9d1f2eb6a4b44dfdba2a04b4e09d0c7f0bd2bf1b64f5df2e3c9d84f1",
+	}
+	job, _, err := NewScanJobBuilder().
+		WithPlugin(testPlugin{}).
+		WithPluginContext(starboard.NewPluginContext().
+			WithName("Trivy").
+			WithNamespace("starboard-system").
+			Get()).
+		WithImageDigests(digests).
+		Get()
+	require.NoError(t, err)
+	for _, digest := range digests {
+		assert.Equal(t, "true", job.Labels[ImageDigestLabel(digest)])
+	}
+}
+
+func TestCanCacheResults(t *testing.T) {
+	assert.True(t, CanCacheResults(struct{ Plugin }{}))
+	assert.True(t, CanCacheResults(testPlugin{parsesVulnerabilities: true}))
+	assert.False(t, CanCacheResults(testPlugin{parsesVulnerabilities: false}))
+}
+
+func TestWorkloadController_loadExploitCatalog(t *testing.T) {
+	testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      starboard.ExploitCatalogConfigMapName,
+			Namespace: "starboard-system",
+		},
+		Data: map[string]string{
+			KeyExploitProbabilities: "cve\nCVE-2022-22965\n",
+		},
+	}).Build()
+	recorder := record.NewFakeRecorder(1)
+	r := &WorkloadController{
+		Logger:        logr.Discard(),
+		EventRecorder: recorder,
+		ExploitCache:  NewExploitCatalogCache(testClient, "starboard-system"),
+	}
+
+	catalog, err := r.loadExploitCatalog(context.TODO())
+	require.NoError(t, err)
+	assert.Nil(t, catalog)
+	assert.Equal(t, "Warning InvalidExploitCatalog Exploit catalog ignored: EPSS scores must have cve and epss columns", <-recorder.Events)
+}
+
+func TestWorkloadController_findPendingScanJob(t *testing.T) {
+	const (
+		digest      = "sha256:d20aa6d1cae56fd17cd458f4807e0de462caf2336f0b70b5eeb69fcaaf30dd9c"
+		otherDigest = "sha256:9dd2d6cb9d1f2eb6a4b44dfdba2a04b4e09d0c7f0bd2bf1b64f5df2e3c9d84f1"
+	)
+
+	workload := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx",
+			Namespace: "default",
+		},
+	}
+	newJob := func(name string) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "starboard-system",
+				Labels: map[string]string{
+					ImageDigestLabel(digest): "true",
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		plugin      Plugin
+		jobs        []*batchv1.Job
+		digests     kube.ContainerImages
+		expectedJob string
+	}{
+		{
+			name:        "Should return scan job of another workload scanning the same digest",
+			plugin:      testPlugin{parsesVulnerabilities: true},
+			jobs:        []*batchv1.Job{newJob("scan-vulnerabilityreport-other")},
+			digests:     kube.ContainerImages{"nginx": digest},
+			expectedJob: "scan-vulnerabilityreport-other",
+		},
+		{
+			name:    "Should ignore scan job of the workload",
+			plugin:  testPlugin{parsesVulnerabilities: true},
+			jobs:    []*batchv1.Job{newJob(GetScanJobName(workload))},
+			digests: kube.ContainerImages{"nginx": digest},
+		},
+		{
+			name:    "Should ignore scan jobs of other digests",
+			plugin:  testPlugin{parsesVulnerabilities: true},
+			jobs:    []*batchv1.Job{newJob("scan-vulnerabilityreport-other")},
+			digests: kube.ContainerImages{"nginx": otherDigest},
+		},
+		{
+			name:    "Should not wait for plugins whose results are not cached",
+			plugin:  testPlugin{parsesVulnerabilities: false},
+			jobs:    []*batchv1.Job{newJob("scan-vulnerabilityreport-other")},
+			digests: kube.ContainerImages{"nginx": digest},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			builder := fake.NewClientBuilder().WithScheme(starboard.NewScheme())
+			for _, job := range tc.jobs {
+				builder.WithObjects(job)
+			}
+			testClient := builder.Build()
+
+			r := &WorkloadController{
+				Config:     etc.Config{Namespace: "starboard-system"},
+				Client:     testClient,
+				Plugin:     tc.plugin,
+				ImageCache: NewImageCache(testClient, ext.NewSystemClock(), time.Hour),
+			}
+
+			job, err := r.findPendingScanJob(context.TODO(), workload, tc.digests)
+			require.NoError(t, err)
+			if tc.expectedJob == "" {
+				assert.Nil(t, job)
+				return
+			}
+			require.NotNil(t, job)
+			assert.Equal(t, tc.expectedJob, job.Name)
+		})
+	}
+}
@@ -0,0 +1,410 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/google/go-containerregistry/pkg/name"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+)
+
+// Converter converts the aquasecurity.TrivyReport produced by a single
+// `trivy k8s cluster` run into v1alpha1.VulnerabilityReport instances, one per
+// container of each scanned workload.
+//
+// Reports are owned by the same objects, named the same way and carry the same
+// container and spec hash labels as the ones created by the WorkloadController,
+// so the Reader can find them regardless of the way they were produced.
+type Converter struct {
+	scheme    *runtime.Scheme
+	resolver  *kube.ObjectResolver
+	clock     ext.Clock
+	reportTTL *time.Duration
+	filter    ResourceFilter
+	cache     ImageCache
+	exploits  *ExploitCatalog
+	vex       VEXDocuments
+
+	applyExceptions bool
+}
+
+// ResourceFilter returns true if a scanned resource of the given kind in the
+// given namespace should be converted.
+type ResourceFilter func(kind, namespace string) bool
+
+// NewConverter constructs a new Converter, which is using the given
+// kube.ObjectResolver to look up scanned workloads and their report owners.
+func NewConverter(scheme *runtime.Scheme, resolver *kube.ObjectResolver, clock ext.Clock) *Converter {
+	return &Converter{
+		scheme:   scheme,
+		resolver: resolver,
+		clock:    clock,
+	}
+}
+
+// WithReportTTL sets the TTL annotation on converted reports.
+func (c *Converter) WithReportTTL(ttl *time.Duration) *Converter {
+	c.reportTTL = ttl
+	return c
+}
+
+// WithResourceFilter skips resources rejected by the given ResourceFilter,
+// which is typically the Matches method of the scan scope.
+func (c *Converter) WithResourceFilter(filter ResourceFilter) *Converter {
+	c.filter = filter
+	return c
+}
+
+// WithImageCache stores converted scan results in the given ImageCache under
+// digests of the scanned images, so that they can be reused by workloads
+// running the same images.
+func (c *Converter) WithImageCache(cache ImageCache) *Converter {
+	c.cache = cache
+	return c
+}
+
+// WithExploitCatalog annotates converted vulnerabilities with known
+// exploitation and exploit probabilities from the given ExploitCatalog.
+func (c *Converter) WithExploitCatalog(catalog *ExploitCatalog) *Converter {
+	c.exploits = catalog
+	return c
+}
+
+// WithVEXDocuments sets the VEX status of converted vulnerabilities according
+// to the given VEXDocuments.
+func (c *Converter) WithVEXDocuments(documents VEXDocuments) *Converter {
+	c.vex = documents
+	return c
+}
+
+// WithExceptions marks vulnerabilities matching active
+// v1alpha1.VulnerabilityException instances in the namespace of each workload
+// as suppressed.
+func (c *Converter) WithExceptions() *Converter {
+	c.applyExceptions = true
+	return c
+}
+
+// Convert returns v1alpha1.VulnerabilityReport instances for all workloads
+// listed in the given aquasecurity.TrivyReport.
+func (c *Converter) Convert(ctx context.Context, report *aquasecurity.TrivyReport) ([]v1alpha1.VulnerabilityReport, error) {
+	if report == nil || report.Report == nil {
+		return nil, nil
+	}
+	var reports []v1alpha1.VulnerabilityReport
+	for _, resource := range report.Report.Vulnerabilities {
+		converted, err := c.ConvertResource(ctx, resource)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, converted...)
+	}
+	return reports, nil
+}
+
+// ConvertResource returns v1alpha1.VulnerabilityReport instances for the
+// containers of the workload described by the given
+// aquasecurity.K8SResourceVulnerability.
+//
+// Resources that are not workloads, that are rejected by the ResourceFilter,
+// that failed to scan or that no longer exist in the cluster are skipped and
+// yield no reports.
+func (c *Converter) ConvertResource(ctx context.Context, resource aquasecurity.K8SResourceVulnerability) ([]v1alpha1.VulnerabilityReport, error) {
+	if !kube.IsWorkload(resource.Kind) {
+		return nil, nil
+	}
+	if c.filter != nil && !c.filter(resource.Kind, resource.Namespace) {
+		return nil, nil
+	}
+	ref := kube.ObjectRef{
+		Kind:      kube.Kind(resource.Kind),
+		Name:      resource.Name,
+		Namespace: resource.Namespace,
+	}
+	if resource.Error != "" && len(resource.Results) == 0 {
+		klog.V(3).Infof("Skipping %s/%s/%s that failed to scan (%s): %s", ref.Kind, ref.Namespace, ref.Name,
+			resource.ScanError().Category, resource.Error)
+		return nil, nil
+	}
+
+	obj, err := c.resolver.ObjectFromObjectRef(ctx, ref)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			klog.V(3).Infof("Skipping %s/%s/%s that must have been deleted", ref.Kind, ref.Namespace, ref.Name)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("resolving object %s/%s/%s: %w", ref.Kind, ref.Namespace, ref.Name, err)
+	}
+	owner, err := c.resolver.ReportOwner(ctx, obj)
+	if err != nil {
+		return nil, fmt.Errorf("resolving report owner of %s/%s/%s: %w", ref.Kind, ref.Namespace, ref.Name, err)
+	}
+	podSpec, err := kube.GetPodSpec(owner)
+	if err != nil {
+		return nil, err
+	}
+	containerImages := kube.GetContainerImagesFromPodSpec(podSpec)
+	podSpecHash := kube.ComputeHash(podSpec)
+
+	digests := kube.ContainerImages{}
+	if c.cache != nil || c.vex != nil {
+		pods, err := c.resolver.PodsByWorkload(ctx, owner)
+		if err != nil {
+			return nil, fmt.Errorf("listing pods of %s/%s/%s: %w", ref.Kind, ref.Namespace, ref.Name, err)
+		}
+		digests = kube.GetContainerImageDigests(podSpec, pods)
+	}
+
+	var exceptions []v1alpha1.VulnerabilityException
+	if c.applyExceptions {
+		exceptions, err = ListActiveExceptions(ctx, c.resolver, owner.GetNamespace(), c.clock.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var reports []v1alpha1.VulnerabilityReport
+	for containerName, results := range GroupResultsByContainer(resource.Results, containerImages) {
+		data, err := c.toReportData(containerImages[containerName], results)
+		if err != nil {
+			return nil, err
+		}
+		if digest, ok := digests[containerName]; ok {
+			data.Artifact.Digest = digest
+			if c.cache != nil {
+				err = c.cache.Put(ctx, digest, data)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		c.vex.AnnotateReportData(&data)
+		report, err := NewReportBuilder(c.scheme).
+			Controller(owner).
+			Container(containerName).
+			PodSpecHash(podSpecHash).
+			Data(data).
+			Exceptions(exceptions).
+			ReportTTL(c.reportTTL).
+			Get()
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// GroupResultsByContainer assigns scan results to the containers of a
+// workload.
+//
+// Trivy lists results image by image. The first result of each image is the
+// OS packages result, which is targeted at the image reference (optionally
+// followed by the detected OS in parentheses). It is followed by language
+// specific results targeted at file paths within that image. Results that
+// precede any image result cannot be attributed and are dropped.
+func GroupResultsByContainer(results []aquasecurity.VulnerabilityScanResult, images kube.ContainerImages) map[string][]aquasecurity.VulnerabilityScanResult {
+	grouped := make(map[string][]aquasecurity.VulnerabilityScanResult)
+	var current []string
+	for _, result := range results {
+		if containers := containersByTarget(result.Target, images); len(containers) > 0 {
+			current = containers
+		}
+		for _, containerName := range current {
+			grouped[containerName] = append(grouped[containerName], result)
+		}
+	}
+	return grouped
+}
+
+// containersByTarget returns names of all containers running the image
+// referenced by the given scan result target.
+func containersByTarget(target string, images kube.ContainerImages) []string {
+	imageRef := target
+	if i := strings.Index(imageRef, " ("); i > 0 {
+		imageRef = imageRef[:i]
+	}
+	var containers []string
+	for containerName, image := range images {
+		if sameImage(imageRef, image) {
+			containers = append(containers, containerName)
+		}
+	}
+	return containers
+}
+
+func sameImage(a, b string) bool {
+	if a == b {
+		return true
+	}
+	refA, err := name.ParseReference(a)
+	if err != nil {
+		return false
+	}
+	refB, err := name.ParseReference(b)
+	if err != nil {
+		return false
+	}
+	return refA.Name() == refB.Name()
+}
+
+func (c *Converter) toReportData(imageRef string, results []aquasecurity.VulnerabilityScanResult) (v1alpha1.VulnerabilityReportData, error) {
+	registry, artifact, err := ParseImageRef(imageRef)
+	if err != nil {
+		return v1alpha1.VulnerabilityReportData{}, err
+	}
+	vulnerabilities := make([]v1alpha1.Vulnerability, 0)
+	for _, result := range results {
+		for _, vulnerability := range result.Vulnerabilities {
+			c.exploits.Annotate(&vulnerability)
+			vulnerabilities = append(vulnerabilities, toVulnerability(vulnerability))
+		}
+	}
+	return v1alpha1.VulnerabilityReportData{
+		UpdateTimestamp: metav1.NewTime(c.clock.Now()),
+		Scanner: v1alpha1.Scanner{
+			Name:   "Trivy",
+			Vendor: "Aqua Security",
+		},
+		Registry:        registry,
+		Artifact:        artifact,
+		Summary:         Summarize(vulnerabilities),
+		Vulnerabilities: vulnerabilities,
+	}, nil
+}
+
+func toVulnerability(vulnerability aquasecurity.Vulnerability) v1alpha1.Vulnerability {
+	links := vulnerability.References
+	if links == nil {
+		links = []string{}
+	}
+	var publishedDate *metav1.Time
+	if vulnerability.PublishedDate != nil {
+		published := metav1.NewTime(*vulnerability.PublishedDate)
+		publishedDate = &published
+	}
+	return v1alpha1.Vulnerability{
+		VulnerabilityID:  vulnerability.VulnerabilityID,
+		Resource:         vulnerability.PkgName,
+		InstalledVersion: vulnerability.InstalledVersion,
+		FixedVersion:     vulnerability.FixedVersion,
+		Severity:         v1alpha1.Severity(vulnerability.Severity),
+		Title:            vulnerability.Title,
+		Description:      vulnerability.Description,
+		PrimaryLink:      vulnerability.PrimaryURL,
+		Links:            links,
+		Score:            vulnerability.Score(),
+		PublishedDate:    publishedDate,
+
+		KnownExploited:          vulnerability.KnownExploited,
+		KnownExploitedDateAdded: vulnerability.KnownExploitedDateAdded,
+		ExploitProbability:      vulnerability.ExploitProbability,
+	}
+}
+
+// ParseImageRef splits the given container image reference into
+// v1alpha1.Registry and v1alpha1.Artifact.
+func ParseImageRef(imageRef string) (v1alpha1.Registry, v1alpha1.Artifact, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return v1alpha1.Registry{}, v1alpha1.Artifact{}, err
+	}
+	registry := v1alpha1.Registry{
+		Server: ref.Context().RegistryStr(),
+	}
+	artifact := v1alpha1.Artifact{
+		Repository: ref.Context().RepositoryStr(),
+	}
+	switch t := ref.(type) {
+	case name.Tag:
+		artifact.Tag = t.TagStr()
+	case name.Digest:
+		artifact.Digest = t.DigestStr()
+	}
+	return registry, artifact, nil
+}
+
+// Summarize counts the given vulnerabilities by severity and known
+// exploitation. Suppressed vulnerabilities are only counted as such, and
+// vulnerabilities that do not affect the artifact according to a VEX document
+// are not counted at all.
+func Summarize(vulnerabilities []v1alpha1.Vulnerability) v1alpha1.VulnerabilitySummary {
+	var vs v1alpha1.VulnerabilitySummary
+	for _, v := range vulnerabilities {
+		if v.VEXStatus == v1alpha1.VEXStatusNotAffected {
+			continue
+		}
+		if v.Suppressed {
+			vs.SuppressedCount++
+			continue
+		}
+		if v.KnownExploited {
+			vs.ExploitedCount++
+		}
+		switch v.Severity {
+		case v1alpha1.SeverityCritical:
+			vs.CriticalCount++
+		case v1alpha1.SeverityHigh:
+			vs.HighCount++
+		case v1alpha1.SeverityMedium:
+			vs.MediumCount++
+		case v1alpha1.SeverityLow:
+			vs.LowCount++
+		default:
+			vs.UnknownCount++
+		}
+	}
+	return vs
+}
+
+// ClusterReportWriter converts the results of a cluster scan and writes them
+// as v1alpha1.VulnerabilityReport instances.
+type ClusterReportWriter interface {
+	WriteTrivyReport(ctx context.Context, report *aquasecurity.TrivyReport) error
+	WriteResource(ctx context.Context, resource aquasecurity.K8SResourceVulnerability) error
+}
+
+type clusterReportWriter struct {
+	converter *Converter
+	writer    Writer
+}
+
+// NewClusterReportWriter constructs a new ClusterReportWriter, which converts
+// cluster scan results with the given Converter and persists them with the
+// given Writer.
+func NewClusterReportWriter(converter *Converter, writer Writer) ClusterReportWriter {
+	return &clusterReportWriter{
+		converter: converter,
+		writer:    writer,
+	}
+}
+
+func (w *clusterReportWriter) WriteTrivyReport(ctx context.Context, report *aquasecurity.TrivyReport) error {
+	if report == nil || report.Report == nil {
+		return nil
+	}
+	for _, resource := range report.Report.Vulnerabilities {
+		err := w.WriteResource(ctx, resource)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *clusterReportWriter) WriteResource(ctx context.Context, resource aquasecurity.K8SResourceVulnerability) error {
+	reports, err := w.converter.ConvertResource(ctx, resource)
+	if err != nil {
+		return err
+	}
+	return w.writer.Write(ctx, reports)
+}
@@ -0,0 +1,257 @@
+package vulnerabilityreport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGroupResultsByContainer(t *testing.T) {
+	results := []aquasecurity.VulnerabilityScanResult{
+		{Target: "usr/local/bin/orphan"},
+		{Target: "nginx:1.16 (debian 10.3)"},
+		{Target: "usr/share/nginx/app.jar"},
+		{Target: "index.docker.io/library/redis:5 (debian 10.4)"},
+	}
+	grouped := vulnerabilityreport.GroupResultsByContainer(results, kube.ContainerImages{
+		"web":   "nginx:1.16",
+		"cache": "redis:5",
+		"idle":  "busybox",
+	})
+	assert.Equal(t, map[string][]aquasecurity.VulnerabilityScanResult{
+		"web": {
+			{Target: "nginx:1.16 (debian 10.3)"},
+			{Target: "usr/share/nginx/app.jar"},
+		},
+		"cache": {
+			{Target: "index.docker.io/library/redis:5 (debian 10.4)"},
+		},
+	}, grouped)
+}
+
+func TestConverter_Convert(t *testing.T) {
+	replicaSet := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "ReplicaSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6d4cf56db6",
+			Namespace: "default",
+			UID:       "ff26fd4d-6e37-4f1b-9b32-6b4e8ee0ab5c",
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "nginx", Image: "nginx:1.16"},
+					},
+				},
+			},
+		},
+	}
+	testClient := fake.NewClientBuilder().
+		WithScheme(starboard.NewScheme()).
+		WithObjects(replicaSet).
+		Build()
+	resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+	now := time.Date(2022, time.August, 1, 10, 0, 0, 0, time.UTC)
+	converter := vulnerabilityreport.NewConverter(testClient.Scheme(), &resolver, ext.NewFixedClock(now))
+	published := time.Date(2019, time.September, 10, 17, 15, 0, 0, time.UTC)
+
+	reports, err := converter.Convert(context.TODO(), &aquasecurity.TrivyReport{
+		Report: &aquasecurity.ScanReport{
+			Vulnerabilities: []aquasecurity.K8SResourceVulnerability{
+				{
+					Namespace: "default",
+					Kind:      "ReplicaSet",
+					Name:      "nginx-6d4cf56db6",
+					Results: []aquasecurity.VulnerabilityScanResult{
+						{
+							Target: "nginx:1.16 (debian 10.3)",
+							Vulnerabilities: []aquasecurity.Vulnerability{
+								{
+									VulnerabilityID:  "CVE-2019-1549",
+									PkgName:          "openssl",
+									InstalledVersion: "1.1.1c-r0",
+									FixedVersion:     "1.1.1d-r0",
+									Severity:         "MEDIUM",
+									Title:            "openssl: information disclosure in fork()",
+									Description:      "OpenSSL 1.1.1 introduced a rewritten random number generator.",
+									PrimaryURL:       "https://avd.aquasec.com/nvd/cve-2019-1549",
+									References:       []string{"https://www.openssl.org/news/secadv/20190910.txt"},
+									CVSS: map[string]*aquasecurity.CVSS{
+										"nvd":    {V3Vector: "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:L/I:N/A:N", V3Score: pointer.Float64(5.3)},
+										"redhat": {V3Score: pointer.Float64(4.8)},
+									},
+									PublishedDate: &published,
+								},
+								{VulnerabilityID: "CVE-2019-1547", PkgName: "openssl", InstalledVersion: "1.1.1c-r0", FixedVersion: "1.1.1d-r0", Severity: "LOW"},
+							},
+						},
+					},
+				},
+				{
+					Namespace: "default",
+					Kind:      "ReplicaSet",
+					Name:      "deleted",
+				},
+				{
+					Namespace: "default",
+					Kind:      "Service",
+					Name:      "nginx",
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+
+	report := reports[0]
+	assert.Equal(t, "replicaset-nginx-6d4cf56db6-nginx", report.Name)
+	assert.Equal(t, "default", report.Namespace)
+	assert.Equal(t, map[string]string{
+		starboard.LabelResourceKind:      "ReplicaSet",
+		starboard.LabelResourceName:      "nginx-6d4cf56db6",
+		starboard.LabelResourceNamespace: "default",
+		starboard.LabelContainerName:     "nginx",
+		starboard.LabelResourceSpecHash:  kube.ComputeHash(replicaSet.Spec.Template.Spec),
+	}, report.Labels)
+	assert.Equal(t, v1alpha1.VulnerabilityReportData{
+		UpdateTimestamp: metav1.NewTime(now),
+		Scanner: v1alpha1.Scanner{
+			Name:   "Trivy",
+			Vendor: "Aqua Security",
+		},
+		Registry: v1alpha1.Registry{Server: "index.docker.io"},
+		Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"},
+		Summary:  v1alpha1.VulnerabilitySummary{MediumCount: 1, LowCount: 1},
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{
+				VulnerabilityID:  "CVE-2019-1549",
+				Resource:         "openssl",
+				InstalledVersion: "1.1.1c-r0",
+				FixedVersion:     "1.1.1d-r0",
+				Severity:         v1alpha1.SeverityMedium,
+				Title:            "openssl: information disclosure in fork()",
+				Description:      "OpenSSL 1.1.1 introduced a rewritten random number generator.",
+				PrimaryLink:      "https://avd.aquasec.com/nvd/cve-2019-1549",
+				Links:            []string{"https://www.openssl.org/news/secadv/20190910.txt"},
+				Score:            pointer.Float64(4.8),
+				PublishedDate:    &metav1.Time{Time: published},
+			},
+			{VulnerabilityID: "CVE-2019-1547", Resource: "openssl", InstalledVersion: "1.1.1c-r0", FixedVersion: "1.1.1d-r0", Severity: v1alpha1.SeverityLow, Links: []string{}},
+		},
+	}, report.Report)
+	require.Len(t, report.OwnerReferences, 1)
+	assert.Equal(t, "nginx-6d4cf56db6", report.OwnerReferences[0].Name)
+}
+
+func TestConverter_WithResourceFilter(t *testing.T) {
+	testClient := fake.NewClientBuilder().
+		WithScheme(starboard.NewScheme()).
+		Build()
+	resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+	converter := vulnerabilityreport.NewConverter(testClient.Scheme(), &resolver, ext.NewSystemClock()).
+		WithResourceFilter(func(kind, namespace string) bool {
+			return namespace == "prod"
+		})
+
+	reports, err := converter.ConvertResource(context.TODO(), aquasecurity.K8SResourceVulnerability{
+		Namespace: "default",
+		Kind:      "Deployment",
+		Name:      "nginx",
+		Results: []aquasecurity.VulnerabilityScanResult{
+			{Target: "nginx:1.16 (debian 10.3)"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, reports)
+}
+
+func TestConverter_WithImageCache(t *testing.T) {
+	replicaSet := &appsv1.ReplicaSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apps/v1",
+			Kind:       "ReplicaSet",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6d4cf56db6",
+			Namespace: "default",
+		},
+		Spec: appsv1.ReplicaSetSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "nginx"},
+			},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "nginx", Image: "nginx:1.16"},
+					},
+				},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6d4cf56db6-xk8wz",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "nginx"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "nginx", Image: "nginx:1.16"},
+			},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "nginx", ImageID: "docker-pullable://nginx@" + testDigest},
+			},
+		},
+	}
+	testClient := fake.NewClientBuilder().
+		WithScheme(starboard.NewScheme()).
+		WithObjects(replicaSet, pod).
+		Build()
+	resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+	now := time.Date(2022, time.August, 1, 10, 0, 0, 0, time.UTC)
+	cache := vulnerabilityreport.NewImageCache(testClient, ext.NewFixedClock(now), time.Hour)
+	converter := vulnerabilityreport.NewConverter(testClient.Scheme(), &resolver, ext.NewFixedClock(now)).
+		WithImageCache(cache)
+
+	reports, err := converter.ConvertResource(context.TODO(), aquasecurity.K8SResourceVulnerability{
+		Namespace: "default",
+		Kind:      "ReplicaSet",
+		Name:      "nginx-6d4cf56db6",
+		Results: []aquasecurity.VulnerabilityScanResult{
+			{
+				Target: "nginx:1.16 (debian 10.3)",
+				Vulnerabilities: []aquasecurity.Vulnerability{
+					{VulnerabilityID: "CVE-2019-1547", PkgName: "openssl", InstalledVersion: "1.1.1c-r0", FixedVersion: "1.1.1d-r0", Severity: "LOW"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+
+	data, ok, err := cache.Get(context.TODO(), testDigest)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16", Digest: testDigest}, data.Artifact)
+	assert.Equal(t, reports[0].Report.Vulnerabilities, data.Vulnerabilities)
+}
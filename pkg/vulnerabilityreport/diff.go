@@ -0,0 +1,267 @@
+package vulnerabilityreport
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+)
+
+// Change classifies a vulnerability found by comparing two scans.
+type Change string
+
+const (
+	// ChangeNew is a vulnerability found only by the second scan.
+	ChangeNew Change = "New"
+	// ChangeFixed is a vulnerability found only by the first scan.
+	ChangeFixed Change = "Fixed"
+	// ChangeUnchanged is a vulnerability found by both scans.
+	ChangeUnchanged Change = "Unchanged"
+)
+
+// ContainerKey identifies a scanned container of a workload. Containers of
+// aquasecurity.TrivyReport are identified by their image reference, because
+// the report does not tell container names.
+type ContainerKey struct {
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Container string `json:"container"`
+}
+
+// VulnerabilityDiff is the Change of a single vulnerability of a package.
+type VulnerabilityDiff struct {
+	VulnerabilityID  string            `json:"vulnerabilityID"`
+	Resource         string            `json:"resource"`
+	InstalledVersion string            `json:"installedVersion"`
+	FixedVersion     string            `json:"fixedVersion"`
+	Change           Change            `json:"change"`
+	Severity         v1alpha1.Severity `json:"severity"`
+	// PreviousSeverity is set for unchanged vulnerabilities whose severity
+	// differs between the two scans.
+	PreviousSeverity v1alpha1.Severity `json:"previousSeverity,omitempty"`
+}
+
+// SeverityChanged returns true if the severity of an unchanged vulnerability
+// differs between the two scans.
+func (d VulnerabilityDiff) SeverityChanged() bool {
+	return d.PreviousSeverity != ""
+}
+
+// DiffSummary counts the vulnerabilities of a diff by Change.
+type DiffSummary struct {
+	NewCount             int `json:"newCount"`
+	FixedCount           int `json:"fixedCount"`
+	UnchangedCount       int `json:"unchangedCount"`
+	SeverityChangedCount int `json:"severityChangedCount"`
+}
+
+func (s *DiffSummary) add(diff VulnerabilityDiff) {
+	switch diff.Change {
+	case ChangeNew:
+		s.NewCount++
+	case ChangeFixed:
+		s.FixedCount++
+	case ChangeUnchanged:
+		s.UnchangedCount++
+	}
+	if diff.SeverityChanged() {
+		s.SeverityChangedCount++
+	}
+}
+
+// ContainerDiff lists vulnerability changes of a single container.
+type ContainerDiff struct {
+	ContainerKey    `json:",inline"`
+	Summary         DiffSummary         `json:"summary"`
+	Vulnerabilities []VulnerabilityDiff `json:"vulnerabilities"`
+}
+
+// Diff is the result of comparing two scans, container by container.
+type Diff struct {
+	Summary    DiffSummary     `json:"summary"`
+	Containers []ContainerDiff `json:"containers"`
+}
+
+// DiffReports compares two sets of v1alpha1.VulnerabilityReport instances,
+// which are matched by the workload and container they were created for.
+func DiffReports(before, after []v1alpha1.VulnerabilityReport) Diff {
+	return diffContainers(vulnerabilitiesByReport(before), vulnerabilitiesByReport(after))
+}
+
+// DiffTrivyReports compares two aquasecurity.TrivyReport instances, which are
+// matched by the workload and image they were produced for.
+func DiffTrivyReports(before, after *aquasecurity.TrivyReport) Diff {
+	return diffContainers(vulnerabilitiesByImage(before), vulnerabilitiesByImage(after))
+}
+
+// GetContainerKey returns the ContainerKey of the workload and container the
+// given v1alpha1.VulnerabilityReport was created for.
+func GetContainerKey(report v1alpha1.VulnerabilityReport) ContainerKey {
+	key := ContainerKey{
+		Namespace: report.Labels[starboard.LabelResourceNamespace],
+		Kind:      report.Labels[starboard.LabelResourceKind],
+		Name:      report.Labels[starboard.LabelResourceName],
+		Container: report.Labels[starboard.LabelContainerName],
+	}
+	if key.Namespace == "" {
+		key.Namespace = report.Namespace
+	}
+	if key.Name == "" {
+		key.Name = report.Name
+	}
+	return key
+}
+
+func vulnerabilitiesByReport(reports []v1alpha1.VulnerabilityReport) map[ContainerKey][]v1alpha1.Vulnerability {
+	containers := make(map[ContainerKey][]v1alpha1.Vulnerability)
+	for _, report := range reports {
+		key := GetContainerKey(report)
+		containers[key] = append(containers[key], report.Report.Vulnerabilities...)
+	}
+	return containers
+}
+
+// vulnerabilitiesByImage groups vulnerabilities by image. Trivy lists the OS
+// packages result of each image before the language specific results of that
+// image, see GroupResultsByContainer.
+func vulnerabilitiesByImage(report *aquasecurity.TrivyReport) map[ContainerKey][]v1alpha1.Vulnerability {
+	containers := make(map[ContainerKey][]v1alpha1.Vulnerability)
+	if report == nil || report.Report == nil {
+		return containers
+	}
+	for _, resource := range report.Report.Vulnerabilities {
+		image := ""
+		for _, result := range resource.Results {
+			if result.Class == "os-pkgs" {
+				image = result.Target
+				if i := strings.Index(image, " ("); i > 0 {
+					image = image[:i]
+				}
+			}
+			key := ContainerKey{
+				Namespace: resource.Namespace,
+				Kind:      resource.Kind,
+				Name:      resource.Name,
+				Container: image,
+			}
+			for _, vulnerability := range result.Vulnerabilities {
+				containers[key] = append(containers[key], toVulnerability(vulnerability))
+			}
+		}
+	}
+	return containers
+}
+
+type vulnerabilityKey struct {
+	id       string
+	resource string
+}
+
+func diffContainers(before, after map[ContainerKey][]v1alpha1.Vulnerability) Diff {
+	keys := make(map[ContainerKey]bool)
+	for key := range before {
+		keys[key] = true
+	}
+	for key := range after {
+		keys[key] = true
+	}
+
+	var diff Diff
+	for key := range keys {
+		containerDiff := ContainerDiff{
+			ContainerKey:    key,
+			Vulnerabilities: diffVulnerabilities(before[key], after[key]),
+		}
+		for _, vulnerability := range containerDiff.Vulnerabilities {
+			containerDiff.Summary.add(vulnerability)
+			diff.Summary.add(vulnerability)
+		}
+		diff.Containers = append(diff.Containers, containerDiff)
+	}
+	sort.Slice(diff.Containers, func(i, j int) bool {
+		return lessContainerKey(diff.Containers[i].ContainerKey, diff.Containers[j].ContainerKey)
+	})
+	return diff
+}
+
+// diffVulnerabilities matches vulnerabilities by ID and package, so that a
+// vulnerability that is still present after a package upgrade is unchanged.
+func diffVulnerabilities(before, after []v1alpha1.Vulnerability) []VulnerabilityDiff {
+	previous := make(map[vulnerabilityKey]v1alpha1.Vulnerability)
+	for _, vulnerability := range before {
+		previous[vulnerabilityKey{id: vulnerability.VulnerabilityID, resource: vulnerability.Resource}] = vulnerability
+	}
+
+	diffs := make([]VulnerabilityDiff, 0)
+	seen := make(map[vulnerabilityKey]bool)
+	for _, vulnerability := range after {
+		key := vulnerabilityKey{id: vulnerability.VulnerabilityID, resource: vulnerability.Resource}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		diff := newVulnerabilityDiff(vulnerability, ChangeNew)
+		if old, ok := previous[key]; ok {
+			diff.Change = ChangeUnchanged
+			if old.Severity != vulnerability.Severity {
+				diff.PreviousSeverity = old.Severity
+			}
+		}
+		diffs = append(diffs, diff)
+	}
+	for _, vulnerability := range before {
+		key := vulnerabilityKey{id: vulnerability.VulnerabilityID, resource: vulnerability.Resource}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		diffs = append(diffs, newVulnerabilityDiff(vulnerability, ChangeFixed))
+	}
+
+	sort.SliceStable(diffs, func(i, j int) bool {
+		if diffs[i].Change != diffs[j].Change {
+			return changeOrder[diffs[i].Change] < changeOrder[diffs[j].Change]
+		}
+		if diffs[i].Severity != diffs[j].Severity {
+			return severityOrder[diffs[i].Severity] < severityOrder[diffs[j].Severity]
+		}
+		if diffs[i].VulnerabilityID != diffs[j].VulnerabilityID {
+			return diffs[i].VulnerabilityID < diffs[j].VulnerabilityID
+		}
+		return diffs[i].Resource < diffs[j].Resource
+	})
+	return diffs
+}
+
+var changeOrder = map[Change]int{
+	ChangeNew:       0,
+	ChangeFixed:     1,
+	ChangeUnchanged: 2,
+}
+
+func newVulnerabilityDiff(vulnerability v1alpha1.Vulnerability, change Change) VulnerabilityDiff {
+	return VulnerabilityDiff{
+		VulnerabilityID:  vulnerability.VulnerabilityID,
+		Resource:         vulnerability.Resource,
+		InstalledVersion: vulnerability.InstalledVersion,
+		FixedVersion:     vulnerability.FixedVersion,
+		Change:           change,
+		Severity:         vulnerability.Severity,
+	}
+}
+
+func lessContainerKey(k1, k2 ContainerKey) bool {
+	if k1.Namespace != k2.Namespace {
+		return k1.Namespace < k2.Namespace
+	}
+	if k1.Kind != k2.Kind {
+		return k1.Kind < k2.Kind
+	}
+	if k1.Name != k2.Name {
+		return k1.Name < k2.Name
+	}
+	return k1.Container < k2.Container
+}
@@ -0,0 +1,129 @@
+package vulnerabilityreport_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiffReports(t *testing.T) {
+	newReport := func(container string, vulnerabilities ...v1alpha1.Vulnerability) v1alpha1.VulnerabilityReport {
+		return v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "replicaset-nginx-6d4cf56db6-" + container,
+				Namespace: "default",
+				Labels: map[string]string{
+					starboard.LabelResourceKind:      "ReplicaSet",
+					starboard.LabelResourceName:      "nginx-6d4cf56db6",
+					starboard.LabelResourceNamespace: "default",
+					starboard.LabelContainerName:     container,
+				},
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Vulnerabilities: vulnerabilities,
+			},
+		}
+	}
+
+	before := []v1alpha1.VulnerabilityReport{
+		newReport("nginx",
+			v1alpha1.Vulnerability{VulnerabilityID: "CVE-2019-1549", Resource: "openssl", InstalledVersion: "1.1.1c-r0", Severity: v1alpha1.SeverityMedium},
+			v1alpha1.Vulnerability{VulnerabilityID: "CVE-2019-1547", Resource: "openssl", InstalledVersion: "1.1.1c-r0", Severity: v1alpha1.SeverityLow},
+		),
+		newReport("sidecar",
+			v1alpha1.Vulnerability{VulnerabilityID: "CVE-2020-1967", Resource: "libssl1.1", Severity: v1alpha1.SeverityHigh},
+		),
+	}
+	after := []v1alpha1.VulnerabilityReport{
+		newReport("nginx",
+			v1alpha1.Vulnerability{VulnerabilityID: "CVE-2019-1549", Resource: "openssl", InstalledVersion: "1.1.1d-r0", Severity: v1alpha1.SeverityHigh},
+			v1alpha1.Vulnerability{VulnerabilityID: "CVE-2021-3711", Resource: "openssl", InstalledVersion: "1.1.1d-r0", Severity: v1alpha1.SeverityCritical},
+		),
+	}
+
+	assert.Equal(t, vulnerabilityreport.Diff{
+		Summary: vulnerabilityreport.DiffSummary{
+			NewCount:             1,
+			FixedCount:           2,
+			UnchangedCount:       1,
+			SeverityChangedCount: 1,
+		},
+		Containers: []vulnerabilityreport.ContainerDiff{
+			{
+				ContainerKey: vulnerabilityreport.ContainerKey{Namespace: "default", Kind: "ReplicaSet", Name: "nginx-6d4cf56db6", Container: "nginx"},
+				Summary: vulnerabilityreport.DiffSummary{
+					NewCount:             1,
+					FixedCount:           1,
+					UnchangedCount:       1,
+					SeverityChangedCount: 1,
+				},
+				Vulnerabilities: []vulnerabilityreport.VulnerabilityDiff{
+					{VulnerabilityID: "CVE-2021-3711", Resource: "openssl", InstalledVersion: "1.1.1d-r0", Change: vulnerabilityreport.ChangeNew, Severity: v1alpha1.SeverityCritical},
+					{VulnerabilityID: "CVE-2019-1547", Resource: "openssl", InstalledVersion: "1.1.1c-r0", Change: vulnerabilityreport.ChangeFixed, Severity: v1alpha1.SeverityLow},
+					{VulnerabilityID: "CVE-2019-1549", Resource: "openssl", InstalledVersion: "1.1.1d-r0", Change: vulnerabilityreport.ChangeUnchanged, Severity: v1alpha1.SeverityHigh, PreviousSeverity: v1alpha1.SeverityMedium},
+				},
+			},
+			{
+				ContainerKey: vulnerabilityreport.ContainerKey{Namespace: "default", Kind: "ReplicaSet", Name: "nginx-6d4cf56db6", Container: "sidecar"},
+				Summary: vulnerabilityreport.DiffSummary{
+					FixedCount: 1,
+				},
+				Vulnerabilities: []vulnerabilityreport.VulnerabilityDiff{
+					{VulnerabilityID: "CVE-2020-1967", Resource: "libssl1.1", Change: vulnerabilityreport.ChangeFixed, Severity: v1alpha1.SeverityHigh},
+				},
+			},
+		},
+	}, vulnerabilityreport.DiffReports(before, after))
+}
+
+func TestDiffTrivyReports(t *testing.T) {
+	newReport := func(results ...aquasecurity.VulnerabilityScanResult) *aquasecurity.TrivyReport {
+		return &aquasecurity.TrivyReport{
+			Report: &aquasecurity.ScanReport{
+				Vulnerabilities: []aquasecurity.K8SResourceVulnerability{
+					{Namespace: "default", Kind: "Deployment", Name: "app", Results: results},
+				},
+			},
+		}
+	}
+
+	before := newReport(
+		aquasecurity.VulnerabilityScanResult{
+			Target: "app:1.0 (alpine 3.10.2)",
+			Class:  "os-pkgs",
+			Vulnerabilities: []aquasecurity.Vulnerability{
+				{VulnerabilityID: "CVE-2019-1549", PkgName: "openssl", Severity: "MEDIUM"},
+			},
+		},
+		aquasecurity.VulnerabilityScanResult{
+			Target: "app/package-lock.json",
+			Class:  "lang-pkgs",
+			Vulnerabilities: []aquasecurity.Vulnerability{
+				{VulnerabilityID: "CVE-2021-23337", PkgName: "lodash", Severity: "HIGH"},
+			},
+		},
+	)
+	after := newReport(
+		aquasecurity.VulnerabilityScanResult{
+			Target: "app:1.0 (alpine 3.10.2)",
+			Class:  "os-pkgs",
+			Vulnerabilities: []aquasecurity.Vulnerability{
+				{VulnerabilityID: "CVE-2019-1549", PkgName: "openssl", Severity: "MEDIUM"},
+			},
+		},
+	)
+
+	diff := vulnerabilityreport.DiffTrivyReports(before, after)
+	assert.Equal(t, vulnerabilityreport.DiffSummary{FixedCount: 1, UnchangedCount: 1}, diff.Summary)
+	assert.Len(t, diff.Containers, 1)
+	assert.Equal(t, vulnerabilityreport.ContainerKey{Namespace: "default", Kind: "Deployment", Name: "app", Container: "app:1.0"}, diff.Containers[0].ContainerKey)
+	assert.Equal(t, []vulnerabilityreport.VulnerabilityDiff{
+		{VulnerabilityID: "CVE-2021-23337", Resource: "lodash", Change: vulnerabilityreport.ChangeFixed, Severity: v1alpha1.SeverityHigh},
+		{VulnerabilityID: "CVE-2019-1549", Resource: "openssl", Change: vulnerabilityreport.ChangeUnchanged, Severity: v1alpha1.SeverityMedium},
+	}, diff.Containers[0].Vulnerabilities)
+}
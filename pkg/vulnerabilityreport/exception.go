@@ -0,0 +1,111 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ListActiveExceptions returns v1alpha1.VulnerabilityException instances in
+// the given namespace that have not expired at the given time. It returns an
+// empty slice if the VulnerabilityException resource is not installed.
+func ListActiveExceptions(ctx context.Context, c client.Reader, namespace string, now time.Time) ([]v1alpha1.VulnerabilityException, error) {
+	var list v1alpha1.VulnerabilityExceptionList
+	active := make([]v1alpha1.VulnerabilityException, 0)
+	err := c.List(ctx, &list, client.InNamespace(namespace))
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return active, nil
+		}
+		return nil, fmt.Errorf("listing vulnerability exceptions: %w", err)
+	}
+	for _, exception := range list.Items {
+		if !exception.IsExpired(now) {
+			active = append(active, exception)
+		}
+	}
+	return active, nil
+}
+
+// ApplyExceptions marks vulnerabilities of the given
+// v1alpha1.VulnerabilityReportData that match any of the given exceptions as
+// suppressed, and clears this mark from all other vulnerabilities. The summary
+// is recalculated so that suppressed vulnerabilities are only included in the
+// SuppressedCount.
+//
+// The workload is the object that owns the report. If it's nil, exceptions
+// with a workload selector do not match.
+func ApplyExceptions(data *v1alpha1.VulnerabilityReportData, workload client.Object, exceptions []v1alpha1.VulnerabilityException) {
+	for i := range data.Vulnerabilities {
+		vulnerability := &data.Vulnerabilities[i]
+		vulnerability.Suppressed = false
+		vulnerability.SuppressedBy = ""
+		for _, exception := range exceptions {
+			if MatchesException(exception, workload, data.Registry, data.Artifact, *vulnerability) {
+				vulnerability.Suppressed = true
+				vulnerability.SuppressedBy = exception.Name
+				break
+			}
+		}
+	}
+	summary := Summarize(data.Vulnerabilities)
+	summary.NoneCount = data.Summary.NoneCount
+	data.Summary = summary
+}
+
+// MatchesException returns true if the given v1alpha1.VulnerabilityException
+// applies to the vulnerability found in the given artifact of the given
+// workload. Expiry is not taken into account.
+func MatchesException(exception v1alpha1.VulnerabilityException, workload client.Object,
+	registry v1alpha1.Registry, artifact v1alpha1.Artifact, vulnerability v1alpha1.Vulnerability) bool {
+	spec := exception.Spec
+	if spec.VulnerabilityID == "" && spec.Resource == "" {
+		return false
+	}
+	if spec.VulnerabilityID != "" && !strings.EqualFold(spec.VulnerabilityID, vulnerability.VulnerabilityID) {
+		return false
+	}
+	if spec.Resource != "" && !matchesPattern(spec.Resource, vulnerability.Resource) {
+		return false
+	}
+	if workload != nil && exception.Namespace != workload.GetNamespace() {
+		return false
+	}
+	if spec.Scope.WorkloadSelector != nil {
+		if workload == nil {
+			return false
+		}
+		selector, err := metav1.LabelSelectorAsSelector(spec.Scope.WorkloadSelector)
+		if err != nil || !selector.Matches(labels.Set(workload.GetLabels())) {
+			return false
+		}
+	}
+	if len(spec.Scope.Repositories) > 0 {
+		repository := artifact.Repository
+		qualified := registry.Server + "/" + artifact.Repository
+		matched := false
+		for _, pattern := range spec.Scope.Repositories {
+			if matchesPattern(pattern, repository) || matchesPattern(pattern, qualified) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesPattern(pattern, value string) bool {
+	matched, err := path.Match(pattern, value)
+	return err == nil && matched
+}
@@ -0,0 +1,147 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/ext"
+	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ReasonExceptionExpired is the reason of the event raised when a
+	// v1alpha1.VulnerabilityException expires.
+	ReasonExceptionExpired = "ExceptionExpired"
+)
+
+// ExceptionController watches v1alpha1.VulnerabilityException instances and
+// applies them to existing v1alpha1.VulnerabilityReport instances in their
+// namespace, so that reports reflect created, updated and deleted exceptions
+// without rescanning workloads.
+//
+// Exceptions are reconciled again when they expire. Vulnerabilities they
+// suppressed become visible, the exception is marked as expired and an event
+// is raised for it.
+type ExceptionController struct {
+	logr.Logger
+	client.Client
+	kube.ObjectResolver
+	ext.Clock
+	record.EventRecorder
+}
+
+func (r *ExceptionController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.VulnerabilityException{}).
+		Complete(r.reconcileExceptions())
+}
+
+func (r *ExceptionController) reconcileExceptions() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		log := r.Logger.WithValues("namespace", req.Namespace)
+		now := r.Clock.Now()
+
+		var exceptions v1alpha1.VulnerabilityExceptionList
+		err := r.Client.List(ctx, &exceptions, client.InNamespace(req.Namespace))
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("listing vulnerability exceptions: %w", err)
+		}
+
+		active := make([]v1alpha1.VulnerabilityException, 0)
+		var nextExpiry *time.Time
+		for i := range exceptions.Items {
+			exception := &exceptions.Items[i]
+			expired := exception.IsExpired(now)
+			if !expired {
+				active = append(active, *exception)
+				if nextExpiry == nil || exception.Spec.ExpiresAt.Time.Before(*nextExpiry) {
+					nextExpiry = &exception.Spec.ExpiresAt.Time
+				}
+			}
+			if exception.Status.Expired == expired {
+				continue
+			}
+			if expired {
+				log.V(1).Info("Vulnerability exception expired", "exception", exception.Name)
+				r.EventRecorder.Eventf(exception, corev1.EventTypeWarning, ReasonExceptionExpired,
+					"Exception expired at %s, matching vulnerabilities are no longer suppressed",
+					exception.Spec.ExpiresAt.UTC().Format(time.RFC3339))
+			}
+			exception.Status.Expired = expired
+			err = r.Client.Status().Update(ctx, exception)
+			if err != nil && !k8sapierror.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("updating vulnerability exception status: %w", err)
+			}
+		}
+
+		err = r.applyExceptions(ctx, req.Namespace, active)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if nextExpiry != nil {
+			return ctrl.Result{RequeueAfter: nextExpiry.Sub(now)}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+}
+
+// applyExceptions updates v1alpha1.VulnerabilityReport instances in the given
+// namespace whose suppressed vulnerabilities differ from the ones matched by
+// the given active exceptions.
+func (r *ExceptionController) applyExceptions(ctx context.Context, namespace string, exceptions []v1alpha1.VulnerabilityException) error {
+	var reports v1alpha1.VulnerabilityReportList
+	err := r.Client.List(ctx, &reports, client.InNamespace(namespace))
+	if err != nil {
+		return fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	for _, report := range reports.Items {
+		workload, err := r.reportOwner(ctx, report)
+		if err != nil {
+			return err
+		}
+		copied := report.DeepCopy()
+		ApplyExceptions(&copied.Report, workload, exceptions)
+		if equality.Semantic.DeepEqual(copied.Report, report.Report) {
+			continue
+		}
+		r.Logger.V(1).Info("Updating suppressed vulnerabilities", "report", namespace+"/"+report.Name)
+		err = r.Client.Update(ctx, copied)
+		if err != nil && !k8sapierror.IsNotFound(err) {
+			return fmt.Errorf("updating vulnerability report: %w", err)
+		}
+	}
+	return nil
+}
+
+// reportOwner returns the workload that controls the given report, or nil if
+// it does not exist.
+func (r *ExceptionController) reportOwner(ctx context.Context, report v1alpha1.VulnerabilityReport) (client.Object, error) {
+	ownerRef := metav1.GetControllerOf(&report)
+	if ownerRef == nil {
+		return nil, nil
+	}
+	owner, err := r.ObjectResolver.ObjectFromObjectRef(ctx, kube.ObjectRef{
+		Kind:      kube.Kind(ownerRef.Kind),
+		Name:      ownerRef.Name,
+		Namespace: report.Namespace,
+	})
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting owner of vulnerability report %s/%s: %w", report.Namespace, report.Name, err)
+	}
+	return owner, nil
+}
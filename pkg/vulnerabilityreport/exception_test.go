@@ -0,0 +1,199 @@
+package vulnerabilityreport_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newException(name string, spec v1alpha1.VulnerabilityExceptionSpec) v1alpha1.VulnerabilityException {
+	return v1alpha1.VulnerabilityException{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: spec,
+	}
+}
+
+func TestMatchesException(t *testing.T) {
+	workload := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nginx-6d4cf56db6",
+			Namespace: "default",
+			Labels:    map[string]string{"app": "nginx"},
+		},
+	}
+	registry := v1alpha1.Registry{Server: "index.docker.io"}
+	artifact := v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.16"}
+	vulnerability := v1alpha1.Vulnerability{VulnerabilityID: "CVE-2019-1547", Resource: "libssl1.1"}
+
+	testCases := []struct {
+		name     string
+		spec     v1alpha1.VulnerabilityExceptionSpec
+		workload *appsv1.ReplicaSet
+		expected bool
+	}{
+		{
+			name:     "Should match vulnerability ID",
+			spec:     v1alpha1.VulnerabilityExceptionSpec{VulnerabilityID: "cve-2019-1547"},
+			workload: workload,
+			expected: true,
+		},
+		{
+			name:     "Should not match other vulnerability ID",
+			spec:     v1alpha1.VulnerabilityExceptionSpec{VulnerabilityID: "CVE-2019-1549"},
+			workload: workload,
+			expected: false,
+		},
+		{
+			name:     "Should match resource pattern",
+			spec:     v1alpha1.VulnerabilityExceptionSpec{Resource: "libssl*"},
+			workload: workload,
+			expected: true,
+		},
+		{
+			name:     "Should not match without vulnerability ID and resource",
+			spec:     v1alpha1.VulnerabilityExceptionSpec{},
+			workload: workload,
+			expected: false,
+		},
+		{
+			name: "Should match workload selector",
+			spec: v1alpha1.VulnerabilityExceptionSpec{
+				Resource: "libssl1.1",
+				Scope: v1alpha1.VulnerabilityExceptionScope{
+					WorkloadSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+				},
+			},
+			workload: workload,
+			expected: true,
+		},
+		{
+			name: "Should not match other workloads",
+			spec: v1alpha1.VulnerabilityExceptionSpec{
+				Resource: "libssl1.1",
+				Scope: v1alpha1.VulnerabilityExceptionScope{
+					WorkloadSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "redis"}},
+				},
+			},
+			workload: workload,
+			expected: false,
+		},
+		{
+			name: "Should not match workload selector without workload",
+			spec: v1alpha1.VulnerabilityExceptionSpec{
+				Resource: "libssl1.1",
+				Scope: v1alpha1.VulnerabilityExceptionScope{
+					WorkloadSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "nginx"}},
+				},
+			},
+			expected: false,
+		},
+		{
+			name: "Should match repository pattern",
+			spec: v1alpha1.VulnerabilityExceptionSpec{
+				VulnerabilityID: "CVE-2019-1547",
+				Scope: v1alpha1.VulnerabilityExceptionScope{
+					Repositories: []string{"quay.io/*/*", "index.docker.io/library/*"},
+				},
+			},
+			workload: workload,
+			expected: true,
+		},
+		{
+			name: "Should not match other repositories",
+			spec: v1alpha1.VulnerabilityExceptionSpec{
+				VulnerabilityID: "CVE-2019-1547",
+				Scope: v1alpha1.VulnerabilityExceptionScope{
+					Repositories: []string{"library/redis"},
+				},
+			},
+			workload: workload,
+			expected: false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			exception := newException("exception", tc.spec)
+			var matched bool
+			if tc.workload != nil {
+				matched = vulnerabilityreport.MatchesException(exception, tc.workload, registry, artifact, vulnerability)
+			} else {
+				matched = vulnerabilityreport.MatchesException(exception, nil, registry, artifact, vulnerability)
+			}
+			assert.Equal(t, tc.expected, matched)
+		})
+	}
+
+	t.Run("Should not match workloads in other namespaces", func(t *testing.T) {
+		exception := newException("exception", v1alpha1.VulnerabilityExceptionSpec{VulnerabilityID: "CVE-2019-1547"})
+		exception.Namespace = "staging"
+		assert.False(t, vulnerabilityreport.MatchesException(exception, workload, registry, artifact, vulnerability))
+	})
+}
+
+func TestApplyExceptions(t *testing.T) {
+	data := v1alpha1.VulnerabilityReportData{
+		Summary: v1alpha1.VulnerabilitySummary{
+			CriticalCount: 1,
+			LowCount:      1,
+			NoneCount:     3,
+		},
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2019-1549", Resource: "libssl1.1", Severity: v1alpha1.SeverityCritical, KnownExploited: true},
+			{VulnerabilityID: "CVE-2019-1547", Resource: "openssl", Severity: v1alpha1.SeverityLow,
+				Suppressed: true, SuppressedBy: "deleted-exception"},
+		},
+	}
+	vulnerabilityreport.ApplyExceptions(&data, nil, []v1alpha1.VulnerabilityException{
+		newException("accept-libssl", v1alpha1.VulnerabilityExceptionSpec{Resource: "libssl*"}),
+	})
+	assert.Equal(t, v1alpha1.VulnerabilityReportData{
+		Summary: v1alpha1.VulnerabilitySummary{
+			LowCount:        1,
+			NoneCount:       3,
+			SuppressedCount: 1,
+		},
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2019-1549", Resource: "libssl1.1", Severity: v1alpha1.SeverityCritical, KnownExploited: true,
+				Suppressed: true, SuppressedBy: "accept-libssl"},
+			{VulnerabilityID: "CVE-2019-1547", Resource: "openssl", Severity: v1alpha1.SeverityLow},
+		},
+	}, data)
+}
+
+func TestListActiveExceptions(t *testing.T) {
+	now := time.Date(2022, time.September, 1, 10, 0, 0, 0, time.UTC)
+	active := newException("active", v1alpha1.VulnerabilityExceptionSpec{
+		VulnerabilityID: "CVE-2019-1547",
+		ExpiresAt:       metav1.NewTime(now.Add(time.Hour)),
+	})
+	expired := newException("expired", v1alpha1.VulnerabilityExceptionSpec{
+		VulnerabilityID: "CVE-2019-1549",
+		ExpiresAt:       metav1.NewTime(now),
+	})
+	testClient := fake.NewClientBuilder().
+		WithScheme(starboard.NewScheme()).
+		WithObjects(&active, &expired).
+		Build()
+
+	exceptions, err := vulnerabilityreport.ListActiveExceptions(context.TODO(), testClient, "default", now)
+	require.NoError(t, err)
+	require.Len(t, exceptions, 1)
+	assert.Equal(t, "active", exceptions[0].Name)
+
+	exceptions, err = vulnerabilityreport.ListActiveExceptions(context.TODO(), testClient, "staging", now)
+	require.NoError(t, err)
+	assert.NotNil(t, exceptions)
+	assert.Empty(t, exceptions)
+}
@@ -0,0 +1,273 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	corev1 "k8s.io/api/core/v1"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// KeyKnownExploitedCatalog is the key of the ExploitCatalog ConfigMap that
+	// holds the catalog of known exploited vulnerabilities in the JSON format
+	// published by CISA.
+	KeyKnownExploitedCatalog = "kev.json"
+
+	// KeyExploitProbabilities is the key of the ExploitCatalog ConfigMap that
+	// holds EPSS scores in the CSV format published by FIRST.
+	KeyExploitProbabilities = "epss.csv"
+
+	// ReasonInvalidExploitCatalog is the reason of the event raised when the
+	// ExploitCatalog ConfigMap holds a malformed catalog.
+	ReasonInvalidExploitCatalog = "InvalidExploitCatalog"
+)
+
+// KnownExploitedVulnerability is an entry of the catalog of known exploited
+// vulnerabilities.
+type KnownExploitedVulnerability struct {
+	CVEID     string `json:"cveID"`
+	DateAdded string `json:"dateAdded"`
+}
+
+type knownExploitedCatalog struct {
+	Vulnerabilities []KnownExploitedVulnerability `json:"vulnerabilities"`
+}
+
+// ExploitCatalog annotates vulnerabilities that are known to be exploited in
+// the wild and their exploit probabilities. A nil ExploitCatalog annotates
+// nothing.
+type ExploitCatalog struct {
+	knownExploited map[string]KnownExploitedVulnerability
+	probabilities  map[string]float64
+}
+
+// NewExploitCatalog constructs a new ExploitCatalog from the given catalog of
+// known exploited vulnerabilities and EPSS scores. Either of them may be
+// empty.
+func NewExploitCatalog(kev, epss string) (*ExploitCatalog, error) {
+	catalog := &ExploitCatalog{
+		knownExploited: make(map[string]KnownExploitedVulnerability),
+		probabilities:  make(map[string]float64),
+	}
+	if strings.TrimSpace(kev) != "" {
+		entries, err := ParseKnownExploitedCatalog(strings.NewReader(kev))
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			catalog.knownExploited[entry.CVEID] = entry
+		}
+	}
+	if strings.TrimSpace(epss) != "" {
+		probabilities, err := ParseExploitProbabilities(strings.NewReader(epss))
+		if err != nil {
+			return nil, err
+		}
+		catalog.probabilities = probabilities
+	}
+	return catalog, nil
+}
+
+// ParseKnownExploitedCatalog parses the catalog of known exploited
+// vulnerabilities in the JSON format published by CISA.
+func ParseKnownExploitedCatalog(r io.Reader) ([]KnownExploitedVulnerability, error) {
+	var catalog knownExploitedCatalog
+	err := json.NewDecoder(r).Decode(&catalog)
+	if err != nil {
+		return nil, fmt.Errorf("decoding known exploited vulnerabilities catalog: %w", err)
+	}
+	var entries []KnownExploitedVulnerability
+	for _, entry := range catalog.Vulnerabilities {
+		if entry.CVEID == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// ParseExploitProbabilities parses EPSS scores in the CSV format published by
+// FIRST, i.e. the cve, epss and percentile columns preceded by an optional
+// comment line with the model version.
+func ParseExploitProbabilities(r io.Reader) (map[string]float64, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading EPSS scores header: %w", err)
+	}
+	cveIndex, epssIndex := -1, -1
+	for i, column := range header {
+		switch strings.ToLower(strings.TrimSpace(column)) {
+		case "cve":
+			cveIndex = i
+		case "epss":
+			epssIndex = i
+		}
+	}
+	if cveIndex < 0 || epssIndex < 0 {
+		return nil, errors.New("EPSS scores must have cve and epss columns")
+	}
+
+	probabilities := make(map[string]float64)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading EPSS scores: %w", err)
+		}
+		if len(record) <= cveIndex || len(record) <= epssIndex {
+			continue
+		}
+		probability, err := strconv.ParseFloat(strings.TrimSpace(record[epssIndex]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing EPSS score of %s: %w", record[cveIndex], err)
+		}
+		probabilities[strings.TrimSpace(record[cveIndex])] = probability
+	}
+	return probabilities, nil
+}
+
+// InvalidExploitCatalogError is returned when the ExploitCatalog ConfigMap
+// holds a malformed catalog.
+type InvalidExploitCatalogError struct {
+	ConfigMap corev1.ConfigMap
+	Err       error
+}
+
+func (e *InvalidExploitCatalogError) Error() string {
+	return fmt.Sprintf("loading exploit catalog from configmap %s/%s: %v", e.ConfigMap.Namespace, e.ConfigMap.Name, e.Err)
+}
+
+func (e *InvalidExploitCatalogError) Unwrap() error {
+	return e.Err
+}
+
+// LoadExploitCatalog reads the ExploitCatalog from the ConfigMap named
+// starboard.ExploitCatalogConfigMapName in the given namespace. It returns
+// nil if the ConfigMap does not exist, and an InvalidExploitCatalogError if
+// the ConfigMap holds a malformed catalog.
+func LoadExploitCatalog(ctx context.Context, c client.Reader, namespace string) (*ExploitCatalog, error) {
+	cm, err := getExploitCatalogConfigMap(ctx, c, namespace)
+	if err != nil || cm == nil {
+		return nil, err
+	}
+	return newExploitCatalogFromConfigMap(*cm)
+}
+
+func getExploitCatalogConfigMap(ctx context.Context, c client.Reader, namespace string) (*corev1.ConfigMap, error) {
+	var cm corev1.ConfigMap
+	err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: starboard.ExploitCatalogConfigMapName}, &cm)
+	if err != nil {
+		if k8sapierror.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("getting exploit catalog: %w", err)
+	}
+	return &cm, nil
+}
+
+func newExploitCatalogFromConfigMap(cm corev1.ConfigMap) (*ExploitCatalog, error) {
+	catalog, err := NewExploitCatalog(cm.Data[KeyKnownExploitedCatalog], cm.Data[KeyExploitProbabilities])
+	if err != nil {
+		return nil, &InvalidExploitCatalogError{ConfigMap: cm, Err: err}
+	}
+	return catalog, nil
+}
+
+// ExploitCatalogCache holds the ExploitCatalog parsed from the ConfigMap named
+// starboard.ExploitCatalogConfigMapName in the given namespace. The ConfigMap
+// is parsed again only when its resourceVersion changes.
+type ExploitCatalogCache struct {
+	reader    client.Reader
+	namespace string
+
+	mu              sync.Mutex
+	resourceVersion string
+	catalog         *ExploitCatalog
+}
+
+// NewExploitCatalogCache constructs a new ExploitCatalogCache, which reads the
+// ConfigMap with the given client.Reader.
+func NewExploitCatalogCache(reader client.Reader, namespace string) *ExploitCatalogCache {
+	return &ExploitCatalogCache{
+		reader:    reader,
+		namespace: namespace,
+	}
+}
+
+// Load returns the current ExploitCatalog, or nil if the ConfigMap does not
+// exist or holds a malformed catalog. A malformed catalog is reported with an
+// InvalidExploitCatalogError by the first call after the ConfigMap changed
+// and ignored by later calls, so that it is reported once.
+func (c *ExploitCatalogCache) Load(ctx context.Context) (*ExploitCatalog, error) {
+	cm, err := getExploitCatalogConfigMap(ctx, c.reader, c.namespace)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cm == nil {
+		c.resourceVersion, c.catalog = "", nil
+		return nil, nil
+	}
+	if cm.ResourceVersion == c.resourceVersion {
+		return c.catalog, nil
+	}
+	c.resourceVersion = cm.ResourceVersion
+	c.catalog, err = newExploitCatalogFromConfigMap(*cm)
+	return c.catalog, err
+}
+
+// Annotate sets the known exploited and exploit probability properties of the
+// given aquasecurity.Vulnerability.
+func (c *ExploitCatalog) Annotate(vulnerability *aquasecurity.Vulnerability) {
+	if c == nil {
+		return
+	}
+	entry, known := c.knownExploited[vulnerability.VulnerabilityID]
+	vulnerability.KnownExploited = known
+	vulnerability.KnownExploitedDateAdded = entry.DateAdded
+	vulnerability.ExploitProbability = c.probability(vulnerability.VulnerabilityID)
+}
+
+// AnnotateReportData sets the known exploited and exploit probability
+// properties of all vulnerabilities of the given
+// v1alpha1.VulnerabilityReportData and updates its summary accordingly.
+func (c *ExploitCatalog) AnnotateReportData(data *v1alpha1.VulnerabilityReportData) {
+	if c == nil {
+		return
+	}
+	for i := range data.Vulnerabilities {
+		vulnerability := &data.Vulnerabilities[i]
+		entry, known := c.knownExploited[vulnerability.VulnerabilityID]
+		vulnerability.KnownExploited = known
+		vulnerability.KnownExploitedDateAdded = entry.DateAdded
+		vulnerability.ExploitProbability = c.probability(vulnerability.VulnerabilityID)
+	}
+	data.Summary.ExploitedCount = Summarize(data.Vulnerabilities).ExploitedCount
+}
+
+func (c *ExploitCatalog) probability(vulnerabilityID string) *float64 {
+	probability, ok := c.probabilities[vulnerabilityID]
+	if !ok {
+		return nil
+	}
+	return &probability
+}
@@ -0,0 +1,220 @@
+package vulnerabilityreport_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testKnownExploitedCatalog = `{
+  "title": "CISA Catalog of Known Exploited Vulnerabilities",
+  "catalogVersion": "2022.09.01",
+  "count": 2,
+  "vulnerabilities": [
+    {
+      "cveID": "CVE-2021-44228",
+      "vendorProject": "Apache",
+      "product": "Log4j2",
+      "dateAdded": "2021-12-10"
+    },
+    {
+      "cveID": "CVE-2022-22965",
+      "vendorProject": "VMware",
+      "product": "Spring Framework",
+      "dateAdded": "2022-04-04"
+    }
+  ]
+}`
+
+const testExploitProbabilities = `#model_version:v2022.01.01,score_date:2022-09-01T00:00:00+0000
+cve,epss,percentile
+CVE-2021-44228,0.97565,1.00000
+CVE-2020-8908,0.00064,0.26234
+`
+
+func TestParseKnownExploitedCatalog(t *testing.T) {
+	entries, err := vulnerabilityreport.ParseKnownExploitedCatalog(strings.NewReader(testKnownExploitedCatalog))
+	require.NoError(t, err)
+	assert.Equal(t, []vulnerabilityreport.KnownExploitedVulnerability{
+		{CVEID: "CVE-2021-44228", DateAdded: "2021-12-10"},
+		{CVEID: "CVE-2022-22965", DateAdded: "2022-04-04"},
+	}, entries)
+
+	_, err = vulnerabilityreport.ParseKnownExploitedCatalog(strings.NewReader("not a catalog"))
+	assert.Error(t, err)
+}
+
+func TestParseExploitProbabilities(t *testing.T) {
+	probabilities, err := vulnerabilityreport.ParseExploitProbabilities(strings.NewReader(testExploitProbabilities))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{
+		"CVE-2021-44228": 0.97565,
+		"CVE-2020-8908":  0.00064,
+	}, probabilities)
+
+	_, err = vulnerabilityreport.ParseExploitProbabilities(strings.NewReader("id,score\nCVE-2021-44228,0.9\n"))
+	assert.EqualError(t, err, "EPSS scores must have cve and epss columns")
+
+	_, err = vulnerabilityreport.ParseExploitProbabilities(strings.NewReader("cve,epss\nCVE-2021-44228,high\n"))
+	assert.Error(t, err)
+}
+
+func TestExploitCatalog_Annotate(t *testing.T) {
+	catalog, err := vulnerabilityreport.NewExploitCatalog(testKnownExploitedCatalog, testExploitProbabilities)
+	require.NoError(t, err)
+
+	vulnerability := aquasecurity.Vulnerability{VulnerabilityID: "CVE-2021-44228"}
+	catalog.Annotate(&vulnerability)
+	assert.True(t, vulnerability.KnownExploited)
+	assert.Equal(t, "2021-12-10", vulnerability.KnownExploitedDateAdded)
+	assert.Equal(t, pointer.Float64Ptr(0.97565), vulnerability.ExploitProbability)
+
+	vulnerability = aquasecurity.Vulnerability{VulnerabilityID: "CVE-2020-8908"}
+	catalog.Annotate(&vulnerability)
+	assert.False(t, vulnerability.KnownExploited)
+	assert.Empty(t, vulnerability.KnownExploitedDateAdded)
+	assert.Equal(t, pointer.Float64Ptr(0.00064), vulnerability.ExploitProbability)
+
+	var noCatalog *vulnerabilityreport.ExploitCatalog
+	vulnerability = aquasecurity.Vulnerability{VulnerabilityID: "CVE-2021-44228"}
+	noCatalog.Annotate(&vulnerability)
+	assert.False(t, vulnerability.KnownExploited)
+	assert.Nil(t, vulnerability.ExploitProbability)
+}
+
+func TestExploitCatalog_AnnotateReportData(t *testing.T) {
+	catalog, err := vulnerabilityreport.NewExploitCatalog(testKnownExploitedCatalog, "")
+	require.NoError(t, err)
+
+	data := v1alpha1.VulnerabilityReportData{
+		Summary: v1alpha1.VulnerabilitySummary{
+			CriticalCount:  1,
+			MediumCount:    1,
+			ExploitedCount: 2,
+		},
+		Vulnerabilities: []v1alpha1.Vulnerability{
+			{VulnerabilityID: "CVE-2022-22965", Severity: v1alpha1.SeverityCritical},
+			{VulnerabilityID: "CVE-2020-8908", Severity: v1alpha1.SeverityMedium, KnownExploited: true},
+		},
+	}
+	catalog.AnnotateReportData(&data)
+	assert.Equal(t, v1alpha1.VulnerabilitySummary{
+		CriticalCount:  1,
+		MediumCount:    1,
+		ExploitedCount: 1,
+	}, data.Summary)
+	assert.Equal(t, []v1alpha1.Vulnerability{
+		{VulnerabilityID: "CVE-2022-22965", Severity: v1alpha1.SeverityCritical,
+			KnownExploited: true, KnownExploitedDateAdded: "2022-04-04"},
+		{VulnerabilityID: "CVE-2020-8908", Severity: v1alpha1.SeverityMedium},
+	}, data.Vulnerabilities)
+}
+
+func TestLoadExploitCatalog(t *testing.T) {
+	t.Run("Should return nil when ConfigMap does not exist", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).Build()
+		catalog, err := vulnerabilityreport.LoadExploitCatalog(context.TODO(), testClient, "starboard")
+		require.NoError(t, err)
+		assert.Nil(t, catalog)
+	})
+
+	t.Run("Should load catalog from ConfigMap", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      starboard.ExploitCatalogConfigMapName,
+				Namespace: "starboard",
+			},
+			Data: map[string]string{
+				vulnerabilityreport.KeyKnownExploitedCatalog: testKnownExploitedCatalog,
+			},
+		}).Build()
+		catalog, err := vulnerabilityreport.LoadExploitCatalog(context.TODO(), testClient, "starboard")
+		require.NoError(t, err)
+		vulnerability := aquasecurity.Vulnerability{VulnerabilityID: "CVE-2022-22965"}
+		catalog.Annotate(&vulnerability)
+		assert.True(t, vulnerability.KnownExploited)
+		assert.Nil(t, vulnerability.ExploitProbability)
+	})
+
+	t.Run("Should return error when catalog is malformed", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      starboard.ExploitCatalogConfigMapName,
+				Namespace: "starboard",
+			},
+			Data: map[string]string{
+				vulnerabilityreport.KeyExploitProbabilities: "cve\nCVE-2022-22965\n",
+			},
+		}).Build()
+		_, err := vulnerabilityreport.LoadExploitCatalog(context.TODO(), testClient, "starboard")
+		assert.EqualError(t, err, "loading exploit catalog from configmap starboard/starboard-exploit-catalog: EPSS scores must have cve and epss columns")
+	})
+}
+
+func TestExploitCatalogCache(t *testing.T) {
+	newConfigMap := func(epss string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      starboard.ExploitCatalogConfigMapName,
+				Namespace: "starboard",
+			},
+			Data: map[string]string{
+				vulnerabilityreport.KeyExploitProbabilities: epss,
+			},
+		}
+	}
+
+	t.Run("Should parse catalog again only when ConfigMap changes", func(t *testing.T) {
+		cm := newConfigMap(testExploitProbabilities)
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).WithObjects(cm).Build()
+		cache := vulnerabilityreport.NewExploitCatalogCache(testClient, "starboard")
+
+		first, err := cache.Load(context.TODO())
+		require.NoError(t, err)
+		require.NotNil(t, first)
+		second, err := cache.Load(context.TODO())
+		require.NoError(t, err)
+		assert.Same(t, first, second)
+
+		cm.Data[vulnerabilityreport.KeyExploitProbabilities] = "cve,epss\nCVE-2022-22965,0.5\n"
+		require.NoError(t, testClient.Update(context.TODO(), cm))
+		updated, err := cache.Load(context.TODO())
+		require.NoError(t, err)
+		assert.NotSame(t, first, updated)
+		vulnerability := aquasecurity.Vulnerability{VulnerabilityID: "CVE-2022-22965"}
+		updated.Annotate(&vulnerability)
+		assert.Equal(t, pointer.Float64(0.5), vulnerability.ExploitProbability)
+
+		require.NoError(t, testClient.Delete(context.TODO(), cm))
+		deleted, err := cache.Load(context.TODO())
+		require.NoError(t, err)
+		assert.Nil(t, deleted)
+	})
+
+	t.Run("Should report malformed catalog once", func(t *testing.T) {
+		testClient := fake.NewClientBuilder().WithScheme(starboard.NewScheme()).
+			WithObjects(newConfigMap("cve\nCVE-2022-22965\n")).Build()
+		cache := vulnerabilityreport.NewExploitCatalogCache(testClient, "starboard")
+
+		catalog, err := cache.Load(context.TODO())
+		var invalid *vulnerabilityreport.InvalidExploitCatalogError
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, starboard.ExploitCatalogConfigMapName, invalid.ConfigMap.Name)
+		assert.Nil(t, catalog)
+
+		catalog, err = cache.Load(context.TODO())
+		require.NoError(t, err)
+		assert.Nil(t, catalog)
+	})
+}
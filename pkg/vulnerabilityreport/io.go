@@ -6,6 +6,7 @@ import (
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
 	"github.com/aquasecurity/starboard/pkg/kube"
+	"github.com/google/go-containerregistry/pkg/name"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,9 +29,29 @@ type Writer interface {
 // v1alpha1.VulnerabilityReport objects owned by related Kubernetes objects.
 // For example, if the given owner is a Deployment, but reports are owned by the
 // active ReplicaSet (current revision) this method will return the reports.
+//
+// FindByImageDigest returns the slice of v1alpha1.VulnerabilityReport
+// instances in the given namespace of container images with the given digest
+// or an empty slice if the reports are not found.
+//
+// FindClusterReportByImageDigest returns the v1alpha1.ClusterVulnerabilityReport
+// of the container image with the given digest or nil if the report is not found.
+//
+// FindByImageTag returns the slice of v1alpha1.VulnerabilityReport instances
+// in the given namespace of container images with the registry, repository
+// and tag of the given reference or an empty slice if the reports are not found.
+//
+// FindClusterReportsByImageTag returns the slice of
+// v1alpha1.ClusterVulnerabilityReport instances of container images with the
+// registry, repository and tag of the given reference or an empty slice if the
+// reports are not found.
 type Reader interface {
 	FindByOwner(context.Context, kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error)
 	FindByOwnerInHierarchy(ctx context.Context, object kube.ObjectRef) ([]v1alpha1.VulnerabilityReport, error)
+	FindByImageDigest(ctx context.Context, namespace, digest string) ([]v1alpha1.VulnerabilityReport, error)
+	FindClusterReportByImageDigest(ctx context.Context, digest string) (*v1alpha1.ClusterVulnerabilityReport, error)
+	FindByImageTag(ctx context.Context, namespace string, tag name.Tag) ([]v1alpha1.VulnerabilityReport, error)
+	FindClusterReportsByImageTag(ctx context.Context, tag name.Tag) ([]v1alpha1.ClusterVulnerabilityReport, error)
 }
 
 type ReadWriter interface {
@@ -120,3 +141,80 @@ func (r *readWriter) FindByOwnerInHierarchy(ctx context.Context, owner kube.Obje
 
 	return reports, nil
 }
+
+func (r *readWriter) FindByImageDigest(ctx context.Context, namespace, digest string) ([]v1alpha1.VulnerabilityReport, error) {
+	var list v1alpha1.VulnerabilityReportList
+
+	err := r.List(ctx, &list, client.InNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]v1alpha1.VulnerabilityReport, 0)
+	for _, report := range list.Items {
+		if report.Report.Artifact.Digest == digest {
+			reports = append(reports, *report.DeepCopy())
+		}
+	}
+
+	return reports, nil
+}
+
+func (r *readWriter) FindClusterReportByImageDigest(ctx context.Context, digest string) (*v1alpha1.ClusterVulnerabilityReport, error) {
+	var report v1alpha1.ClusterVulnerabilityReport
+
+	err := r.Get(ctx, client.ObjectKey{Name: GetClusterReportName(digest)}, &report)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return report.DeepCopy(), nil
+}
+
+func (r *readWriter) FindByImageTag(ctx context.Context, namespace string, tag name.Tag) ([]v1alpha1.VulnerabilityReport, error) {
+	var list v1alpha1.VulnerabilityReportList
+
+	err := r.List(ctx, &list, client.InNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]v1alpha1.VulnerabilityReport, 0)
+	for _, report := range list.Items {
+		if matchesImageTag(report.Report, tag) {
+			reports = append(reports, *report.DeepCopy())
+		}
+	}
+
+	return reports, nil
+}
+
+func (r *readWriter) FindClusterReportsByImageTag(ctx context.Context, tag name.Tag) ([]v1alpha1.ClusterVulnerabilityReport, error) {
+	var list v1alpha1.ClusterVulnerabilityReportList
+
+	err := r.List(ctx, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]v1alpha1.ClusterVulnerabilityReport, 0)
+	for _, report := range list.Items {
+		if matchesImageTag(report.Report, tag) {
+			reports = append(reports, *report.DeepCopy())
+		}
+	}
+
+	return reports, nil
+}
+
+// matchesImageTag returns true if the given v1alpha1.VulnerabilityReportData
+// is the scan result of a container image with the registry, repository and
+// tag of the given reference.
+func matchesImageTag(data v1alpha1.VulnerabilityReportData, tag name.Tag) bool {
+	return data.Registry.Server == tag.RegistryStr() &&
+		data.Artifact.Repository == tag.RepositoryStr() &&
+		data.Artifact.Tag == tag.TagStr()
+}
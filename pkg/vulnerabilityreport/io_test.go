@@ -8,6 +8,7 @@ import (
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -259,4 +260,92 @@ func TestNewReadWriter(t *testing.T) {
 		}, reports)
 	})
 
+	t.Run("Should find reports by image digest", func(t *testing.T) {
+		digest := "sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767"
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "my-namespace",
+				Name:      "replicaset-nginx-6d4cf56db6-nginx",
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Artifact: v1alpha1.Artifact{Repository: "library/nginx", Digest: digest},
+			},
+		}, &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "my-namespace",
+				Name:      "replicaset-redis-7d8b4c9f5d-redis",
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Artifact: v1alpha1.Artifact{Repository: "library/redis", Tag: "6"},
+			},
+		}, &v1alpha1.ClusterVulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: vulnerabilityreport.GetClusterReportName(digest),
+			},
+			Report: v1alpha1.VulnerabilityReportData{
+				Artifact: v1alpha1.Artifact{Repository: "library/nginx", Digest: digest},
+			},
+		}).Build()
+		resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+		readWriter := vulnerabilityreport.NewReadWriter(&resolver)
+
+		reports, err := readWriter.FindByImageDigest(context.TODO(), "my-namespace", digest)
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, "replicaset-nginx-6d4cf56db6-nginx", reports[0].Name)
+
+		reports, err = readWriter.FindByImageDigest(context.TODO(), "other-namespace", digest)
+		require.NoError(t, err)
+		assert.Empty(t, reports)
+
+		clusterReport, err := readWriter.FindClusterReportByImageDigest(context.TODO(), digest)
+		require.NoError(t, err)
+		require.NotNil(t, clusterReport)
+		assert.Equal(t, digest, clusterReport.Report.Artifact.Digest)
+
+		clusterReport, err = readWriter.FindClusterReportByImageDigest(context.TODO(), "sha256:0000000000000000000000000000000000000000000000000000000000000000")
+		require.NoError(t, err)
+		assert.Nil(t, clusterReport)
+	})
+
+	t.Run("Should find reports by image tag", func(t *testing.T) {
+		newReportData := func(registry, repository, tag string) v1alpha1.VulnerabilityReportData {
+			return v1alpha1.VulnerabilityReportData{
+				Registry: v1alpha1.Registry{Server: registry},
+				Artifact: v1alpha1.Artifact{Repository: repository, Tag: tag},
+			}
+		}
+		testClient := fake.NewClientBuilder().WithScheme(kubernetesScheme).WithObjects(&v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "my-namespace", Name: "replicaset-nginx-6d4cf56db6-nginx"},
+			Report:     newReportData("index.docker.io", "library/nginx", "1.16"),
+		}, &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "my-namespace", Name: "replicaset-nginx-5c8d4b9f7c-nginx"},
+			Report:     newReportData("index.docker.io", "library/nginx", "1.17"),
+		}, &v1alpha1.VulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "my-namespace", Name: "replicaset-app-7d8b4c9f5d-nginx"},
+			Report:     newReportData("quay.io", "library/nginx", "1.16"),
+		}, &v1alpha1.ClusterVulnerabilityReport{
+			ObjectMeta: metav1.ObjectMeta{Name: "sha256-2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767"},
+			Report:     newReportData("index.docker.io", "library/nginx", "1.16"),
+		}).Build()
+		resolver := kube.NewObjectResolver(testClient, &kube.CompatibleObjectMapper{})
+		readWriter := vulnerabilityreport.NewReadWriter(&resolver)
+		tag, err := name.NewTag("nginx:1.16")
+		require.NoError(t, err)
+
+		reports, err := readWriter.FindByImageTag(context.TODO(), "my-namespace", tag)
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, "replicaset-nginx-6d4cf56db6-nginx", reports[0].Name)
+
+		reports, err = readWriter.FindByImageTag(context.TODO(), "other-namespace", tag)
+		require.NoError(t, err)
+		assert.Empty(t, reports)
+
+		clusterReports, err := readWriter.FindClusterReportsByImageTag(context.TODO(), tag)
+		require.NoError(t, err)
+		require.Len(t, clusterReports, 1)
+		assert.Equal(t, "1.16", clusterReports[0].Report.Artifact.Tag)
+	})
+
 }
@@ -0,0 +1,224 @@
+package vulnerabilityreport
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
+)
+
+const (
+	sectionVulnerabilities   = "Vulnerabilities"
+	sectionMisconfigurations = "Misconfigurations"
+)
+
+// LeadingTextReader is implemented by streams that discard the text preceding
+// a JSON document, such as the one returned by Plugin.GetJSONLogStream.
+type LeadingTextReader interface {
+	GetLeadingString() string
+}
+
+// ScanReportIterator decodes the aquasecurity.ScanReport produced by a cluster
+// scan one resource at a time, so that callers can process each
+// aquasecurity.K8SResourceVulnerability and
+// aquasecurity.K8SResourceMisconfiguration without holding the whole report
+// in memory.
+//
+// Successive calls to the Next method step through the resources. Iteration
+// stops at the end of the report or at the first error, which is then
+// returned by the Err method.
+//
+//	it := NewScanReportIterator(stream)
+//	defer it.Close()
+//	for it.Next() {
+//		if resource, ok := it.Vulnerability(); ok {
+//			// process resource
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle error
+//	}
+type ScanReportIterator struct {
+	stream  io.ReadCloser
+	decoder *json.Decoder
+	started bool
+	section string
+	done    bool
+	err     error
+
+	vulnerability    *aquasecurity.K8SResourceVulnerability
+	misconfiguration *aquasecurity.K8SResourceMisconfiguration
+}
+
+// NewScanReportIterator constructs a new ScanReportIterator reading the JSON
+// report from the given stream.
+func NewScanReportIterator(stream io.ReadCloser) *ScanReportIterator {
+	return &ScanReportIterator{
+		stream:  stream,
+		decoder: json.NewDecoder(stream),
+	}
+}
+
+// Next advances the iterator to the next resource, which will then be
+// available through the Vulnerability or Misconfiguration method. It returns
+// false when the iteration stops.
+func (it *ScanReportIterator) Next() bool {
+	it.vulnerability = nil
+	it.misconfiguration = nil
+	if it.done || it.err != nil {
+		return false
+	}
+	if !it.started {
+		if err := it.expectDelim('{'); err != nil {
+			it.err = err
+			return false
+		}
+		it.started = true
+	}
+	for {
+		if it.section != "" {
+			if it.decoder.More() {
+				it.err = it.decodeResource()
+				return it.err == nil
+			}
+			if err := it.expectDelim(']'); err != nil {
+				it.err = err
+				return false
+			}
+			it.section = ""
+			continue
+		}
+		if !it.decoder.More() {
+			if err := it.expectDelim('}'); err != nil {
+				it.err = err
+				return false
+			}
+			it.done = true
+			return false
+		}
+		if err := it.enterSection(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+}
+
+// Vulnerability returns the current resource if it comes from the
+// vulnerabilities section of the report.
+func (it *ScanReportIterator) Vulnerability() (aquasecurity.K8SResourceVulnerability, bool) {
+	if it.vulnerability == nil {
+		return aquasecurity.K8SResourceVulnerability{}, false
+	}
+	return *it.vulnerability, true
+}
+
+// Misconfiguration returns the current resource if it comes from the
+// misconfigurations section of the report.
+func (it *ScanReportIterator) Misconfiguration() (aquasecurity.K8SResourceMisconfiguration, bool) {
+	if it.misconfiguration == nil {
+		return aquasecurity.K8SResourceMisconfiguration{}, false
+	}
+	return *it.misconfiguration, true
+}
+
+// Err returns the first error encountered by the iterator.
+func (it *ScanReportIterator) Err() error {
+	return it.err
+}
+
+// Errors returns the scanner errors printed before the JSON report, if the
+// underlying stream collects them.
+func (it *ScanReportIterator) Errors() string {
+	if r, ok := it.stream.(LeadingTextReader); ok {
+		return r.GetLeadingString()
+	}
+	return ""
+}
+
+// Close closes the underlying stream.
+func (it *ScanReportIterator) Close() error {
+	return it.stream.Close()
+}
+
+func (it *ScanReportIterator) enterSection() error {
+	token, err := it.decoder.Token()
+	if err != nil {
+		return fmt.Errorf("reading scan report: %w", err)
+	}
+	key, ok := token.(string)
+	if !ok {
+		return fmt.Errorf("reading scan report: unexpected token %v", token)
+	}
+	if key != sectionVulnerabilities && key != sectionMisconfigurations {
+		var ignored json.RawMessage
+		if err := it.decoder.Decode(&ignored); err != nil {
+			return fmt.Errorf("reading scan report: %w", err)
+		}
+		return nil
+	}
+	token, err = it.decoder.Token()
+	if err != nil {
+		return fmt.Errorf("reading scan report: %w", err)
+	}
+	switch token {
+	case nil:
+		return nil
+	case json.Delim('['):
+		it.section = key
+		return nil
+	default:
+		return fmt.Errorf("reading scan report: expected array of %s but got %v", key, token)
+	}
+}
+
+func (it *ScanReportIterator) decodeResource() error {
+	var err error
+	switch it.section {
+	case sectionVulnerabilities:
+		it.vulnerability = &aquasecurity.K8SResourceVulnerability{}
+		err = it.decoder.Decode(it.vulnerability)
+	case sectionMisconfigurations:
+		it.misconfiguration = &aquasecurity.K8SResourceMisconfiguration{}
+		err = it.decoder.Decode(it.misconfiguration)
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s of scan report: %w", it.section, err)
+	}
+	return nil
+}
+
+func (it *ScanReportIterator) expectDelim(delim json.Delim) error {
+	token, err := it.decoder.Token()
+	if err != nil {
+		return fmt.Errorf("reading scan report: %w", err)
+	}
+	if token != delim {
+		return fmt.Errorf("reading scan report: expected %v but got %v", delim, token)
+	}
+	return nil
+}
+
+// ReadTrivyReport drains the given ScanReportIterator into an
+// aquasecurity.TrivyReport. Prefer iterating directly when the report may be
+// large.
+func ReadTrivyReport(it *ScanReportIterator) (*aquasecurity.TrivyReport, error) {
+	report := &aquasecurity.ScanReport{}
+	for it.Next() {
+		if resource, ok := it.Vulnerability(); ok {
+			report.Vulnerabilities = append(report.Vulnerabilities, resource)
+		}
+		if resource, ok := it.Misconfiguration(); ok {
+			report.Misconfigurations = append(report.Misconfigurations, resource)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	trivyReport := &aquasecurity.TrivyReport{
+		Report: report,
+		Errors: it.Errors(),
+	}
+	trivyReport.ParseScanErrors()
+	return trivyReport, nil
+}
@@ -0,0 +1,86 @@
+package vulnerabilityreport_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanReportIterator(t *testing.T) {
+
+	t.Run("Should yield resources one at a time", func(t *testing.T) {
+		it := vulnerabilityreport.NewScanReportIterator(io.NopCloser(strings.NewReader(`{
+  "ClusterName": "kind",
+  "Vulnerabilities": [
+    {"Namespace": "default", "Kind": "Deployment", "Name": "nginx", "Results": [{"Target": "nginx:1.16 (debian 10.3)", "Vulnerabilities": [{"VulnerabilityID": "CVE-2019-1549", "Severity": "MEDIUM"}]}]},
+    {"Namespace": "default", "Kind": "Pod", "Name": "broken", "Error": "unable to scan"}
+  ],
+  "Misconfigurations": [
+    {"Namespace": "default", "Kind": "Deployment", "Name": "nginx", "Results": [{"Target": "Deployment/nginx", "Misconfigurations": [{"ID": "KSV001", "Status": "FAIL"}]}]}
+  ]
+}`)))
+		defer func() {
+			_ = it.Close()
+		}()
+
+		var vulnerabilities []aquasecurity.K8SResourceVulnerability
+		var misconfigurations []aquasecurity.K8SResourceMisconfiguration
+		for it.Next() {
+			if resource, ok := it.Vulnerability(); ok {
+				vulnerabilities = append(vulnerabilities, resource)
+			}
+			if resource, ok := it.Misconfiguration(); ok {
+				misconfigurations = append(misconfigurations, resource)
+			}
+		}
+		require.NoError(t, it.Err())
+		assert.Equal(t, []aquasecurity.K8SResourceVulnerability{
+			{
+				Namespace: "default",
+				Kind:      "Deployment",
+				Name:      "nginx",
+				Results: []aquasecurity.VulnerabilityScanResult{
+					{
+						Target:          "nginx:1.16 (debian 10.3)",
+						Vulnerabilities: []aquasecurity.Vulnerability{{VulnerabilityID: "CVE-2019-1549", Severity: "MEDIUM"}},
+					},
+				},
+			},
+			{Namespace: "default", Kind: "Pod", Name: "broken", Error: "unable to scan"},
+		}, vulnerabilities)
+		assert.Equal(t, []aquasecurity.K8SResourceMisconfiguration{
+			{
+				Namespace: "default",
+				Kind:      "Deployment",
+				Name:      "nginx",
+				Results: []aquasecurity.MisconfigurationScanResult{
+					{
+						Target:            "Deployment/nginx",
+						Misconfigurations: []aquasecurity.Misconfiguration{{ID: "KSV001", Status: "FAIL"}},
+					},
+				},
+			},
+		}, misconfigurations)
+		assert.False(t, it.Next())
+	})
+
+	t.Run("Should accept null sections", func(t *testing.T) {
+		report, err := vulnerabilityreport.ReadTrivyReport(vulnerabilityreport.NewScanReportIterator(
+			io.NopCloser(strings.NewReader(`{"Vulnerabilities": null, "Misconfigurations": []}`))))
+		require.NoError(t, err)
+		assert.Equal(t, &aquasecurity.TrivyReport{Report: &aquasecurity.ScanReport{}}, report)
+	})
+
+	t.Run("Should return error when report is truncated", func(t *testing.T) {
+		it := vulnerabilityreport.NewScanReportIterator(io.NopCloser(strings.NewReader(
+			`{"Vulnerabilities": [{"Namespace": "default", "Kind": "Pod", "Name": "nginx"}, {"Namespace": "def`)))
+		assert.True(t, it.Next())
+		assert.False(t, it.Next())
+		assert.Error(t, it.Err())
+	})
+}
@@ -41,3 +41,18 @@ type Plugin interface {
 
 	GetJSONLogStream(ctx starboard.PluginContext, logsReader io.ReadCloser) (io.ReadCloser, error)
 }
+
+// ReportParser is an optional interface implemented by plugins that can tell
+// whether ParseVulnerabilityReportData returns complete scan results.
+// Incomplete results are written to reports but never stored in the
+// ImageCache, so that they are not reused for other workloads.
+type ReportParser interface {
+	ParsesVulnerabilities() bool
+}
+
+// CanCacheResults returns true unless the given Plugin implements the
+// ReportParser interface and does not return complete scan results.
+func CanCacheResults(plugin Plugin) bool {
+	parser, ok := plugin.(ReportParser)
+	return !ok || parser.ParsesVulnerabilities()
+}
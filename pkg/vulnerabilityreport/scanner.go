@@ -2,14 +2,17 @@ package vulnerabilityreport
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity"
 	"github.com/aquasecurity/starboard/pkg/kube"
 	"github.com/aquasecurity/starboard/pkg/runner"
 	"github.com/aquasecurity/starboard/pkg/starboard"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes"
@@ -20,15 +23,16 @@ import (
 // Scanner is a template for running static vulnerability scanners that implement
 // the Plugin interface.
 type Scanner struct {
-	scheme         *runtime.Scheme
-	clientset      kubernetes.Interface
-	plugin         Plugin
-	pluginContext  starboard.PluginContext
-	objectResolver *kube.ObjectResolver
-	logsReader     kube.LogsReader
-	config         starboard.ConfigData
-	opts           kube.ScannerOpts
-	secretsReader  kube.SecretsReader
+	scheme          *runtime.Scheme
+	clientset       kubernetes.Interface
+	plugin          Plugin
+	pluginContext   starboard.PluginContext
+	objectResolver  *kube.ObjectResolver
+	logsReader      kube.LogsReader
+	config          starboard.ConfigData
+	opts            kube.ScannerOpts
+	secretsReader   kube.SecretsReader
+	resultCollector kube.ResultCollector
 }
 
 // NewScanner constructs a new static vulnerability Scanner with the specified
@@ -46,146 +50,156 @@ func NewScanner(
 ) *Scanner {
 	or := kube.NewObjectResolver(client, cm)
 	return &Scanner{
-		scheme:         client.Scheme(),
-		clientset:      clientset,
-		opts:           opts,
-		plugin:         plugin,
-		pluginContext:  pluginContext,
-		objectResolver: &or,
-		logsReader:     kube.NewLogsReader(clientset),
-		config:         config,
-		secretsReader:  kube.NewSecretsReader(client),
+		scheme:          client.Scheme(),
+		clientset:       clientset,
+		opts:            opts,
+		plugin:          plugin,
+		pluginContext:   pluginContext,
+		objectResolver:  &or,
+		logsReader:      kube.NewLogsReader(clientset),
+		config:          config,
+		secretsReader:   kube.NewSecretsReader(client),
+		resultCollector: kube.NewResultCollector(clientset),
 	}
 }
 
 // Scan creates a Kubernetes job to scan the specified workload. The pod created
 // by the scan job has template contributed by the Plugin.
 // It is a blocking method that watches the status of the job until it succeeds
-// or fails. When succeeded it parses container logs and coverts the output
-// to instances of v1alpha1.VulnerabilityReport by delegating such transformation
-// logic also to the Plugin.
+// or fails. When succeeded it parses the report retrieved from the scan pod and
+// coverts the output to an instance of aquasecurity.TrivyReport.
 func (s *Scanner) Scan(ctx context.Context) (*aquasecurity.TrivyReport, error) {
-	// defer klog.Flush()
-	// klog.InitFlags(nil)
-	// klog.SetOutput(os.Stdout)
-	// klog.V(3).Infof("Getting Pod template for workload: %v", workload)
-
-	// workloadObj, err := s.objectResolver.ObjectFromObjectRef(ctx, workload)
-	// if err != nil {
-	// 	return nil, fmt.Errorf("resolving object: %w", err)
-	// }
-
-	// owner, err := s.objectResolver.ReportOwner(ctx, workloadObj)
-	// if err != nil {
-	// 	return nil, err
-	// }
-
-	scanJobTolerations, err := s.config.GetScanJobTolerations()
+	it, err := s.ScanIterator(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("getting scan job tolerations: %w", err)
+		return nil, err
 	}
-
-	scanJobAnnotations, err := s.config.GetScanJobAnnotations()
+	trivyReport, err := ReadTrivyReport(it)
 	if err != nil {
-		return nil, fmt.Errorf("getting scan job annotations: %w", err)
+		_ = it.Close()
+		return nil, err
 	}
-
-	scanJobPodTemplateLabels, err := s.config.GetScanJobPodTemplateLabels()
+	err = it.Close()
 	if err != nil {
-		return nil, fmt.Errorf("getting scan job template labels: %w", err)
+		return nil, err
 	}
+	if trivyReport.Errors == "" {
+		trivyReport.Errors = it.Errors()
+		trivyReport.ParseScanErrors()
+	}
+	return trivyReport, nil
+}
 
-	klog.V(3).Infof("Scanning with options: %+v", s.opts)
-
-	// credentials, err := s.secretsReader.CredentialsByWorkload(ctx, owner)
-	// if err != nil {
-	// 	return nil, err
-	// }
+// GetScanReportReader creates a Kubernetes job to scan the cluster and returns
+// the JSON report produced by the scan job.
+//
+// If the pod created by the scan job runs the result collector sidecar, the
+// report file is streamed through kube.ResultCollector while the job is
+// running. Closing the returned stream releases the scan pod, waits for the
+// job to complete and deletes it. Otherwise the method waits for the job to
+// complete and falls back to reading the logs of the scanner container.
+func (s *Scanner) GetScanReportReader(ctx context.Context) (io.ReadCloser, error) {
+	job, secrets, err := s.newScanJob()
+	if err != nil {
+		return nil, err
+	}
 
-	job, secrets, err := NewScanJobBuilder().
-		WithPlugin(s.plugin).
-		WithPluginContext(s.pluginContext).
-		WithTimeout(s.opts.ScanJobTimeout). // job will be stopped by kubernetes
-		// WithObject(owner).
-		// WithCredentials(credentials).
-		WithTolerations(scanJobTolerations).
-		WithAnnotations(scanJobAnnotations).
-		WithPodTemplateLabels(scanJobPodTemplateLabels).
-		Get()
+	resultCtx, cancel := context.WithCancel(ctx)
+	completed := make(chan error, 1)
+	go func() {
+		defer cancel()
+		completed <- runner.New().Run(ctx, kube.NewRunnableJob(s.scheme, s.clientset, job, secrets...))
+	}()
 
-	if err != nil {
-		return nil, fmt.Errorf("constructing scan job: %w", err)
+	if !hasResultCollector(job) {
+		err = <-completed
+		if err != nil {
+			return nil, fmt.Errorf("running scan job: %w", err)
+		}
+		defer s.deleteScanJob(ctx, job)
+		klog.V(3).Infof("Scan job completed: %s/%s", job.Namespace, job.Name)
+		return s.getReportStream(ctx, job)
 	}
 
-	err = runner.New().Run(ctx, kube.NewRunnableJob(s.scheme, s.clientset, job, secrets...))
+	stream, err := s.resultCollector.GetResultByJob(resultCtx, job)
 	if err != nil {
+		if errors.Is(err, kube.ErrResultNotProduced) {
+			_ = s.resultCollector.ReleaseByJob(ctx, job)
+		}
+		if jobErr := <-completed; jobErr != nil {
+			err = jobErr
+		}
+		s.deleteScanJob(ctx, job)
 		return nil, fmt.Errorf("running scan job: %w", err)
 	}
 
-	defer func() {
-		if !s.opts.DeleteScanJob {
-			klog.V(3).Infof("Skipping scan job deletion: %s/%s", job.Namespace, job.Name)
-			return
-		}
-		klog.V(3).Infof("Deleting scan job: %s/%s", job.Namespace, job.Name)
-		background := metav1.DeletePropagationBackground
-		_ = s.clientset.BatchV1().Jobs(job.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{
-			PropagationPolicy: &background,
-		})
-	}()
-
-	klog.V(3).Infof("Scan job completed: %s/%s", job.Namespace, job.Name)
-
-	return s.getVulnerabilityReportsByScanJob(ctx, job)
+	return &resultStream{
+		ReadCloser: stream,
+		scanner:    s,
+		ctx:        ctx,
+		job:        job,
+		completed:  completed,
+	}, nil
 }
 
-// TODO To make this method look the same as the one used by the operator we
-// should resolve the owner based on labels set on the given job instead of
-// passing owner directly. The goal is for CLI and operator to create jobs
-// with the same struct and set of labels to reuse code responsible for parsing
-// v1alpha1.VulnerabilityReport instances.
-func (s *Scanner) getVulnerabilityReportsByScanJob(ctx context.Context, job *batchv1.Job) (*aquasecurity.TrivyReport, error) {
-	// var reports []v1alpha1.VulnerabilityReport
-	// podSpecHash, ok := job.Labels[starboard.LabelResourceSpecHash]
-	// if !ok {
-	// 	return nil, fmt.Errorf("expected label %s not set", starboard.LabelResourceSpecHash)
-	// }
-
-	containerName := "trivy"
-
-	logsStream, err := s.logsReader.GetLogsByJobAndContainerName(ctx, job, containerName)
+// ScanIterator runs the scan job the same way as Scan, but instead of decoding
+// the whole report in memory it returns a ScanReportIterator over the report
+// streamed from the scan job. The caller is responsible for closing the
+// returned iterator. Errors printed by the scanner are available once the
+// iterator is closed.
+func (s *Scanner) ScanIterator(ctx context.Context) (*ScanReportIterator, error) {
+	stream, err := s.GetScanReportReader(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if s.opts.RawReportWriter != nil {
+		stream = &teeStream{
+			Reader: io.TeeReader(stream, s.opts.RawReportWriter),
+			stream: stream,
+		}
+	}
+	return NewScanReportIterator(stream), nil
+}
 
-	// TODO: Return error using another go type
-	trivyReport, err := s.plugin.ParseVulnerabilityReportDataNew(s.pluginContext, logsStream)
-	if err != nil {
-		return nil, err
+// teeStream copies the report read from the underlying stream to
+// kube.ScannerOpts.RawReportWriter. Closing it copies the rest of the report,
+// which the ScanReportIterator does not read past the end of the JSON document.
+type teeStream struct {
+	io.Reader
+	stream io.ReadCloser
+}
+
+func (t *teeStream) Close() error {
+	_, err := io.Copy(io.Discard, t.Reader)
+	if closeErr := t.stream.Close(); closeErr != nil {
+		return closeErr
 	}
-	err = logsStream.Close()
 	if err != nil {
-		klog.V(3).Infof("Logstream close error: %v", err)
+		return fmt.Errorf("copying raw report: %w", err)
 	}
-
-	return trivyReport, nil
+	return nil
 }
 
-func (s *Scanner) GetScanReportReader(ctx context.Context) (io.ReadCloser, error) {
+func (t *teeStream) GetLeadingString() string {
+	if r, ok := t.stream.(LeadingTextReader); ok {
+		return r.GetLeadingString()
+	}
+	return ""
+}
 
+func (s *Scanner) newScanJob() (*batchv1.Job, []*corev1.Secret, error) {
 	scanJobTolerations, err := s.config.GetScanJobTolerations()
 	if err != nil {
-		return nil, fmt.Errorf("getting scan job tolerations: %w", err)
+		return nil, nil, fmt.Errorf("getting scan job tolerations: %w", err)
 	}
 
 	scanJobAnnotations, err := s.config.GetScanJobAnnotations()
 	if err != nil {
-		return nil, fmt.Errorf("getting scan job annotations: %w", err)
+		return nil, nil, fmt.Errorf("getting scan job annotations: %w", err)
 	}
 
 	scanJobPodTemplateLabels, err := s.config.GetScanJobPodTemplateLabels()
 	if err != nil {
-		return nil, fmt.Errorf("getting scan job template labels: %w", err)
+		return nil, nil, fmt.Errorf("getting scan job template labels: %w", err)
 	}
 
 	klog.V(3).Infof("Scanning with options: %+v", s.opts)
@@ -194,37 +208,26 @@ func (s *Scanner) GetScanReportReader(ctx context.Context) (io.ReadCloser, error
 		WithPlugin(s.plugin).
 		WithPluginContext(s.pluginContext).
 		WithTimeout(s.opts.ScanJobTimeout). // job will be stopped by kubernetes
-		// WithObject(owner).
-		// WithCredentials(credentials).
 		WithTolerations(scanJobTolerations).
 		WithAnnotations(scanJobAnnotations).
 		WithPodTemplateLabels(scanJobPodTemplateLabels).
 		Get()
-
 	if err != nil {
-		return nil, fmt.Errorf("constructing scan job: %w", err)
+		return nil, nil, fmt.Errorf("constructing scan job: %w", err)
 	}
+	return job, secrets, nil
+}
 
-	err = runner.New().Run(ctx, kube.NewRunnableJob(s.scheme, s.clientset, job, secrets...))
-	if err != nil {
-		return nil, fmt.Errorf("running scan job: %w", err)
+func (s *Scanner) deleteScanJob(ctx context.Context, job *batchv1.Job) {
+	if !s.opts.DeleteScanJob {
+		klog.V(3).Infof("Skipping scan job deletion: %s/%s", job.Namespace, job.Name)
+		return
 	}
-
-	defer func() {
-		if !s.opts.DeleteScanJob {
-			klog.V(3).Infof("Skipping scan job deletion: %s/%s", job.Namespace, job.Name)
-			return
-		}
-		klog.V(3).Infof("Deleting scan job: %s/%s", job.Namespace, job.Name)
-		background := metav1.DeletePropagationBackground
-		_ = s.clientset.BatchV1().Jobs(job.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{
-			PropagationPolicy: &background,
-		})
-	}()
-
-	klog.V(3).Infof("Scan job completed: %s/%s", job.Namespace, job.Name)
-
-	return s.getReportStream(ctx, job)
+	klog.V(3).Infof("Deleting scan job: %s/%s", job.Namespace, job.Name)
+	background := metav1.DeletePropagationBackground
+	_ = s.clientset.BatchV1().Jobs(job.Namespace).Delete(ctx, job.Name, metav1.DeleteOptions{
+		PropagationPolicy: &background,
+	})
 }
 
 func (s *Scanner) getReportStream(ctx context.Context, job *batchv1.Job) (io.ReadCloser, error) {
@@ -243,3 +246,79 @@ func (s *Scanner) getReportStream(ctx context.Context, job *batchv1.Job) (io.Rea
 
 	return reader, nil
 }
+
+// getScannerLogs returns the logs of the scanner containers of the given
+// job, which is where the scanner prints errors and warnings when the report
+// is written to a file.
+func (s *Scanner) getScannerLogs(ctx context.Context, job *batchv1.Job) (string, error) {
+	var logs strings.Builder
+	for _, container := range job.Spec.Template.Spec.Containers {
+		if container.Name == kube.ResultCollectorContainerName {
+			continue
+		}
+		stream, err := s.logsReader.GetLogsByJobAndContainerName(ctx, job, container.Name)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(&logs, stream)
+		_ = stream.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	return logs.String(), nil
+}
+
+func hasResultCollector(job *batchv1.Job) bool {
+	for _, container := range job.Spec.Template.Spec.Containers {
+		if container.Name == kube.ResultCollectorContainerName {
+			return true
+		}
+	}
+	return false
+}
+
+// resultStream is the report streamed by the result collector sidecar. Closing
+// it releases the scan pod and finishes the scan job.
+type resultStream struct {
+	io.ReadCloser
+	scanner   *Scanner
+	ctx       context.Context
+	job       *batchv1.Job
+	completed <-chan error
+	logs      string
+	closed    bool
+}
+
+func (r *resultStream) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	if err := r.ReadCloser.Close(); err != nil {
+		klog.V(3).Infof("Result stream close error: %v", err)
+	}
+	defer r.scanner.deleteScanJob(r.ctx, r.job)
+
+	err := r.scanner.resultCollector.ReleaseByJob(r.ctx, r.job)
+	if err != nil {
+		return fmt.Errorf("releasing scan job: %w", err)
+	}
+	err = <-r.completed
+	if err != nil {
+		return fmt.Errorf("running scan job: %w", err)
+	}
+	klog.V(3).Infof("Scan job completed: %s/%s", r.job.Namespace, r.job.Name)
+
+	r.logs, err = r.scanner.getScannerLogs(r.ctx, r.job)
+	if err != nil {
+		klog.V(3).Infof("Getting scanner logs: %v", err)
+	}
+	return nil
+}
+
+// GetLeadingString returns the logs of the scanner, which are only available
+// once the stream has been closed.
+func (r *resultStream) GetLeadingString() string {
+	return r.logs
+}
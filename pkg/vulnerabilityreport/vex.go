@@ -0,0 +1,422 @@
+package vulnerabilityreport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8sapierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// KeyVEXDocument is the key of a ConfigMap labeled with
+	// starboard.LabelVEXDocument that holds an OpenVEX document.
+	KeyVEXDocument = "openvex.json"
+
+	openVEXContextPrefix = "https://openvex.dev/ns"
+)
+
+// VEXDocument is an OpenVEX document, see https://github.com/openvex/spec.
+// Both the v0.0.x and v0.2.x formats of vulnerabilities and products are
+// supported.
+type VEXDocument struct {
+	Context    string         `json:"@context"`
+	ID         string         `json:"@id"`
+	Author     string         `json:"author,omitempty"`
+	Timestamp  *time.Time     `json:"timestamp,omitempty"`
+	Statements []VEXStatement `json:"statements"`
+}
+
+// VEXStatement states the status of a vulnerability in products.
+type VEXStatement struct {
+	Vulnerability VEXVulnerability   `json:"vulnerability"`
+	Products      []VEXProduct       `json:"products"`
+	Subcomponents []VEXProduct       `json:"subcomponents,omitempty"`
+	Status        v1alpha1.VEXStatus `json:"status"`
+	Justification string             `json:"justification,omitempty"`
+	Timestamp     *time.Time         `json:"timestamp,omitempty"`
+}
+
+// VEXVulnerability identifies the vulnerability of a VEXStatement.
+type VEXVulnerability struct {
+	Name    string   `json:"name"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// UnmarshalJSON accepts the vulnerability name as a string too.
+func (v *VEXVulnerability) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*v = VEXVulnerability{Name: name}
+		return nil
+	}
+	type vulnerability VEXVulnerability
+	return json.Unmarshal(data, (*vulnerability)(v))
+}
+
+// VEXProduct identifies a product, i.e. a container image or a package, by
+// its image reference, digest or package URL (purl).
+type VEXProduct struct {
+	ID            string            `json:"@id"`
+	Hashes        map[string]string `json:"hashes,omitempty"`
+	Subcomponents []VEXProduct      `json:"subcomponents,omitempty"`
+}
+
+// UnmarshalJSON accepts the product identifier as a string too.
+func (p *VEXProduct) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		*p = VEXProduct{ID: id}
+		return nil
+	}
+	type product VEXProduct
+	return json.Unmarshal(data, (*product)(p))
+}
+
+// ParseVEXDocument parses and validates the given OpenVEX document.
+func ParseVEXDocument(r io.Reader) (VEXDocument, error) {
+	var document VEXDocument
+	err := json.NewDecoder(r).Decode(&document)
+	if err != nil {
+		return VEXDocument{}, fmt.Errorf("decoding OpenVEX document: %w", err)
+	}
+	if !strings.HasPrefix(document.Context, openVEXContextPrefix) {
+		return VEXDocument{}, fmt.Errorf("unsupported VEX document context %q, expected %s", document.Context, openVEXContextPrefix)
+	}
+	for i, statement := range document.Statements {
+		if statement.Vulnerability.Name == "" {
+			return VEXDocument{}, fmt.Errorf("statement %d: vulnerability name is required", i)
+		}
+		if len(statement.Products) == 0 {
+			return VEXDocument{}, fmt.Errorf("statement %d: at least one product is required", i)
+		}
+		switch statement.Status {
+		case v1alpha1.VEXStatusNotAffected, v1alpha1.VEXStatusAffected,
+			v1alpha1.VEXStatusFixed, v1alpha1.VEXStatusUnderInvestigation:
+		default:
+			return VEXDocument{}, fmt.Errorf("statement %d: invalid status %q", i, statement.Status)
+		}
+	}
+	return document, nil
+}
+
+// InvalidVEXDocument is a ConfigMap labeled with starboard.LabelVEXDocument
+// that does not hold a valid OpenVEX document.
+type InvalidVEXDocument struct {
+	ConfigMap corev1.ConfigMap
+	Err       error
+}
+
+func (d InvalidVEXDocument) Error() string {
+	return fmt.Sprintf("loading VEX document from configmap %s/%s: %v", d.ConfigMap.Namespace, d.ConfigMap.Name, d.Err)
+}
+
+// LoadVEXDocuments reads OpenVEX documents from ConfigMaps labeled with
+// starboard.LabelVEXDocument in the given namespace, ordered by ConfigMap
+// names. Invalid documents are skipped and returned separately, so that a
+// single malformed ConfigMap does not disable the valid ones.
+func LoadVEXDocuments(ctx context.Context, c client.Reader, namespace string) (VEXDocuments, []InvalidVEXDocument, error) {
+	var list corev1.ConfigMapList
+	err := c.List(ctx, &list, client.InNamespace(namespace), client.HasLabels{starboard.LabelVEXDocument})
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing VEX documents: %w", err)
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].Name < list.Items[j].Name
+	})
+	documents := make(VEXDocuments, 0, len(list.Items))
+	var invalid []InvalidVEXDocument
+	for _, cm := range list.Items {
+		document, err := ParseVEXDocument(strings.NewReader(cm.Data[KeyVEXDocument]))
+		if err != nil {
+			invalid = append(invalid, InvalidVEXDocument{ConfigMap: cm, Err: err})
+			continue
+		}
+		documents = append(documents, document)
+	}
+	return documents, invalid, nil
+}
+
+// GetVEXDocumentConfigMap returns the ConfigMap that stores the given OpenVEX
+// document under the given name.
+func GetVEXDocumentConfigMap(namespace, name string, document []byte) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("vex-%s", name),
+			Namespace: namespace,
+			Labels: map[string]string{
+				starboard.LabelVEXDocument: "true",
+			},
+		},
+		Data: map[string]string{
+			KeyVEXDocument: string(document),
+		},
+	}
+}
+
+// VEXDocuments sets the VEX status of reported vulnerabilities. Nil
+// VEXDocuments annotate nothing.
+type VEXDocuments []VEXDocument
+
+// AnnotateReportData sets the VEX status of all vulnerabilities of the given
+// v1alpha1.VulnerabilityReportData to the status of the most recent matching
+// statement, or clears it if there is none. The summary is recalculated
+// accordingly.
+func (d VEXDocuments) AnnotateReportData(data *v1alpha1.VulnerabilityReportData) {
+	if d == nil {
+		return
+	}
+	for i := range data.Vulnerabilities {
+		vulnerability := &data.Vulnerabilities[i]
+		vulnerability.VEXStatus = d.status(data.Registry, data.Artifact, *vulnerability)
+	}
+	summary := Summarize(data.Vulnerabilities)
+	summary.NoneCount = data.Summary.NoneCount
+	data.Summary = summary
+}
+
+func (d VEXDocuments) status(registry v1alpha1.Registry, artifact v1alpha1.Artifact, vulnerability v1alpha1.Vulnerability) v1alpha1.VEXStatus {
+	var status v1alpha1.VEXStatus
+	var latest time.Time
+	for _, document := range d {
+		for _, statement := range document.Statements {
+			if !statement.matches(registry, artifact, vulnerability) {
+				continue
+			}
+			timestamp := statement.Timestamp
+			if timestamp == nil {
+				timestamp = document.Timestamp
+			}
+			// Later statements win unless they're older than the latest match.
+			if timestamp != nil {
+				if timestamp.Before(latest) {
+					continue
+				}
+				latest = *timestamp
+			}
+			status = statement.Status
+		}
+	}
+	return status
+}
+
+func (s VEXStatement) matches(registry v1alpha1.Registry, artifact v1alpha1.Artifact, vulnerability v1alpha1.Vulnerability) bool {
+	if !s.Vulnerability.matches(vulnerability.VulnerabilityID) {
+		return false
+	}
+	for _, product := range s.Products {
+		if isPackageURL(product.ID) && !strings.HasPrefix(product.ID, "pkg:oci/") {
+			if matchesPackage(product.ID, vulnerability) {
+				return true
+			}
+			continue
+		}
+		if !product.matchesImage(registry, artifact) {
+			continue
+		}
+		var subcomponents []VEXProduct
+		subcomponents = append(subcomponents, product.Subcomponents...)
+		subcomponents = append(subcomponents, s.Subcomponents...)
+		if len(subcomponents) == 0 {
+			return true
+		}
+		for _, subcomponent := range subcomponents {
+			if matchesPackage(subcomponent.ID, vulnerability) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (v VEXVulnerability) matches(vulnerabilityID string) bool {
+	if strings.EqualFold(v.Name, vulnerabilityID) {
+		return true
+	}
+	for _, alias := range v.Aliases {
+		if strings.EqualFold(alias, vulnerabilityID) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesImage returns true if the product identifies the container image
+// with the given digest by an oci purl, an image reference, a digest or a
+// hash.
+func (p VEXProduct) matchesImage(registry v1alpha1.Registry, artifact v1alpha1.Artifact) bool {
+	if artifact.Digest == "" {
+		return false
+	}
+	for algorithm, hash := range p.Hashes {
+		if strings.ReplaceAll(algorithm, "-", "") == "sha256" && "sha256:"+hash == artifact.Digest {
+			return true
+		}
+	}
+	switch {
+	case strings.HasPrefix(p.ID, "pkg:oci/"):
+		purl, err := parsePackageURL(p.ID)
+		if err != nil || purl.version != artifact.Digest {
+			return false
+		}
+		if repositoryURL := purl.qualifiers.Get("repository_url"); repositoryURL != "" {
+			return sameRepository(repositoryURL, registry, artifact)
+		}
+		return true
+	case strings.HasPrefix(p.ID, "sha256:"):
+		return p.ID == artifact.Digest
+	default:
+		ref, err := name.NewDigest(p.ID)
+		if err != nil {
+			return false
+		}
+		return ref.DigestStr() == artifact.Digest && sameRepository(ref.Context().Name(), registry, artifact)
+	}
+}
+
+func sameRepository(repository string, registry v1alpha1.Registry, artifact v1alpha1.Artifact) bool {
+	expected, err := name.NewRepository(repository)
+	if err != nil {
+		return false
+	}
+	actual, err := name.NewRepository(registry.Server + "/" + artifact.Repository)
+	if err != nil {
+		return false
+	}
+	return expected.Name() == actual.Name()
+}
+
+// matchesPackage returns true if the given package URL identifies the
+// vulnerable resource. The version is optional.
+func matchesPackage(id string, vulnerability v1alpha1.Vulnerability) bool {
+	purl, err := parsePackageURL(id)
+	if err != nil {
+		return false
+	}
+	if purl.version != "" && purl.version != vulnerability.InstalledVersion {
+		return false
+	}
+	switch vulnerability.Resource {
+	case purl.name, purl.namespace + "/" + purl.name, purl.namespace + ":" + purl.name:
+		return true
+	}
+	return false
+}
+
+type packageURL struct {
+	typ        string
+	namespace  string
+	name       string
+	version    string
+	qualifiers url.Values
+}
+
+func isPackageURL(id string) bool {
+	return strings.HasPrefix(id, "pkg:")
+}
+
+// parsePackageURL parses the given package URL, see
+// https://github.com/package-url/purl-spec.
+func parsePackageURL(id string) (packageURL, error) {
+	if !isPackageURL(id) {
+		return packageURL{}, errors.New("package URL must start with pkg:")
+	}
+	remainder := strings.TrimPrefix(id, "pkg:")
+	if i := strings.Index(remainder, "#"); i >= 0 {
+		remainder = remainder[:i]
+	}
+	var purl packageURL
+	if i := strings.Index(remainder, "?"); i >= 0 {
+		qualifiers, err := url.ParseQuery(remainder[i+1:])
+		if err != nil {
+			return packageURL{}, err
+		}
+		purl.qualifiers = qualifiers
+		remainder = remainder[:i]
+	}
+	if i := strings.LastIndex(remainder, "@"); i >= 0 {
+		version, err := url.PathUnescape(remainder[i+1:])
+		if err != nil {
+			return packageURL{}, err
+		}
+		purl.version = version
+		remainder = remainder[:i]
+	}
+	segments := strings.Split(strings.Trim(remainder, "/"), "/")
+	if len(segments) < 2 {
+		return packageURL{}, fmt.Errorf("package URL %s must have type and name", id)
+	}
+	for i := range segments {
+		segment, err := url.PathUnescape(segments[i])
+		if err != nil {
+			return packageURL{}, err
+		}
+		segments[i] = segment
+	}
+	purl.typ = strings.ToLower(segments[0])
+	purl.name = segments[len(segments)-1]
+	purl.namespace = strings.Join(segments[1:len(segments)-1], "/")
+	return purl, nil
+}
+
+// UpdateVEXStatus annotates existing v1alpha1.VulnerabilityReport instances
+// in all namespaces and v1alpha1.ClusterVulnerabilityReport instances with the
+// given VEXDocuments. It returns the number of updated reports.
+func UpdateVEXStatus(ctx context.Context, c client.Client, documents VEXDocuments) (int, error) {
+	var reports v1alpha1.VulnerabilityReportList
+	err := c.List(ctx, &reports)
+	if err != nil {
+		return 0, fmt.Errorf("listing vulnerability reports: %w", err)
+	}
+	updated := 0
+	for _, report := range reports.Items {
+		copied := report.DeepCopy()
+		documents.AnnotateReportData(&copied.Report)
+		if equality.Semantic.DeepEqual(copied.Report, report.Report) {
+			continue
+		}
+		err = c.Update(ctx, copied)
+		if err != nil {
+			if k8sapierror.IsNotFound(err) {
+				continue
+			}
+			return updated, fmt.Errorf("updating vulnerability report: %w", err)
+		}
+		updated++
+	}
+
+	var clusterReports v1alpha1.ClusterVulnerabilityReportList
+	err = c.List(ctx, &clusterReports)
+	if err != nil {
+		return updated, fmt.Errorf("listing cluster vulnerability reports: %w", err)
+	}
+	for _, report := range clusterReports.Items {
+		copied := report.DeepCopy()
+		documents.AnnotateReportData(&copied.Report)
+		if equality.Semantic.DeepEqual(copied.Report, report.Report) {
+			continue
+		}
+		err = c.Update(ctx, copied)
+		if err != nil {
+			if k8sapierror.IsNotFound(err) {
+				continue
+			}
+			return updated, fmt.Errorf("updating cluster vulnerability report: %w", err)
+		}
+		updated++
+	}
+	return updated, nil
+}
@@ -0,0 +1,67 @@
+package vulnerabilityreport
+
+import (
+	. "github.com/aquasecurity/starboard/pkg/operator/predicate"
+
+	"context"
+
+	"github.com/aquasecurity/starboard/pkg/operator/etc"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ReasonInvalidVEXDocument is the reason of the event raised when a
+	// ConfigMap does not hold a valid OpenVEX document.
+	ReasonInvalidVEXDocument = "InvalidVEXDocument"
+)
+
+// VEXController watches ConfigMaps that hold OpenVEX documents in the
+// operator namespace and sets the VEX status of vulnerabilities in existing
+// v1alpha1.VulnerabilityReport and v1alpha1.ClusterVulnerabilityReport
+// instances whenever documents are created, updated or deleted. Invalid
+// documents are skipped with a warning event raised for the ConfigMap.
+type VEXController struct {
+	logr.Logger
+	etc.Config
+	client.Client
+	record.EventRecorder
+}
+
+func (r *VEXController) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("vex").
+		For(&corev1.ConfigMap{}, builder.WithPredicates(
+			InNamespace(r.Config.Namespace),
+			IsVEXDocument,
+		)).
+		Complete(r.reconcileVEXDocuments())
+}
+
+func (r *VEXController) reconcileVEXDocuments() reconcile.Func {
+	return func(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+		documents, invalid, err := LoadVEXDocuments(ctx, r.Client, r.Config.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		for _, document := range invalid {
+			r.Logger.Error(document.Err, "Skipping invalid VEX document", "configMap", client.ObjectKeyFromObject(&document.ConfigMap))
+			if r.EventRecorder != nil {
+				r.EventRecorder.Eventf(&document.ConfigMap, corev1.EventTypeWarning, ReasonInvalidVEXDocument,
+					"VEX document skipped: %v", document.Err)
+			}
+		}
+		updated, err := UpdateVEXStatus(ctx, r.Client, documents)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		r.Logger.V(1).Info("Updated VEX status of vulnerability reports", "configMap", req.NamespacedName,
+			"documents", len(documents), "reports", updated)
+		return ctrl.Result{}, nil
+	}
+}
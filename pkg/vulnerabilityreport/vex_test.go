@@ -0,0 +1,233 @@
+package vulnerabilityreport_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/starboard/pkg/apis/aquasecurity/v1alpha1"
+	"github.com/aquasecurity/starboard/pkg/starboard"
+	"github.com/aquasecurity/starboard/pkg/vulnerabilityreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testImageDigest = "sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767"
+
+const testVEXDocument = `{
+  "@context": "https://openvex.dev/ns/v0.2.0",
+  "@id": "https://example.com/vex/nginx-2022-09",
+  "author": "Example Vendor",
+  "timestamp": "2022-09-01T10:00:00Z",
+  "statements": [
+    {
+      "vulnerability": {"name": "CVE-2022-1292"},
+      "products": [
+        {
+          "@id": "pkg:oci/nginx@sha256%3A2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767?repository_url=index.docker.io/library/nginx",
+          "subcomponents": [{"@id": "pkg:deb/debian/openssl@1.1.1n-0+deb11u1"}]
+        }
+      ],
+      "status": "not_affected",
+      "justification": "vulnerable_code_not_in_execute_path"
+    },
+    {
+      "vulnerability": {"name": "CVE-2022-2068"},
+      "products": [{"@id": "docker.io/library/nginx@sha256:2834dc507516af02784808c5f48b7cbe38b8ed5d0f4837f16e78d00deb7e7767"}],
+      "status": "under_investigation"
+    },
+    {
+      "vulnerability": {"name": "GHSA-jfh8-c2jp-5v3q", "aliases": ["CVE-2021-44228"]},
+      "products": ["pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"],
+      "status": "fixed"
+    }
+  ]
+}`
+
+func TestParseVEXDocument(t *testing.T) {
+	t.Run("Should parse document", func(t *testing.T) {
+		document, err := vulnerabilityreport.ParseVEXDocument(strings.NewReader(testVEXDocument))
+		require.NoError(t, err)
+		assert.Equal(t, "Example Vendor", document.Author)
+		require.Len(t, document.Statements, 3)
+		assert.Equal(t, v1alpha1.VEXStatusNotAffected, document.Statements[0].Status)
+		assert.Equal(t, "pkg:deb/debian/openssl@1.1.1n-0+deb11u1", document.Statements[0].Products[0].Subcomponents[0].ID)
+		assert.Equal(t, vulnerabilityreport.VEXProduct{ID: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"},
+			document.Statements[2].Products[0])
+	})
+
+	t.Run("Should parse v0.0.1 document", func(t *testing.T) {
+		document, err := vulnerabilityreport.ParseVEXDocument(strings.NewReader(`{
+  "@context": "https://openvex.dev/ns",
+  "@id": "https://example.com/vex/1",
+  "statements": [
+    {"vulnerability": "CVE-2022-1292", "products": ["` + testImageDigest + `"], "status": "fixed"}
+  ]
+}`))
+		require.NoError(t, err)
+		require.Len(t, document.Statements, 1)
+		assert.Equal(t, "CVE-2022-1292", document.Statements[0].Vulnerability.Name)
+		assert.Equal(t, testImageDigest, document.Statements[0].Products[0].ID)
+	})
+
+	t.Run("Should return error when context is not OpenVEX", func(t *testing.T) {
+		_, err := vulnerabilityreport.ParseVEXDocument(strings.NewReader(`{"@context": "https://cyclonedx.org"}`))
+		assert.EqualError(t, err, `unsupported VEX document context "https://cyclonedx.org", expected https://openvex.dev/ns`)
+	})
+
+	t.Run("Should return error when status is invalid", func(t *testing.T) {
+		_, err := vulnerabilityreport.ParseVEXDocument(strings.NewReader(`{
+  "@context": "https://openvex.dev/ns",
+  "statements": [{"vulnerability": "CVE-2022-1292", "products": ["` + testImageDigest + `"], "status": "wont_fix"}]
+}`))
+		assert.EqualError(t, err, `statement 0: invalid status "wont_fix"`)
+	})
+}
+
+func TestVEXDocuments_AnnotateReportData(t *testing.T) {
+	document, err := vulnerabilityreport.ParseVEXDocument(strings.NewReader(testVEXDocument))
+	require.NoError(t, err)
+
+	newReportData := func(digest string) v1alpha1.VulnerabilityReportData {
+		return v1alpha1.VulnerabilityReportData{
+			Registry: v1alpha1.Registry{Server: "index.docker.io"},
+			Artifact: v1alpha1.Artifact{Repository: "library/nginx", Tag: "1.21", Digest: digest},
+			Summary:  v1alpha1.VulnerabilitySummary{NoneCount: 2},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2022-1292", Resource: "openssl", InstalledVersion: "1.1.1n-0+deb11u1", Severity: v1alpha1.SeverityCritical},
+				{VulnerabilityID: "CVE-2022-1292", Resource: "libssl1.1", InstalledVersion: "1.1.1n-0+deb11u1", Severity: v1alpha1.SeverityCritical},
+				{VulnerabilityID: "CVE-2022-2068", Resource: "openssl", InstalledVersion: "1.1.1n-0+deb11u1", Severity: v1alpha1.SeverityHigh},
+				{VulnerabilityID: "CVE-2021-44228", Resource: "org.apache.logging.log4j:log4j-core", InstalledVersion: "2.14.1", Severity: v1alpha1.SeverityCritical},
+			},
+		}
+	}
+
+	t.Run("Should set VEX status of matching vulnerabilities", func(t *testing.T) {
+		data := newReportData(testImageDigest)
+		vulnerabilityreport.VEXDocuments{document}.AnnotateReportData(&data)
+		assert.Equal(t, []v1alpha1.VEXStatus{
+			v1alpha1.VEXStatusNotAffected,
+			"",
+			v1alpha1.VEXStatusUnderInvestigation,
+			v1alpha1.VEXStatusFixed,
+		}, vexStatuses(data))
+		assert.Equal(t, v1alpha1.VulnerabilitySummary{
+			CriticalCount: 2,
+			HighCount:     1,
+			NoneCount:     2,
+		}, data.Summary)
+	})
+
+	t.Run("Should not match other image digests", func(t *testing.T) {
+		data := newReportData("sha256:0000000000000000000000000000000000000000000000000000000000000000")
+		vulnerabilityreport.VEXDocuments{document}.AnnotateReportData(&data)
+		assert.Equal(t, []v1alpha1.VEXStatus{"", "", "", v1alpha1.VEXStatusFixed}, vexStatuses(data))
+	})
+
+	t.Run("Should clear VEX status of vulnerabilities that no longer match", func(t *testing.T) {
+		data := newReportData(testImageDigest)
+		data.Vulnerabilities[1].VEXStatus = v1alpha1.VEXStatusNotAffected
+		vulnerabilityreport.VEXDocuments{}.AnnotateReportData(&data)
+		assert.Equal(t, []v1alpha1.VEXStatus{"", "", "", ""}, vexStatuses(data))
+		assert.Equal(t, 3, data.Summary.CriticalCount)
+	})
+
+	t.Run("Should prefer most recent statement", func(t *testing.T) {
+		update, err := vulnerabilityreport.ParseVEXDocument(strings.NewReader(`{
+  "@context": "https://openvex.dev/ns/v0.2.0",
+  "timestamp": "2022-10-01T10:00:00Z",
+  "statements": [
+    {"vulnerability": {"name": "CVE-2022-2068"}, "products": [{"@id": "` + testImageDigest + `"}], "status": "affected"}
+  ]
+}`))
+		require.NoError(t, err)
+		data := newReportData(testImageDigest)
+		vulnerabilityreport.VEXDocuments{update, document}.AnnotateReportData(&data)
+		assert.Equal(t, v1alpha1.VEXStatusAffected, data.Vulnerabilities[2].VEXStatus)
+	})
+
+	t.Run("Should do nothing when documents are nil", func(t *testing.T) {
+		data := newReportData(testImageDigest)
+		data.Vulnerabilities[0].VEXStatus = v1alpha1.VEXStatusFixed
+		var documents vulnerabilityreport.VEXDocuments
+		documents.AnnotateReportData(&data)
+		assert.Equal(t, v1alpha1.VEXStatusFixed, data.Vulnerabilities[0].VEXStatus)
+	})
+}
+
+func vexStatuses(data v1alpha1.VulnerabilityReportData) []v1alpha1.VEXStatus {
+	var statuses []v1alpha1.VEXStatus
+	for _, vulnerability := range data.Vulnerabilities {
+		statuses = append(statuses, vulnerability.VEXStatus)
+	}
+	return statuses
+}
+
+func TestLoadVEXDocuments(t *testing.T) {
+	document := vulnerabilityreport.GetVEXDocumentConfigMap("starboard-system", "nginx", []byte(testVEXDocument))
+	unlabeled := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "starboard-system"},
+		Data:       map[string]string{vulnerabilityreport.KeyVEXDocument: "invalid"},
+	}
+	malformed := vulnerabilityreport.GetVEXDocumentConfigMap("starboard-system", "broken", []byte("{"))
+	testClient := fake.NewClientBuilder().
+		WithScheme(starboard.NewScheme()).
+		WithObjects(document, unlabeled, malformed).
+		Build()
+
+	documents, invalid, err := vulnerabilityreport.LoadVEXDocuments(context.TODO(), testClient, "starboard-system")
+	require.NoError(t, err)
+	require.Len(t, documents, 1)
+	assert.Equal(t, "https://example.com/vex/nginx-2022-09", documents[0].ID)
+	require.Len(t, invalid, 1)
+	assert.Equal(t, "vex-broken", invalid[0].ConfigMap.Name)
+	assert.Error(t, invalid[0].Err)
+
+	documents, invalid, err = vulnerabilityreport.LoadVEXDocuments(context.TODO(), testClient, "starboard")
+	require.NoError(t, err)
+	assert.NotNil(t, documents)
+	assert.Empty(t, documents)
+	assert.Empty(t, invalid)
+}
+
+func TestUpdateVEXStatus(t *testing.T) {
+	document, err := vulnerabilityreport.ParseVEXDocument(strings.NewReader(testVEXDocument))
+	require.NoError(t, err)
+	report := &v1alpha1.VulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{Name: "replicaset-nginx-6d4cf56db6-nginx", Namespace: "default"},
+		Report: v1alpha1.VulnerabilityReportData{
+			Summary: v1alpha1.VulnerabilitySummary{CriticalCount: 1},
+			Vulnerabilities: []v1alpha1.Vulnerability{
+				{VulnerabilityID: "CVE-2021-44228", Resource: "org.apache.logging.log4j:log4j-core", InstalledVersion: "2.14.1", Severity: v1alpha1.SeverityCritical},
+			},
+		},
+	}
+	clusterReport := &v1alpha1.ClusterVulnerabilityReport{
+		ObjectMeta: metav1.ObjectMeta{Name: vulnerabilityreport.GetClusterReportName(testImageDigest)},
+		Report:     *report.Report.DeepCopy(),
+	}
+	testClient := fake.NewClientBuilder().
+		WithScheme(starboard.NewScheme()).
+		WithObjects(report, clusterReport).
+		Build()
+
+	updated, err := vulnerabilityreport.UpdateVEXStatus(context.TODO(), testClient, vulnerabilityreport.VEXDocuments{document})
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+
+	updated, err = vulnerabilityreport.UpdateVEXStatus(context.TODO(), testClient, vulnerabilityreport.VEXDocuments{document})
+	require.NoError(t, err)
+	assert.Equal(t, 0, updated)
+
+	var actual v1alpha1.VulnerabilityReport
+	require.NoError(t, testClient.Get(context.TODO(), client.ObjectKeyFromObject(report), &actual))
+	assert.Equal(t, v1alpha1.VEXStatusFixed, actual.Report.Vulnerabilities[0].VEXStatus)
+
+	var actualCluster v1alpha1.ClusterVulnerabilityReport
+	require.NoError(t, testClient.Get(context.TODO(), client.ObjectKeyFromObject(clusterReport), &actualCluster))
+	assert.Equal(t, v1alpha1.VEXStatusFixed, actualCluster.Report.Vulnerabilities[0].VEXStatus)
+}